@@ -0,0 +1,307 @@
+package lit
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/viebiz/lit/iam"
+	"github.com/viebiz/lit/monitoring"
+	"github.com/viebiz/lit/monitoring/instrumenthttp"
+)
+
+const (
+	// defaultResponseBodyLogLimit is the default number of response body bytes kept for logging
+	defaultResponseBodyLogLimit = 4 << 10 // 4KB
+
+	accessLogMessage = "http.incoming_request"
+)
+
+// AccessLogField is a field of the request access log
+type AccessLogField string
+
+const (
+	AccessLogMethod       AccessLogField = "method"
+	AccessLogPath         AccessLogField = "path"
+	AccessLogQuery        AccessLogField = "query"
+	AccessLogRoute        AccessLogField = "route" // Route template, e.g. /orders/:id
+	AccessLogStatus       AccessLogField = "status"
+	AccessLogLatency      AccessLogField = "latency" // Seconds in AccessLogFormatOTel, nanoseconds in AccessLogFormatECS
+	AccessLogClientIP     AccessLogField = "client_ip"
+	AccessLogUserAgent    AccessLogField = "user_agent"
+	AccessLogUserID       AccessLogField = "user_id"  // ID of the user or M2M profile in the request context
+	AccessLogBytesIn      AccessLogField = "bytes_in" // Number of the request body bytes read, e.g. by the handler
+	AccessLogBytesOut     AccessLogField = "bytes_out"
+	AccessLogRequestBody  AccessLogField = "request_body"
+	AccessLogResponseBody AccessLogField = "response_body"
+)
+
+// AccessLogFormat is the preset of the access log field names
+type AccessLogFormat int
+
+const (
+	// AccessLogFormatOTel names the fields by OpenTelemetry semantic conventions
+	AccessLogFormatOTel AccessLogFormat = iota
+
+	// AccessLogFormatECS names the fields by Elastic Common Schema
+	AccessLogFormatECS
+)
+
+var (
+	defaultAccessLogFields = []AccessLogField{
+		AccessLogMethod,
+		AccessLogPath,
+		AccessLogQuery,
+		AccessLogRequestBody,
+		AccessLogStatus,
+		AccessLogBytesOut,
+		AccessLogResponseBody,
+	}
+
+	accessLogFieldNames = map[AccessLogFormat]map[AccessLogField]string{
+		AccessLogFormatOTel: {
+			AccessLogMethod:       "http.request.method",
+			AccessLogPath:         "url.path",
+			AccessLogQuery:        "url.query",
+			AccessLogRoute:        "http.route",
+			AccessLogStatus:       "http.response.status_code",
+			AccessLogLatency:      "http.request.duration", // Seconds, http.server.request.duration is the metric name
+			AccessLogClientIP:     "client.address",
+			AccessLogUserAgent:    "user_agent.original",
+			AccessLogUserID:       "enduser.id",
+			AccessLogBytesIn:      "http.request.body.size",
+			AccessLogBytesOut:     "http.response.body.size",
+			AccessLogRequestBody:  "http.request.body",
+			AccessLogResponseBody: "http.response.body",
+		},
+		AccessLogFormatECS: {
+			AccessLogMethod:       "http.request.method",
+			AccessLogPath:         "url.path",
+			AccessLogQuery:        "url.query",
+			AccessLogRoute:        "http.route",
+			AccessLogStatus:       "http.response.status_code",
+			AccessLogLatency:      "event.duration", // Nanoseconds
+			AccessLogClientIP:     "client.ip",
+			AccessLogUserAgent:    "user_agent.original",
+			AccessLogUserID:       "user.id",
+			AccessLogBytesIn:      "http.request.body.bytes",
+			AccessLogBytesOut:     "http.response.body.bytes",
+			AccessLogRequestBody:  "http.request.body.content",
+			AccessLogResponseBody: "http.response.body.content",
+		},
+	}
+
+	// sampleFunc returns a random number in [0.0, 1.0) to sample the successful requests
+	sampleFunc = rand.Float64
+)
+
+// AccessLogConfig configures the request access log written by the router
+type AccessLogConfig struct {
+	// Format is the preset of the field names
+	// Default: AccessLogFormatOTel
+	Format AccessLogFormat
+
+	// Fields is the list of fields to log
+	// Default: method, path, query, request_body, status, bytes_out, response_body
+	Fields []AccessLogField
+
+	// SkipPaths suppresses the access log of the routes, matched by the route template or the request path.
+	// The endpoint of WithLivenessEndpoint is always skipped
+	SkipPaths []string
+
+	// SampleRate is the fraction of the successful (status < 400) requests to log, in range (0, 1].
+	// Failed requests are always logged
+	// Default: 1
+	SampleRate float64
+
+	// LevelFunc returns the log level of the request by the response status
+	// Default: 5xx as error, 4xx as warn, others as info
+	LevelFunc func(status int) monitoring.Level
+}
+
+// accessLogConfig holds the prepared configuration of the access log written by rootMiddleware
+type accessLogConfig struct {
+	// responseBodyLimit is the maximum number of response body bytes to log, 0 disables response body logging
+	responseBodyLimit int
+
+	format     AccessLogFormat
+	fieldNames map[AccessLogField]string
+	fields     []AccessLogField
+	skipPaths  map[string]bool
+	sampleRate float64
+	levelFunc  func(status int) monitoring.Level
+}
+
+func defaultAccessLogConfig() accessLogConfig {
+	return accessLogConfig{
+		responseBodyLimit: defaultResponseBodyLogLimit,
+		format:            AccessLogFormatOTel,
+		fieldNames:        accessLogFieldNames[AccessLogFormatOTel],
+		fields:            defaultAccessLogFields,
+		sampleRate:        1,
+		levelFunc:         defaultAccessLogLevel,
+	}
+}
+
+// WithAccessLog configures the request access log
+//
+// Example:
+//
+//	r := lit.NewRouter(ctx,
+//		lit.WithLivenessEndpoint("/alive"),
+//		lit.WithAccessLog(lit.AccessLogConfig{
+//			Format:     lit.AccessLogFormatECS,
+//			Fields:     []lit.AccessLogField{lit.AccessLogMethod, lit.AccessLogRoute, lit.AccessLogStatus, lit.AccessLogLatency},
+//			SkipPaths:  []string{"/metrics"},
+//			SampleRate: 0.1,
+//		}),
+//	)
+func WithAccessLog(cfg AccessLogConfig) RouterOption {
+	return func(r Router) {
+		rt, ok := r.(*router)
+		if !ok {
+			return
+		}
+
+		if names, exists := accessLogFieldNames[cfg.Format]; exists {
+			rt.accessLog.format = cfg.Format
+			rt.accessLog.fieldNames = names
+		}
+
+		if len(cfg.Fields) > 0 {
+			rt.accessLog.fields = cfg.Fields
+		}
+
+		for _, path := range cfg.SkipPaths {
+			rt.accessLog.skipPath(path)
+		}
+
+		if cfg.SampleRate > 0 && cfg.SampleRate <= 1 {
+			rt.accessLog.sampleRate = cfg.SampleRate
+		}
+
+		if cfg.LevelFunc != nil {
+			rt.accessLog.levelFunc = cfg.LevelFunc
+		}
+	}
+}
+
+// WithResponseBodyLogLimit sets the maximum number of response body bytes kept in the request log.
+// The remaining bytes are still sent to the client but the logged body is marked as truncated.
+// Set limit to 0 to disable response body logging
+func WithResponseBodyLogLimit(limit int) RouterOption {
+	return func(r Router) {
+		if rt, ok := r.(*router); ok {
+			rt.accessLog.responseBodyLimit = max(limit, 0)
+		}
+	}
+}
+
+// skipPath suppresses the access log of the route template or request path
+func (cfg *accessLogConfig) skipPath(path string) {
+	if cfg.skipPaths == nil {
+		cfg.skipPaths = map[string]bool{}
+	}
+
+	cfg.skipPaths[path] = true
+}
+
+func defaultAccessLogLevel(status int) monitoring.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return monitoring.ErrorLevel
+	case status >= http.StatusBadRequest:
+		return monitoring.WarnLevel
+	default:
+		return monitoring.InfoLevel
+	}
+}
+
+// shouldLog reports whether the request should be logged by the suppression and sampling rules
+func (cfg accessLogConfig) shouldLog(c Context, status int) bool {
+	if cfg.skipPaths[c.FullPath()] || cfg.skipPaths[c.Request().URL.Path] {
+		return false
+	}
+
+	if status < http.StatusBadRequest && cfg.sampleRate < 1 {
+		return sampleFunc() < cfg.sampleRate
+	}
+
+	return true
+}
+
+// logIncomingRequest logs one combined record of the request and the captured response
+func logIncomingRequest(c Context, cfg accessLogConfig, reqMeta instrumenthttp.RequestMetadata, w *captureWriter, body *countingBody, startTime time.Time) {
+	status := c.Writer().Status()
+	if !cfg.shouldLog(c, status) {
+		return
+	}
+
+	ctx := c.Request().Context()
+	logFields := make([]monitoring.Field, 0, len(cfg.fields)+1)
+	for _, field := range cfg.fields {
+		key := cfg.fieldNames[field]
+		switch field {
+		case AccessLogMethod:
+			logFields = append(logFields, monitoring.StringField(key, reqMeta.Method))
+		case AccessLogPath:
+			logFields = append(logFields, monitoring.StringField(key, reqMeta.Path))
+		case AccessLogQuery:
+			logFields = append(logFields, monitoring.StringField(key, reqMeta.Query))
+		case AccessLogRoute:
+			logFields = append(logFields, monitoring.StringField(key, c.FullPath()))
+		case AccessLogStatus:
+			logFields = append(logFields, monitoring.IntField(key, status))
+		case AccessLogLatency:
+			latency := time.Since(startTime)
+			if cfg.format == AccessLogFormatECS {
+				logFields = append(logFields, monitoring.IntField(key, int(latency.Nanoseconds())))
+			} else {
+				logFields = append(logFields, monitoring.Float64Field(key, latency.Seconds()))
+			}
+		case AccessLogClientIP:
+			logFields = append(logFields, monitoring.StringField(key, c.ClientIP()))
+		case AccessLogUserAgent:
+			logFields = append(logFields, monitoring.StringField(key, c.Request().UserAgent()))
+		case AccessLogUserID:
+			if id := requestUserID(c); id != "" {
+				logFields = append(logFields, monitoring.StringField(key, id))
+			}
+		case AccessLogBytesIn:
+			logFields = append(logFields, monitoring.IntField(key, int(body.size)))
+		case AccessLogBytesOut:
+			logFields = append(logFields, monitoring.IntField(key, c.Writer().Size()))
+		case AccessLogRequestBody:
+			logFields = append(logFields, monitoring.JSONField(key, reqMeta.BodyToLog))
+		case AccessLogResponseBody:
+			if _, skip := c.Get(SkipLoggingResponseBodyKey); !skip {
+				logFields = w.bodyLogFields(logFields, key)
+			}
+		}
+	}
+
+	monitoring.FromContext(ctx).Log(cfg.levelFunc(status), accessLogMessage, logFields...)
+}
+
+// requestUserID returns the ID of the authenticated user or M2M client
+func requestUserID(c Context) string {
+	ctx := c.Request().Context()
+	if id := iam.GetUserProfileFromContext(ctx).ID(); id != "" {
+		return id
+	}
+
+	return iam.GetM2MProfileFromContext(ctx).ID()
+}
+
+// countingBody counts the request body bytes read, the Content-Length is unknown for chunked requests
+type countingBody struct {
+	io.ReadCloser
+	size int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+	return n, err
+}
@@ -0,0 +1,265 @@
+package lit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit/iam"
+	"github.com/viebiz/lit/monitoring"
+	"github.com/viebiz/lit/monitoring/tracing/mocktracer"
+)
+
+func TestAccessLog(t *testing.T) {
+	tp := mocktracer.Start()
+	defer tp.Stop()
+
+	tcs := map[string]struct {
+		givenConfig   AccessLogConfig
+		givenTarget   string
+		givenStatus   int
+		givenChunked  bool
+		expLogged     bool
+		expLevel      string
+		expFields     map[string]interface{}
+		expNoFields   []string
+		expDuration   string
+		expDurationNs bool
+	}{
+		"otel - selected fields": {
+			givenConfig: AccessLogConfig{
+				Fields: []AccessLogField{AccessLogMethod, AccessLogRoute, AccessLogStatus, AccessLogLatency, AccessLogClientIP, AccessLogUserAgent, AccessLogUserID, AccessLogBytesIn},
+			},
+			givenTarget: "/orders/10",
+			givenStatus: http.StatusOK,
+			expLogged:   true,
+			expLevel:    "INFO",
+			expFields: map[string]interface{}{
+				"http.request.method":       "POST",
+				"http.route":                "/orders/:id",
+				"http.response.status_code": float64(200),
+				"client.address":            "192.0.2.1",
+				"user_agent.original":       "lit-test",
+				"enduser.id":                "user-1",
+				"http.request.body.size":    float64(17),
+			},
+			expNoFields: []string{"url.path", "http.request.body", "http.response.body"},
+			expDuration: "http.request.duration",
+		},
+		"otel - chunked request body size": {
+			givenConfig:  AccessLogConfig{Fields: []AccessLogField{AccessLogBytesIn}},
+			givenTarget:  "/orders/10",
+			givenStatus:  http.StatusOK,
+			givenChunked: true,
+			expLogged:    true,
+			expLevel:     "INFO",
+			expFields: map[string]interface{}{
+				"http.request.body.size": float64(17),
+			},
+		},
+		"ecs - field names": {
+			givenConfig: AccessLogConfig{
+				Format: AccessLogFormatECS,
+				Fields: []AccessLogField{AccessLogPath, AccessLogStatus, AccessLogLatency, AccessLogClientIP, AccessLogUserID, AccessLogBytesOut, AccessLogResponseBody},
+			},
+			givenTarget: "/orders/10",
+			givenStatus: http.StatusOK,
+			expLogged:   true,
+			expLevel:    "INFO",
+			expFields: map[string]interface{}{
+				"url.path":                   "/orders/10",
+				"http.response.status_code":  float64(200),
+				"client.ip":                  "192.0.2.1",
+				"user.id":                    "user-1",
+				"http.response.body.bytes":   float64(2),
+				"http.response.body.content": "ok",
+			},
+			expDuration:   "event.duration",
+			expDurationNs: true,
+		},
+		"skip path - route template": {
+			givenConfig: AccessLogConfig{SkipPaths: []string{"/orders/:id"}},
+			givenTarget: "/orders/10",
+			givenStatus: http.StatusOK,
+		},
+		"skip path - request path": {
+			givenConfig: AccessLogConfig{SkipPaths: []string{"/orders/10"}},
+			givenTarget: "/orders/10",
+			givenStatus: http.StatusInternalServerError,
+		},
+		"level - 4xx as warn": {
+			givenTarget: "/orders/10",
+			givenStatus: http.StatusNotFound,
+			expLogged:   true,
+			expLevel:    "WARN",
+		},
+		"level - 5xx as error": {
+			givenTarget: "/orders/10",
+			givenStatus: http.StatusServiceUnavailable,
+			expLogged:   true,
+			expLevel:    "ERROR",
+		},
+		"level - custom": {
+			givenConfig: AccessLogConfig{
+				LevelFunc: func(status int) monitoring.Level {
+					return monitoring.InfoLevel
+				},
+			},
+			givenTarget: "/orders/10",
+			givenStatus: http.StatusServiceUnavailable,
+			expLogged:   true,
+			expLevel:    "INFO",
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			parsedLogs := serveAccessLog(t, tc.givenConfig, tc.givenTarget, tc.givenStatus, tc.givenChunked)
+
+			// Then
+			if !tc.expLogged {
+				require.Empty(t, parsedLogs)
+				return
+			}
+
+			require.Len(t, parsedLogs, 1)
+			require.Equal(t, "http.incoming_request", parsedLogs[0]["msg"])
+			require.Equal(t, tc.expLevel, parsedLogs[0]["level"])
+			for k, v := range tc.expFields {
+				require.Equal(t, v, parsedLogs[0][k], k)
+			}
+			for _, k := range tc.expNoFields {
+				require.NotContains(t, parsedLogs[0], k)
+			}
+			if tc.expDuration != "" {
+				duration, ok := parsedLogs[0][tc.expDuration].(float64)
+				require.True(t, ok)
+				require.Greater(t, duration, float64(0))
+				if tc.expDurationNs {
+					require.Equal(t, float64(int64(duration)), duration)
+				}
+			}
+		})
+	}
+}
+
+func TestAccessLog_Sampling(t *testing.T) {
+	tp := mocktracer.Start()
+	defer tp.Stop()
+
+	defer func(f func() float64) { sampleFunc = f }(sampleFunc)
+
+	tcs := map[string]struct {
+		givenSample float64
+		givenStatus int
+		expLogged   bool
+	}{
+		"success - sampled": {
+			givenSample: 0.05,
+			givenStatus: http.StatusOK,
+			expLogged:   true,
+		},
+		"success - dropped": {
+			givenSample: 0.5,
+			givenStatus: http.StatusOK,
+		},
+		"failure - always logged": {
+			givenSample: 0.5,
+			givenStatus: http.StatusBadRequest,
+			expLogged:   true,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			sampleFunc = func() float64 { return tc.givenSample }
+
+			// When
+			parsedLogs := serveAccessLog(t, AccessLogConfig{SampleRate: 0.1}, "/orders/10", tc.givenStatus, false)
+
+			// Then
+			require.Equal(t, tc.expLogged, len(parsedLogs) == 1)
+		})
+	}
+}
+
+func TestWithAccessLog(t *testing.T) {
+	r := NewRouter(context.Background(), WithAccessLog(AccessLogConfig{
+		Format:     AccessLogFormatECS,
+		Fields:     []AccessLogField{AccessLogStatus},
+		SkipPaths:  []string{"/alive"},
+		SampleRate: 0.2,
+	}))
+	cfg := r.(*router).accessLog
+	require.Equal(t, AccessLogFormatECS, cfg.format)
+	require.Equal(t, "event.duration", cfg.fieldNames[AccessLogLatency])
+	require.Equal(t, []AccessLogField{AccessLogStatus}, cfg.fields)
+	require.Equal(t, map[string]bool{"/alive": true}, cfg.skipPaths)
+	require.Equal(t, 0.2, cfg.sampleRate)
+	require.Equal(t, defaultResponseBodyLogLimit, cfg.responseBodyLimit)
+
+	// The liveness endpoint is always skipped
+	r = NewRouter(context.Background(), WithLivenessEndpoint("/healthz"), WithAccessLog(AccessLogConfig{SkipPaths: []string{"/metrics"}}))
+	cfg = r.(*router).accessLog
+	require.Equal(t, map[string]bool{"/healthz": true, "/metrics": true}, cfg.skipPaths)
+
+	// Invalid values keep the defaults
+	r = NewRouter(context.Background(), WithAccessLog(AccessLogConfig{Format: AccessLogFormat(9), SampleRate: 2}))
+	cfg = r.(*router).accessLog
+	require.Equal(t, AccessLogFormatOTel, cfg.format)
+	require.Equal(t, defaultAccessLogFields, cfg.fields)
+	require.Equal(t, float64(1), cfg.sampleRate)
+	require.NotNil(t, cfg.levelFunc)
+}
+
+// serveAccessLog serves a request to POST /orders/:id with the access log config and returns the logs,
+// the request body is sent without Content-Length when chunked is set
+func serveAccessLog(t *testing.T, accessLogCfg AccessLogConfig, target string, status int, chunked bool) []map[string]interface{} {
+	logBuffer := bytes.NewBuffer(nil)
+	m, err := monitoring.New(monitoring.Config{ServerName: "lightning", Environment: "dev", Version: "1.0.0", Writer: logBuffer})
+	require.NoError(t, err)
+	monitorCtx := monitoring.SetInContext(context.Background(), m)
+
+	cfg := defaultAccessLogConfig()
+	rt := &router{accessLog: cfg}
+	WithAccessLog(accessLogCfg)(rt)
+
+	w := httptest.NewRecorder()
+	r, ctx, handleRequest := NewRouterForTest(w)
+	r.Use(rootMiddleware(monitorCtx, rt.accessLog))
+	r.Post("/orders/:id", func(c Context) error {
+		c.SetRequestContext(iam.SetUserProfileInContext(c.Request().Context(), iam.NewUserProfile("user-1", nil, nil)))
+		_, err := io.ReadAll(c.Request().Body)
+		require.NoError(t, err)
+		return c.String(status, "ok")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{"id":"order-10"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "lit-test")
+	if chunked {
+		req.ContentLength = -1
+		req.TransferEncoding = []string{"chunked"}
+	}
+	ctx.SetRequest(req)
+
+	// When
+	handleRequest()
+
+	parsedLogs, err := parseLog(logBuffer.Bytes(), 2) // Skip 2 init log
+	require.NoError(t, err)
+
+	return parsedLogs
+}
@@ -2,6 +2,7 @@ package lit
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"os"
 	"testing"
@@ -100,11 +101,63 @@ func BenchmarkMiddlewareRoot(b *testing.B) {
 
 	gin.SetMode(gin.ReleaseMode)
 	r := newRouter(gin.New())
-	r.Use(rootMiddleware(ctx))
+	r.Use(rootMiddleware(ctx, defaultAccessLogConfig()))
 	r.Get("/ping", func(c Context) error { return nil })
 	runRequest(b, r.Handler(), http.MethodGet, "/ping")
 }
 
+func BenchmarkMiddlewareChain(b *testing.B) {
+	gin.SetMode(gin.ReleaseMode)
+	r := newRouter(gin.New())
+	nextHandler := func(c Context) error {
+		c.Next()
+		return nil
+	}
+	r.Use(nextHandler, nextHandler, nextHandler)
+	r.Get("/ping", func(c Context) error { return nil }, nextHandler, nextHandler)
+	runRequest(b, r.Handler(), http.MethodGet, "/ping")
+}
+
+var benchMonitor *monitoring.Monitor
+
+func BenchmarkMonitorWithTag(b *testing.B) {
+	monitor, err := monitoring.New(monitoring.Config{
+		ServerName: "lit", Environment: "dev", Writer: io.Discard,
+	})
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchMonitor = monitor.WithTag("user_id", "ID-00001")
+	}
+}
+
+// BenchmarkMonitorRequest simulates the tags derivation and logs of a request:
+// request tags by the root middleware, tracing tags by a segment, then several log lines
+func BenchmarkMonitorRequest(b *testing.B) {
+	monitor, err := monitoring.New(monitoring.Config{
+		ServerName: "lit", Environment: "dev", Writer: io.Discard,
+	})
+	require.NoError(b, err)
+	reqTags := map[string]string{
+		"http.request.method": http.MethodGet,
+		"url.path":            "/ping",
+		"trace_id":            "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":             "00f067aa0ba902b7",
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m := monitor.With(reqTags).WithTag("span_id", "a2fb4a1d1a96d312")
+		m.Info("[incoming_request] Wrote response")
+		m.Info("Processing order")
+		m.Info("Processed order")
+		m.Info("http.incoming_request")
+	}
+}
+
 type mockWriter struct {
 	headers http.Header
 }
@@ -1,25 +1,276 @@
 package lit
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
+	"sync"
 
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	"github.com/viebiz/lit/i18n"
 )
 
-// Bind binds the incoming request body and URI parameters to the provided object.
+const (
+	unknownFieldMessageID = "unknown_field"
+	jsonUnknownFieldError = "json: unknown field "
+
+	// multipartMemory is the memory limit of the parsed multipart form, the rest is stored in temporary files
+	multipartMemory = 32 << 20
+)
+
+var (
+	// fieldNameTags are the struct tags used to name a field in ValidationError, by priority
+	fieldNameTags = []string{"json", "form", "uri", "header", "cookie"}
+
+	// crossFieldTags are the validation tags that compare the field with another field
+	crossFieldTags = map[string]string{
+		"eqfield":    "equal to",
+		"nefield":    "not equal to",
+		"gtfield":    "greater than",
+		"gtefield":   "greater than or equal to",
+		"ltfield":    "less than",
+		"ltefield":   "less than or equal to",
+		"eqcsfield":  "equal to",
+		"necsfield":  "not equal to",
+		"gtcsfield":  "greater than",
+		"gtecsfield": "greater than or equal to",
+		"ltcsfield":  "less than",
+		"ltecsfield": "less than or equal to",
+	}
+
+	// bindValidator validates the bound objects by `binding` tags, it's private so the field name resolver
+	// and custom validations don't leak into the shared validator of gin
+	bindValidator = sync.OnceValue(func() *validator.Validate {
+		v := validator.New()
+		v.SetTagName("binding")
+		v.RegisterTagNameFunc(fieldName)
+		return v
+	})
+
+	customMessageIDs sync.Map // map[tag]messageID of custom validations
+)
+
+// BindOption alters the behaviour of Context.Bind
+type BindOption func(*bindConfig)
+
+type bindConfig struct {
+	disallowUnknownFields bool
+}
+
+// BindDisallowUnknownFields rejects JSON request body that contains fields not declared in the object
+func BindDisallowUnknownFields() BindOption {
+	return func(cfg *bindConfig) {
+		cfg.disallowUnknownFields = true
+	}
+}
+
+// RegisterValidation adds a custom validation tag, the error message is localized by messageID.
+// It should be called at the initialization time, before serving any request
+//
+// Usage:
+//
+//	lit.RegisterValidation("sku", func(fl validator.FieldLevel) bool {
+//		return skuRegex.MatchString(fl.Field().String())
+//	}, "invalid_sku")
+func RegisterValidation(tag string, fn validator.Func, messageID string) error {
+	if err := bindValidator().RegisterValidation(tag, fn); err != nil {
+		return err
+	}
+
+	customMessageIDs.Store(tag, messageID)
+	return nil
+}
+
+// Bind binds the incoming request URI parameters, headers, cookies and body to the provided object.
 // Return error if the got error when binding and validating the object.
+// Fields are bound by `uri`, `header`, `cookie`, `json` and `form` tags, and the ValidationError is keyed by the JSON path of the field
 // For more about validation tags refer to: `https://pkg.go.dev/github.com/go-playground/validator/v10#hdr-Baked_In_Validators_and_Tags`
-func (c litContext) Bind(obj interface{}) error {
-	if err := c.Context.ShouldBindUri(obj); err != nil {
-		var vErr validator.ValidationErrors
-		if !errors.As(err, &vErr) {
+func (c litContext) Bind(obj interface{}, opts ...BindOption) error {
+	var cfg bindConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// 1. Bind URI, headers and cookies, only the fields tagged by the source are bound
+	req := c.Request()
+	if err := mapTaggedValues(obj, "uri", func(name string) []string {
+		if value, ok := c.Context.Params.Get(name); ok {
+			return []string{value}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := mapTaggedValues(obj, "header", req.Header.Values); err != nil {
+		return err
+	}
+
+	cookies := cookieValues(req)
+	if err := mapTaggedValues(obj, "cookie", func(name string) []string {
+		return cookies[name]
+	}); err != nil {
+		return err
+	}
+
+	// 2. Bind body and validate the object
+	if err := c.bindBody(obj, cfg); err != nil {
+		return convertValidationErr(c, obj, err)
+	}
+
+	if err := validateStruct(obj); err != nil {
+		return convertValidationErr(c, obj, err)
+	}
+
+	return nil
+}
+
+// bindBody decodes the request body by its content type without validating the object, the query is bound when there is no body
+func (c litContext) bindBody(obj interface{}, cfg bindConfig) error {
+	req := c.Request()
+	switch b := binding.Default(req.Method, c.Context.ContentType()); b {
+	case binding.JSON:
+		if req.Body == nil {
+			return errors.New("invalid request")
+		}
+
+		decoder := json.NewDecoder(req.Body)
+		if cfg.disallowUnknownFields {
+			decoder.DisallowUnknownFields()
+		}
+		return decoder.Decode(obj)
+	case binding.XML:
+		if req.Body == nil {
+			return errors.New("invalid request")
+		}
+
+		return xml.NewDecoder(req.Body).Decode(obj)
+	case binding.Form, binding.FormMultipart:
+		if err := req.ParseForm(); err != nil {
+			return err
+		}
+		if err := req.ParseMultipartForm(multipartMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+			return err
+		}
+
+		return binding.MapFormWithTag(obj, req.Form, "form")
+	default:
+		// The other formats are decoded by gin, its validation errors are ignored as the object is validated by bindValidator
+		if err := b.Bind(req, obj); err != nil && !isValidationErr(err) {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// validateStruct validates the struct, pointer to struct or the slice of structs by bindValidator
+func validateStruct(obj interface{}) error {
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return bindValidator().Struct(v.Interface())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateStruct(v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// mapTaggedValues binds the values into the fields explicitly tagged by tag, e.g. `header:"X-Tenant-ID"`.
+// Unlike binding.MapFormWithTag, it never falls back to the field name, so a request can't set the untagged fields
+func mapTaggedValues(obj interface{}, tag string, lookup func(name string) []string) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return nil
+	}
+
+	return mapTaggedStruct(v.Elem(), tag, lookup)
+}
+
+func mapTaggedStruct(v reflect.Value, tag string, lookup func(name string) []string) error {
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		field := v.Field(i)
+		if !field.CanSet() && !sf.Anonymous {
+			continue
+		}
+
+		name, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			// Untagged fields are never bound, only the nested structs are walked
+			if err := mapTaggedNested(field, tag, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.CanSet() {
+			continue
+		}
+
+		// Bind the single field by gin to keep its conversions and tag options, e.g. `default` and `time_format`
+		holder := reflect.New(reflect.StructOf([]reflect.StructField{{Name: "Value", Type: sf.Type, Tag: sf.Tag}})).Elem()
+		holder.Field(0).Set(field)
+
+		values := map[string][]string{}
+		if vals := lookup(name); len(vals) > 0 {
+			values[name] = vals
+		}
+
+		if err := binding.MapFormWithTag(holder.Addr().Interface(), values, tag); err != nil {
 			return err
 		}
+
+		field.Set(holder.Field(0))
 	}
-	if err := c.Context.ShouldBind(obj); err != nil {
-		return convertValidationErr(c, err)
+
+	return nil
+}
+
+func mapTaggedNested(field reflect.Value, tag string, lookup func(name string) []string) error {
+	switch {
+	case field.Kind() == reflect.Struct:
+		return mapTaggedStruct(field, tag, lookup)
+	case field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.Struct:
+		if !field.IsNil() {
+			return mapTaggedStruct(field.Elem(), tag, lookup)
+		}
+
+		if !field.CanSet() {
+			return nil
+		}
+
+		// Only keep the allocated struct if any field is bound
+		nested := reflect.New(field.Type().Elem())
+		if err := mapTaggedStruct(nested.Elem(), tag, lookup); err != nil {
+			return err
+		}
+		if !nested.Elem().IsZero() {
+			field.Set(nested)
+		}
 	}
 
 	return nil
@@ -40,20 +291,127 @@ func (v ValidationError) StatusCode() int {
 	return http.StatusBadRequest
 }
 
-func convertValidationErr(ctx Context, err error) error {
+func convertValidationErr(ctx Context, obj interface{}, err error) error {
+	localize := i18n.FromContext(ctx)
+
+	// Unknown field is rejected by the JSON decoder
+	if field, found := strings.CutPrefix(err.Error(), jsonUnknownFieldError); found {
+		field = strings.Trim(field, `"`)
+		return ValidationError{
+			field: localize.Localize(unknownFieldMessageID, map[string]interface{}{
+				"Field": field,
+			}),
+		}
+	}
+
 	var validationErrs validator.ValidationErrors
 	if !errors.As(err, &validationErrs) {
 		return err
 	}
 
-	localize := i18n.FromContext(ctx)
+	rootType := indirectType(reflect.TypeOf(obj))
 	errs := make(ValidationError, len(validationErrs))
 	for _, e := range validationErrs {
-		errs[e.Field()] = localize.Localize(e.Tag(), map[string]interface{}{
+		messageID := e.Tag()
+		if id, ok := customMessageIDs.Load(e.Tag()); ok {
+			messageID = id.(string)
+		}
+
+		params := map[string]interface{}{
 			"Field":     e.Field(),
 			"Value":     e.Value(),
 			"Condition": e.Param(),
-		})
+		}
+
+		relation, isCrossField := crossFieldTags[e.Tag()]
+		if isCrossField {
+			params["Condition"] = referencedFieldName(rootType, e.StructNamespace(), e.Tag(), e.Param())
+		}
+
+		msg := localize.Localize(messageID, params)
+		if isCrossField && msg == messageID {
+			// Message is not translated, fallback to a readable message that includes the referenced field
+			msg = fmt.Sprintf("%s must be %s %s", e.Field(), relation, params["Condition"])
+		}
+
+		errs[jsonPath(e.Namespace())] = msg
 	}
 	return errs
 }
+
+func isValidationErr(err error) bool {
+	var vErr validator.ValidationErrors
+	return errors.As(err, &vErr)
+}
+
+func cookieValues(r *http.Request) map[string][]string {
+	values := map[string][]string{}
+	for _, cookie := range r.Cookies() {
+		values[cookie.Name] = append(values[cookie.Name], cookie.Value)
+	}
+
+	return values
+}
+
+// fieldName returns the field name used in ValidationError
+func fieldName(field reflect.StructField) string {
+	for _, tag := range fieldNameTags {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// jsonPath removes the root struct name from the validator namespace, e.g. createOrderRequest.items[2].sku -> items[2].sku
+func jsonPath(namespace string) string {
+	if _, path, found := strings.Cut(namespace, "."); found {
+		return path
+	}
+
+	return namespace
+}
+
+// referencedFieldName resolves the name of the field referenced by a cross-field validation tag
+func referencedFieldName(rootType reflect.Type, structNamespace string, tag string, param string) string {
+	var segments []string
+	if strings.HasSuffix(tag, "csfield") {
+		// Cross struct param is the namespace from the root struct
+		segments = strings.Split(param, ".")
+	} else {
+		// Param is the sibling field
+		segments = strings.Split(structNamespace, ".")
+		segments = append(segments[1:len(segments)-1], param)
+	}
+
+	t := rootType
+	for idx, segment := range segments {
+		if t.Kind() != reflect.Struct {
+			return param
+		}
+
+		name, _, _ := strings.Cut(segment, "[")
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return param
+		}
+
+		if idx == len(segments)-1 {
+			return fieldName(field)
+		}
+
+		t = indirectType(field.Type)
+	}
+
+	return param
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+
+	return t
+}
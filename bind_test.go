@@ -10,6 +10,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/require"
 	"github.com/viebiz/lit/i18n"
 	"github.com/viebiz/lit/testutil"
@@ -58,14 +60,14 @@ func TestLitContext_BindWithValidation(t *testing.T) {
                 "multi":30
             }`,
 			expectedErr: ValidationError{
-				"ID":               "The ID field is required",
-				"Equal":            "The Equal field must be 100",
-				"NotEqual":         "ne",
-				"LessThan":         "lt",
-				"LessThanEqual":    "lte",
-				"GreaterThan":      "gt",
-				"GreaterThanEqual": "gte",
-				"Multi":            "The Multi field must be at most 20 but got 30",
+				"id":               "The id field is required",
+				"equal":            "The equal field must be 100",
+				"notequal":         "ne",
+				"lessthan":         "lt",
+				"lessthanequal":    "lte",
+				"greaterthan":      "gt",
+				"greaterthanequal": "gte",
+				"multi":            "The multi field must be at most 20 but got 30",
 			},
 		},
 		"success form": {
@@ -98,14 +100,14 @@ func TestLitContext_BindWithValidation(t *testing.T) {
 				return f.Encode()
 			}(),
 			expectedErr: ValidationError{
-				"ID":               "The ID field is required",
-				"Equal":            "The Equal field must be 100",
-				"NotEqual":         "ne",
-				"LessThan":         "lt",
-				"LessThanEqual":    "lte",
-				"GreaterThan":      "gt",
-				"GreaterThanEqual": "gte",
-				"Multi":            "The Multi field must be at least 10 but got 3",
+				"id":               "The id field is required",
+				"equal":            "The equal field must be 100",
+				"notequal":         "ne",
+				"lessthan":         "lt",
+				"lessthanequal":    "lte",
+				"greaterthan":      "gt",
+				"greaterthanequal": "gte",
+				"multi":            "The multi field must be at least 10 but got 3",
 			},
 		},
 		"got unexpected error": {
@@ -297,3 +299,160 @@ func TestListContext_Bind(t *testing.T) {
 		})
 	}
 }
+
+func TestLitContext_BindRequestSources(t *testing.T) {
+	type item struct {
+		SKU      string `json:"sku" binding:"required"`
+		Quantity int    `json:"quantity" binding:"gte=1"`
+	}
+	type orderRequest struct {
+		ID              int    `uri:"id" binding:"required"`
+		TenantID        string `header:"X-Tenant-ID" binding:"required"`
+		Theme           string `cookie:"theme"`
+		Items           []item `json:"items" binding:"required,dive"`
+		Password        string `json:"password"`
+		PasswordConfirm string `json:"password_confirm" binding:"eqfield=Password"`
+		Coupon          string `json:"coupon" binding:"omitempty,coupon_code"`
+		UserID          string `json:"-"`
+		Role            string `json:"role"`
+	}
+
+	require.NoError(t, RegisterValidation("coupon_code", func(fl validator.FieldLevel) bool {
+		return strings.HasPrefix(fl.Field().String(), "LIT")
+	}, "invalid_coupon"))
+
+	tcs := map[string]struct {
+		givenHeaders http.Header
+		givenCookies []*http.Cookie
+		givenBody    string
+		givenOpts    []BindOption
+		expResult    orderRequest
+		expErr       error
+	}{
+		"success": {
+			givenHeaders: http.Header{"X-Tenant-Id": []string{"lit"}},
+			givenCookies: []*http.Cookie{{Name: "theme", Value: "dark"}},
+			givenBody:    `{"items":[{"sku":"A1","quantity":2}],"password":"secret","password_confirm":"secret","coupon":"LIT10"}`,
+			expResult: orderRequest{
+				ID:              7,
+				TenantID:        "lit",
+				Theme:           "dark",
+				Items:           []item{{SKU: "A1", Quantity: 2}},
+				Password:        "secret",
+				PasswordConfirm: "secret",
+				Coupon:          "LIT10",
+			},
+		},
+		"success - untagged fields are not bound from headers and cookies": {
+			givenHeaders: http.Header{"X-Tenant-Id": []string{"lit"}, "Userid": []string{"attacker"}, "Role": []string{"admin"}},
+			givenCookies: []*http.Cookie{{Name: "Role", Value: "admin"}, {Name: "UserID", Value: "attacker"}},
+			givenBody:    `{"items":[{"sku":"A1","quantity":2}]}`,
+			expResult: orderRequest{
+				ID:       7,
+				TenantID: "lit",
+				Items:    []item{{SKU: "A1", Quantity: 2}},
+			},
+		},
+		"success - unknown field is ignored by default": {
+			givenHeaders: http.Header{"X-Tenant-Id": []string{"lit"}},
+			givenBody:    `{"items":[{"sku":"A1","quantity":2}],"note":"gift"}`,
+			expResult: orderRequest{
+				ID:       7,
+				TenantID: "lit",
+				Items:    []item{{SKU: "A1", Quantity: 2}},
+			},
+		},
+		"error - unknown field is rejected": {
+			givenHeaders: http.Header{"X-Tenant-Id": []string{"lit"}},
+			givenBody:    `{"items":[{"sku":"A1","quantity":2}],"note":"gift"}`,
+			givenOpts:    []BindOption{BindDisallowUnknownFields()},
+			expErr:       ValidationError{"note": "unknown_field"},
+		},
+		"error - nested and cross field": {
+			givenBody: `{"items":[{"sku":"A1","quantity":2},{"quantity":0}],"password":"secret","password_confirm":"secrets","coupon":"FREE"}`,
+			givenOpts: []BindOption{BindDisallowUnknownFields()},
+			expErr: ValidationError{
+				"X-Tenant-ID":       "The X-Tenant-ID field is required",
+				"items[1].sku":      "The sku field is required",
+				"items[1].quantity": "gte",
+				"password_confirm":  "password_confirm must be equal to password",
+				"coupon":            "invalid_coupon",
+			},
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/orders/7", strings.NewReader(tc.givenBody))
+			req.Header.Set("Content-Type", "application/json")
+			for k, v := range tc.givenHeaders {
+				req.Header[k] = v
+			}
+			for _, cookie := range tc.givenCookies {
+				req.AddCookie(cookie)
+			}
+
+			langBundle := i18n.Init(context.Background(), i18n.BundleConfig{
+				SourcePath: "i18n/testdata",
+			})
+
+			var (
+				result orderRequest
+				err    error
+			)
+			r, c, handleRequest := NewRouterForTest(w)
+			r.Post("/orders/:id", func(c Context) error {
+				c.SetRequestContext(i18n.SetInContext(c.Request().Context(), langBundle.GetLocalize("en")))
+
+				// When
+				err = c.Bind(&result, tc.givenOpts...)
+				return nil
+			})
+			c.SetRequest(req)
+			handleRequest()
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expResult, result)
+		})
+	}
+}
+
+func TestLitContext_BindKeepsGinValidator(t *testing.T) {
+	type request struct {
+		Name string `json:"name" binding:"required"`
+	}
+
+	// Given
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var bindErr error
+	r, c, handleRequest := NewRouterForTest(w)
+	r.Post("/", func(c Context) error {
+		// When
+		bindErr = c.Bind(&request{})
+		return nil
+	})
+	c.SetRequest(req)
+	handleRequest()
+
+	// Then
+	require.Equal(t, ValidationError{"name": "required"}, bindErr)
+
+	// The shared validator of gin still names the fields by the struct field name
+	var vErrs validator.ValidationErrors
+	require.ErrorAs(t, binding.Validator.ValidateStruct(&request{}), &vErrs)
+	require.Equal(t, "Name", vErrs[0].Field())
+}
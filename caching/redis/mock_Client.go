@@ -835,6 +835,54 @@ func (_c *MockClient_Ping_Call) RunAndReturn(run func(context.Context) error) *M
 	return _c
 }
 
+// Publish provides a mock function with given fields: ctx, channel, message
+func (_m *MockClient) Publish(ctx context.Context, channel string, message interface{}) error {
+	ret := _m.Called(ctx, channel, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
+		r0 = rf(ctx, channel, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockClient_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel string
+//   - message interface{}
+func (_e *MockClient_Expecter) Publish(ctx interface{}, channel interface{}, message interface{}) *MockClient_Publish_Call {
+	return &MockClient_Publish_Call{Call: _e.mock.On("Publish", ctx, channel, message)}
+}
+
+func (_c *MockClient_Publish_Call) Run(run func(ctx context.Context, channel string, message interface{})) *MockClient_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockClient_Publish_Call) Return(_a0 error) *MockClient_Publish_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_Publish_Call) RunAndReturn(run func(context.Context, string, interface{}) error) *MockClient_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetFloat provides a mock function with given fields: ctx, key, value, expiration
 func (_m *MockClient) SetFloat(ctx context.Context, key string, value float64, expiration time.Duration) error {
 	ret := _m.Called(ctx, key, value, expiration)
@@ -1276,6 +1324,56 @@ func (_c *MockClient_SetStringIfNotExist_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// Subscribe provides a mock function with given fields: ctx, channels, handler
+func (_m *MockClient) Subscribe(ctx context.Context, channels []string, handler MessageHandler) Subscriber {
+	ret := _m.Called(ctx, channels, handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 Subscriber
+	if rf, ok := ret.Get(0).(func(context.Context, []string, MessageHandler) Subscriber); ok {
+		r0 = rf(ctx, channels, handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Subscriber)
+		}
+	}
+
+	return r0
+}
+
+// MockClient_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type MockClient_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channels []string
+//   - handler MessageHandler
+func (_e *MockClient_Expecter) Subscribe(ctx interface{}, channels interface{}, handler interface{}) *MockClient_Subscribe_Call {
+	return &MockClient_Subscribe_Call{Call: _e.mock.On("Subscribe", ctx, channels, handler)}
+}
+
+func (_c *MockClient_Subscribe_Call) Run(run func(ctx context.Context, channels []string, handler MessageHandler)) *MockClient_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(MessageHandler))
+	})
+	return _c
+}
+
+func (_c *MockClient_Subscribe_Call) Return(_a0 Subscriber) *MockClient_Subscribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_Subscribe_Call) RunAndReturn(run func(context.Context, []string, MessageHandler) Subscriber) *MockClient_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockClient creates a new instance of MockClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockClient(t interface {
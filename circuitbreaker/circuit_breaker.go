@@ -0,0 +1,201 @@
+package circuitbreaker
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+const (
+	defaultWindowSize           = time.Minute
+	defaultWindowBuckets        = 10
+	defaultMinimumCalls         = 20
+	defaultFailureRateThreshold = 0.5
+	defaultOpenTimeout          = 30 * time.Second
+	defaultHalfOpenCalls        = 5
+)
+
+// CircuitBreaker stops sending calls to a failing downstream.
+//
+// It's closed by default and opens when the failure rate or the slow call rate over the rolling window
+// reaches its threshold. While open, the calls fail fast with OpenError. After the open timeout, it's half-open
+// and lets a few trial calls through: it closes again when all of them succeed and reopens on the first failure
+type CircuitBreaker struct {
+	name string
+
+	windowSize            time.Duration
+	windowBuckets         int
+	minimumCalls          int
+	failureRateThreshold  float64
+	slowCallDuration      time.Duration
+	slowCallRateThreshold float64
+	openTimeout           time.Duration
+	halfOpenCalls         int
+
+	now func() time.Time
+
+	mu     sync.Mutex
+	state  State
+	window *window
+	// generation changes on each transition, so the results of the calls allowed in a previous state are ignored
+	generation     uint64
+	openedAt       time.Time
+	halfOpenCalled int
+	halfOpenPassed int
+}
+
+// New creates a closed CircuitBreaker, the name identifies the downstream in the errors, logs and traces
+func New(name string, opts ...Option) (*CircuitBreaker, error) {
+	cb := &CircuitBreaker{
+		name:                 strings.TrimSpace(name),
+		windowSize:           defaultWindowSize,
+		windowBuckets:        defaultWindowBuckets,
+		minimumCalls:         defaultMinimumCalls,
+		failureRateThreshold: defaultFailureRateThreshold,
+		openTimeout:          defaultOpenTimeout,
+		halfOpenCalls:        defaultHalfOpenCalls,
+		now:                  time.Now,
+	}
+	if cb.name == "" {
+		return nil, pkgerrors.WithStack(ErrMissingName)
+	}
+
+	for _, opt := range opts {
+		opt(cb)
+	}
+
+	if err := cb.validate(); err != nil {
+		return nil, err
+	}
+
+	cb.window = newWindow(cb.windowSize, cb.windowBuckets)
+
+	return cb, nil
+}
+
+// Name returns the name of the CircuitBreaker
+func (cb *CircuitBreaker) Name() string {
+	return cb.name
+}
+
+// State returns the current state, an open CircuitBreaker is reported half-open once the open timeout elapsed
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == StateOpen && !cb.now().Before(cb.openedAt.Add(cb.openTimeout)) {
+		return StateHalfOpen
+	}
+
+	return cb.state
+}
+
+// Allow checks if the call can be sent, it returns OpenError when the call is rejected.
+// Otherwise, done must be called with the result of the call once it's finished, the call duration is measured
+// from Allow to done
+func (cb *CircuitBreaker) Allow(ctx context.Context) (done func(failed bool), err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+	switch cb.state {
+	case StateOpen:
+		if retryAfter := cb.openedAt.Add(cb.openTimeout).Sub(now); retryAfter > 0 {
+			return nil, pkgerrors.WithStack(OpenError{Name: cb.name, RetryAfter: retryAfter})
+		}
+
+		cb.transition(ctx, StateHalfOpen, now)
+		fallthrough
+	case StateHalfOpen:
+		if cb.halfOpenCalled >= cb.halfOpenCalls {
+			return nil, pkgerrors.WithStack(OpenError{Name: cb.name}) // Waiting for the trial calls
+		}
+		cb.halfOpenCalled++
+	}
+
+	generation := cb.generation
+	var once sync.Once
+
+	return func(failed bool) {
+		once.Do(func() {
+			cb.record(ctx, generation, now, failed)
+		})
+	}, nil
+}
+
+func (cb *CircuitBreaker) record(ctx context.Context, generation uint64, start time.Time, failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if generation != cb.generation {
+		return // The call was allowed in a previous state
+	}
+
+	now := cb.now()
+	slow := cb.slowCallDuration > 0 && now.Sub(start) >= cb.slowCallDuration
+
+	switch cb.state {
+	case StateClosed:
+		cb.window.record(now, failed, slow)
+		if cb.window.exceeds(now, cb.minimumCalls, cb.failureRateThreshold, cb.slowCallRateThreshold) {
+			cb.transition(ctx, StateOpen, now)
+		}
+	case StateHalfOpen:
+		if failed || slow {
+			cb.transition(ctx, StateOpen, now)
+			return
+		}
+
+		cb.halfOpenPassed++
+		if cb.halfOpenPassed >= cb.halfOpenCalls {
+			cb.transition(ctx, StateClosed, now)
+		}
+	}
+}
+
+// transition moves to the given state and records it in the call monitoring, the lock must be held
+func (cb *CircuitBreaker) transition(ctx context.Context, to State, now time.Time) {
+	from := cb.state
+
+	cb.state = to
+	cb.generation++
+	cb.halfOpenCalled = 0
+	cb.halfOpenPassed = 0
+	switch to {
+	case StateOpen:
+		cb.openedAt = now
+	case StateClosed:
+		cb.window.reset()
+	}
+
+	recordTransition(ctx, cb.name, from, to)
+}
+
+func (cb *CircuitBreaker) validate() error {
+	if cb.windowSize <= 0 || cb.windowBuckets <= 0 {
+		return pkgerrors.Wrap(ErrInvalidConfig, "window size and buckets should be greater than zero")
+	}
+	if cb.minimumCalls <= 0 {
+		return pkgerrors.Wrap(ErrInvalidConfig, "minimum calls should be greater than zero")
+	}
+	if cb.failureRateThreshold <= 0 || cb.failureRateThreshold > 1 {
+		return pkgerrors.Wrap(ErrInvalidConfig, "failure rate threshold should be in (0, 1]")
+	}
+	if cb.slowCallDuration < 0 || cb.slowCallRateThreshold < 0 || cb.slowCallRateThreshold > 1 {
+		return pkgerrors.Wrap(ErrInvalidConfig, "slow call duration should not be less than zero and slow call rate threshold should be in [0, 1]")
+	}
+	if (cb.slowCallDuration > 0) != (cb.slowCallRateThreshold > 0) {
+		return pkgerrors.Wrap(ErrInvalidConfig, "slow call duration and rate threshold should be set together")
+	}
+	if cb.openTimeout <= 0 {
+		return pkgerrors.Wrap(ErrInvalidConfig, "open timeout should be greater than zero")
+	}
+	if cb.halfOpenCalls <= 0 {
+		return pkgerrors.Wrap(ErrInvalidConfig, "half-open calls should be greater than zero")
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package circuitbreaker
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit/monitoring"
+)
+
+// fakeClock is the clock of the CircuitBreaker in tests
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// call is a call made through the CircuitBreaker in a test case
+type call struct {
+	after    time.Duration // Time elapsed before the call
+	duration time.Duration
+	failed   bool
+	expState State // State after the call
+	expErr   error
+}
+
+func TestCircuitBreaker_Allow(t *testing.T) {
+	failures := func(n int, expState State) []call {
+		calls := make([]call, n)
+		for idx := range calls {
+			calls[idx] = call{failed: true, expState: StateClosed}
+		}
+		calls[n-1].expState = expState
+		return calls
+	}
+
+	tcs := map[string]struct {
+		opts  []Option
+		calls []call
+	}{
+		"closed below minimum calls": {
+			opts:  []Option{WithMinimumCalls(3)},
+			calls: failures(2, StateClosed),
+		},
+		"opens on failure rate": {
+			opts:  []Option{WithMinimumCalls(3)},
+			calls: failures(3, StateOpen),
+		},
+		"closed below failure rate": {
+			opts: []Option{WithMinimumCalls(4), WithFailureRateThreshold(0.75)},
+			calls: []call{
+				{failed: true, expState: StateClosed},
+				{failed: false, expState: StateClosed},
+				{failed: true, expState: StateClosed},
+				{failed: false, expState: StateClosed},
+			},
+		},
+		"outdated failures are dropped from window": {
+			opts: []Option{WithMinimumCalls(2), WithWindow(10*time.Second, 10)},
+			calls: []call{
+				{failed: true, expState: StateClosed},
+				{after: 10 * time.Second, failed: true, expState: StateClosed},
+				{after: 9 * time.Second, failed: true, expState: StateOpen},
+			},
+		},
+		"opens on slow call rate": {
+			opts: []Option{WithMinimumCalls(2), WithSlowCallThreshold(time.Second, 1)},
+			calls: []call{
+				{duration: time.Second, expState: StateClosed},
+				{duration: 2 * time.Second, expState: StateOpen},
+			},
+		},
+		"rejects while open": {
+			opts: []Option{WithMinimumCalls(1), WithOpenTimeout(10 * time.Second)},
+			calls: []call{
+				{failed: true, expState: StateOpen},
+				{after: 4 * time.Second, expState: StateOpen, expErr: OpenError{Name: "orders", RetryAfter: 6 * time.Second}},
+			},
+		},
+		"closes after half-open calls succeeded": {
+			opts: []Option{WithMinimumCalls(1), WithOpenTimeout(10 * time.Second), WithHalfOpenCalls(2)},
+			calls: []call{
+				{failed: true, expState: StateOpen},
+				{after: 10 * time.Second, expState: StateHalfOpen},
+				{expState: StateClosed},
+				{failed: false, expState: StateClosed},
+			},
+		},
+		"reopens on half-open failure": {
+			opts: []Option{WithMinimumCalls(1), WithOpenTimeout(10 * time.Second), WithHalfOpenCalls(2)},
+			calls: []call{
+				{failed: true, expState: StateOpen},
+				{after: 10 * time.Second, failed: true, expState: StateOpen},
+				{after: time.Second, expState: StateOpen, expErr: OpenError{Name: "orders", RetryAfter: 9 * time.Second}},
+			},
+		},
+	}
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+			cb, err := New("orders", tc.opts...)
+			require.NoError(t, err)
+			cb.now = clock.Now
+
+			for idx, c := range tc.calls {
+				clock.Advance(c.after)
+
+				// When
+				done, err := cb.Allow(context.Background())
+
+				// Then
+				if c.expErr != nil {
+					require.ErrorIs(t, err, c.expErr, "call %d", idx)
+				} else {
+					require.NoError(t, err, "call %d", idx)
+					clock.Advance(c.duration)
+					done(c.failed)
+				}
+				require.Equal(t, c.expState, cb.State(), "call %d", idx)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker_Allow_HalfOpenLimit(t *testing.T) {
+	// Given
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cb, err := New("orders", WithMinimumCalls(1), WithOpenTimeout(time.Second), WithHalfOpenCalls(1))
+	require.NoError(t, err)
+	cb.now = clock.Now
+
+	done, err := cb.Allow(context.Background())
+	require.NoError(t, err)
+	done(true)
+	clock.Advance(time.Second)
+
+	trialDone, err := cb.Allow(context.Background())
+	require.NoError(t, err)
+
+	// When
+	_, err = cb.Allow(context.Background())
+
+	// Then
+	require.ErrorIs(t, err, OpenError{Name: "orders"})
+
+	trialDone(false)
+	trialDone(true) // Only the first result is recorded
+	require.Equal(t, StateClosed, cb.State())
+}
+
+func TestCircuitBreaker_Allow_StaleResult(t *testing.T) {
+	// Given
+	cb, err := New("orders", WithMinimumCalls(1))
+	require.NoError(t, err)
+
+	staleDone, err := cb.Allow(context.Background())
+	require.NoError(t, err)
+	done, err := cb.Allow(context.Background())
+	require.NoError(t, err)
+	done(true)
+	require.Equal(t, StateOpen, cb.State())
+
+	// When
+	staleDone(false) // The call was allowed before the circuit breaker opened
+
+	// Then
+	require.Equal(t, StateOpen, cb.State())
+}
+
+func TestCircuitBreaker_Allow_RecordsTransition(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	m, err := monitoring.New(monitoring.Config{Writer: &buf})
+	require.NoError(t, err)
+	ctx := monitoring.SetInContext(context.Background(), m)
+
+	cb, err := New("orders", WithMinimumCalls(1))
+	require.NoError(t, err)
+
+	done, err := cb.Allow(ctx)
+	require.NoError(t, err)
+
+	// When
+	done(true)
+
+	// Then
+	require.Contains(t, buf.String(), `"msg":"circuit_breaker.state_changed"`)
+	require.Contains(t, buf.String(), `"circuit_breaker.name":"orders","circuit_breaker.previous_state":"closed","circuit_breaker.state":"open"`)
+}
+
+func TestNew(t *testing.T) {
+	tcs := map[string]struct {
+		name   string
+		opts   []Option
+		expErr error
+	}{
+		"defaults": {
+			name: "orders",
+		},
+		"missing name": {
+			name:   " ",
+			expErr: ErrMissingName,
+		},
+		"invalid failure rate": {
+			name:   "orders",
+			opts:   []Option{WithFailureRateThreshold(1.5)},
+			expErr: ErrInvalidConfig,
+		},
+		"slow call duration without rate": {
+			name:   "orders",
+			opts:   []Option{WithSlowCallThreshold(time.Second, 0)},
+			expErr: ErrInvalidConfig,
+		},
+		"invalid window": {
+			name:   "orders",
+			opts:   []Option{WithWindow(time.Minute, 0)},
+			expErr: ErrInvalidConfig,
+		},
+	}
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// When
+			cb, err := New(tc.name, tc.opts...)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.name, cb.Name())
+			require.Equal(t, StateClosed, cb.State())
+		})
+	}
+}
+
+func TestOpenError(t *testing.T) {
+	// Given
+	err := OpenError{Name: "orders", RetryAfter: 5 * time.Second}
+
+	// When & Then
+	require.Equal(t, "circuit breaker [orders] is open", err.Error())
+	require.Equal(t, http.StatusServiceUnavailable, err.StatusCode())
+	require.Equal(t, "circuit_open", err.ErrorCode())
+	require.Equal(t, 5*time.Second, err.RetryDelay())
+}
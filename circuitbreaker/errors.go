@@ -0,0 +1,44 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	openErrorCode = "circuit_open"
+)
+
+var (
+	ErrMissingName   = errors.New("missing circuit breaker name")
+	ErrInvalidConfig = errors.New("circuit breaker config invalid")
+)
+
+// OpenError is returned when the CircuitBreaker rejects the call.
+// It carries its status and retry hint, so lit returns it to the HTTP and gRPC clients as Service Unavailable
+type OpenError struct {
+	Name string
+	// RetryAfter is the remaining open time, it's zero while waiting for the half-open trial calls
+	RetryAfter time.Duration
+}
+
+func (e OpenError) Error() string {
+	return fmt.Sprintf("circuit breaker [%s] is open", e.Name)
+}
+
+// StatusCode returns 503 Service Unavailable
+func (e OpenError) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+// ErrorCode returns the code of the error sent to the clients
+func (e OpenError) ErrorCode() string {
+	return openErrorCode
+}
+
+// RetryDelay returns the remaining open time, it's sent as the Retry-After header or the gRPC RetryInfo
+func (e OpenError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
@@ -0,0 +1,33 @@
+package circuitbreaker
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/viebiz/lit/monitoring"
+)
+
+const (
+	stateChangedEventName = "circuit_breaker.state_changed"
+)
+
+// recordTransition records the state change as an event of the span and a log line of the call causing it
+func recordTransition(ctx context.Context, name string, from, to State) {
+	trace.SpanFromContext(ctx).AddEvent(stateChangedEventName, trace.WithAttributes(
+		attribute.String("circuit_breaker.name", name),
+		attribute.String("circuit_breaker.previous_state", from.String()),
+		attribute.String("circuit_breaker.state", to.String()),
+	))
+
+	level := monitoring.InfoLevel
+	if to == StateOpen {
+		level = monitoring.WarnLevel
+	}
+	monitoring.FromContext(ctx).Log(level, stateChangedEventName,
+		monitoring.StringField("circuit_breaker.name", name),
+		monitoring.StringField("circuit_breaker.previous_state", from.String()),
+		monitoring.StringField("circuit_breaker.state", to.String()),
+	)
+}
@@ -0,0 +1,53 @@
+package circuitbreaker
+
+import (
+	"time"
+)
+
+// Option alters behaviour of the CircuitBreaker
+type Option func(cb *CircuitBreaker)
+
+// WithWindow overrides the rolling window in which the calls are counted, 1 minute in 10 buckets by default
+func WithWindow(size time.Duration, buckets int) Option {
+	return func(cb *CircuitBreaker) {
+		cb.windowSize = size
+		cb.windowBuckets = buckets
+	}
+}
+
+// WithMinimumCalls overrides the number of calls in the window before the rates are evaluated, 20 by default
+func WithMinimumCalls(n int) Option {
+	return func(cb *CircuitBreaker) {
+		cb.minimumCalls = n
+	}
+}
+
+// WithFailureRateThreshold overrides the failure rate opening the CircuitBreaker, 0.5 by default
+func WithFailureRateThreshold(rate float64) Option {
+	return func(cb *CircuitBreaker) {
+		cb.failureRateThreshold = rate
+	}
+}
+
+// WithSlowCallThreshold opens the CircuitBreaker when the rate of the calls taking at least duration reaches rate.
+// It's disabled by default
+func WithSlowCallThreshold(duration time.Duration, rate float64) Option {
+	return func(cb *CircuitBreaker) {
+		cb.slowCallDuration = duration
+		cb.slowCallRateThreshold = rate
+	}
+}
+
+// WithOpenTimeout overrides how long the CircuitBreaker stays open before the trial calls, 30 seconds by default
+func WithOpenTimeout(d time.Duration) Option {
+	return func(cb *CircuitBreaker) {
+		cb.openTimeout = d
+	}
+}
+
+// WithHalfOpenCalls overrides the number of trial calls in half-open state, 5 by default
+func WithHalfOpenCalls(n int) Option {
+	return func(cb *CircuitBreaker) {
+		cb.halfOpenCalls = n
+	}
+}
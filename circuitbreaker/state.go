@@ -0,0 +1,26 @@
+package circuitbreaker
+
+// State is the state of the CircuitBreaker
+type State int
+
+const (
+	// StateClosed lets all the calls through
+	StateClosed State = iota
+	// StateOpen rejects all the calls
+	StateOpen
+	// StateHalfOpen lets a few trial calls through to check if the downstream is recovered
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
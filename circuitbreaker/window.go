@@ -0,0 +1,80 @@
+package circuitbreaker
+
+import (
+	"time"
+)
+
+// window counts the calls of the last windowSize in buckets, the oldest bucket is dropped as the time goes
+type window struct {
+	bucketSize time.Duration
+	buckets    []bucket
+}
+
+type bucket struct {
+	// index is the position of the bucket since the epoch, it tells whether the bucket is outdated
+	index    int64
+	calls    int
+	failures int
+	slow     int
+}
+
+func newWindow(size time.Duration, buckets int) *window {
+	bucketSize := size / time.Duration(buckets)
+	if bucketSize <= 0 {
+		bucketSize = 1
+	}
+
+	return &window{
+		bucketSize: bucketSize,
+		buckets:    make([]bucket, buckets),
+	}
+}
+
+func (w *window) record(now time.Time, failed, slow bool) {
+	idx := now.UnixNano() / int64(w.bucketSize)
+
+	b := &w.buckets[idx%int64(len(w.buckets))]
+	if b.index != idx {
+		*b = bucket{index: idx}
+	}
+
+	b.calls++
+	if failed {
+		b.failures++
+	}
+	if slow {
+		b.slow++
+	}
+}
+
+// exceeds checks if the failure rate or the slow call rate reaches the threshold, a zero threshold is disabled.
+// The rates are only evaluated once there are minimumCalls calls in the window
+func (w *window) exceeds(now time.Time, minimumCalls int, failureRateThreshold, slowCallRateThreshold float64) bool {
+	oldest := now.UnixNano()/int64(w.bucketSize) - int64(len(w.buckets)) + 1
+
+	var calls, failures, slow int
+	for _, b := range w.buckets {
+		if b.index < oldest {
+			continue
+		}
+		calls += b.calls
+		failures += b.failures
+		slow += b.slow
+	}
+
+	if calls == 0 || calls < minimumCalls {
+		return false
+	}
+
+	if failureRateThreshold > 0 && float64(failures)/float64(calls) >= failureRateThreshold {
+		return true
+	}
+
+	return slowCallRateThreshold > 0 && float64(slow)/float64(calls) >= slowCallRateThreshold
+}
+
+func (w *window) reset() {
+	for idx := range w.buckets {
+		w.buckets[idx] = bucket{}
+	}
+}
@@ -4,16 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"math"
 	"mime/multipart"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/viebiz/lit/monitoring"
 )
 
 const (
 	plainContentType = "text/plain; charset=utf-8"
 	jsonContentType  = "application/json"
+	retryAfterHeader = "Retry-After"
 )
 
 type Context interface {
@@ -34,10 +39,10 @@ type Context interface {
 	// SetWriter updates the current writer
 	SetWriter(w ResponseWriter)
 
-	// Bind binds the incoming request body and URI parameters to the provided object
+	// Bind binds the incoming request URI parameters, headers, cookies and body to the provided object
 	// Return error if the got error when binding and validating the object
 	// Support validation tags from https://github.com/go-playground/validator/v10
-	Bind(obj interface{}) error
+	Bind(obj interface{}, opts ...BindOption) error
 
 	// FormFile returns the first file for the provided form key
 	FormFile(name string) (*multipart.FileHeader, error)
@@ -91,6 +96,55 @@ type Context interface {
 	// QueryWithCallback behaves like Query but returns the value from the callback if the parameter is missing or empty
 	QueryWithCallback(key string, callback func() string) string
 
+	// QueryArray returns all the values of the URL query parameter key
+	// e.g. /search?tag=a&tag=b returns []string{"a", "b"}
+	QueryArray(key string) []string
+
+	// QueryMap returns a map of the URL query parameters with the given key prefix
+	// e.g. /search?filter[name]=lit&filter[type]=lib returns map[string]string{"name": "lit", "type": "lib"}
+	QueryMap(key string) map[string]string
+
+	// ParamInt parses the URL path parameter as an integer
+	// Return ValidationError if the parameter is empty or not an integer
+	ParamInt(key string) (int, error)
+
+	// ParamUUID parses the URL path parameter as a UUID
+	// Return ValidationError if the parameter is empty or not a UUID
+	ParamUUID(key string) (uuid.UUID, error)
+
+	// QueryInt parses the URL query parameter as an integer, returns 0 if the parameter is missing
+	// Return ValidationError if the parameter is not an integer
+	QueryInt(key string) (int, error)
+
+	// QueryBool parses the URL query parameter as a boolean, returns false if the parameter is missing
+	// Return ValidationError if the parameter is not a boolean
+	QueryBool(key string) (bool, error)
+
+	// QueryUUID parses the URL query parameter as a UUID, returns uuid.Nil if the parameter is missing
+	// Return ValidationError if the parameter is not a UUID
+	QueryUUID(key string) (uuid.UUID, error)
+
+	// QueryTime parses the URL query parameter as time with the given layout, returns zero time if the parameter is missing
+	// Return ValidationError if the parameter does not match the layout
+	QueryTime(key string, layout string) (time.Time, error)
+
+	// ClientIP returns the client IP, X-Forwarded-For and X-Real-IP are only used if the request comes from a trusted proxy
+	ClientIP() string
+
+	// GetHeader returns the value of the request header
+	GetHeader(key string) string
+
+	// Cookie returns the value of the named request cookie
+	// Return http.ErrNoCookie if the cookie is not found
+	Cookie(name string) (string, error)
+
+	// SetCookie adds a Set-Cookie header to the response, Path defaults to "/"
+	SetCookie(cookie *http.Cookie)
+
+	// Redirect replies to the request with a redirect to location
+	// Return error if the code is not a redirect status code
+	Redirect(code int, location string) error
+
 	// Next continues to the next handler in the chain
 	Next()
 
@@ -102,12 +156,15 @@ type Context interface {
 	Abort()
 }
 
+// litContext is a view over the gin.Context which is pooled and reused by gin.
+// It only holds a single pointer, so converting it to the Context interface does not allocate,
+// and every handler in the chain shares the same underlying request state
 type litContext struct {
 	*gin.Context
 }
 
 func newContext(c *gin.Context) Context {
-	return &litContext{
+	return litContext{
 		Context: c,
 	}
 }
@@ -208,6 +265,14 @@ func (c litContext) Error(err error) {
 		}
 	}
 
+	if e, ok := httpErr.(retryableError); ok {
+		httpErr = retryableHTTPError(e)
+	}
+
+	if e, ok := asHTTPError(err); ok && e.RetryAfter > 0 {
+		c.Header(retryAfterHeader, strconv.Itoa(int(math.Ceil(e.RetryAfter.Seconds()))))
+	}
+
 	switch c.Request().Method {
 	case http.MethodHead:
 		err = c.NoContent(httpErr.StatusCode())
@@ -0,0 +1,95 @@
+package lit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viebiz/lit/i18n"
+)
+
+// Message IDs of parameter parsing errors, they are the same as validator tags to share the translations with Bind
+const (
+	requiredMessageID = "required"
+	numberMessageID   = "number"
+	booleanMessageID  = "boolean"
+	uuidMessageID     = "uuid"
+	datetimeMessageID = "datetime"
+)
+
+func (c litContext) SetCookie(cookie *http.Cookie) {
+	if cookie.Path == "" {
+		cookie.Path = "/"
+	}
+
+	http.SetCookie(c.Writer(), cookie)
+}
+
+func (c litContext) Redirect(code int, location string) error {
+	if (code < http.StatusMultipleChoices || code > http.StatusPermanentRedirect) && code != http.StatusCreated {
+		return fmt.Errorf("cannot redirect with status code %d", code)
+	}
+
+	http.Redirect(c.Writer(), c.Request(), location, code)
+	return nil
+}
+
+func (c litContext) ParamInt(key string) (int, error) {
+	return parseRequiredValue(c, key, c.Context.Param(key), numberMessageID, "", strconv.Atoi)
+}
+
+func (c litContext) ParamUUID(key string) (uuid.UUID, error) {
+	return parseRequiredValue(c, key, c.Context.Param(key), uuidMessageID, "", uuid.Parse)
+}
+
+func (c litContext) QueryInt(key string) (int, error) {
+	return parseOptionalValue(c, key, c.Context.Query(key), numberMessageID, "", strconv.Atoi)
+}
+
+func (c litContext) QueryBool(key string) (bool, error) {
+	return parseOptionalValue(c, key, c.Context.Query(key), booleanMessageID, "", strconv.ParseBool)
+}
+
+func (c litContext) QueryUUID(key string) (uuid.UUID, error) {
+	return parseOptionalValue(c, key, c.Context.Query(key), uuidMessageID, "", uuid.Parse)
+}
+
+func (c litContext) QueryTime(key string, layout string) (time.Time, error) {
+	return parseOptionalValue(c, key, c.Context.Query(key), datetimeMessageID, layout, func(v string) (time.Time, error) {
+		return time.Parse(layout, v)
+	})
+}
+
+func parseRequiredValue[T any](c Context, key, value, messageID, condition string, parseFn func(string) (T, error)) (T, error) {
+	if value == "" {
+		return *new(T), newParamError(c, key, value, requiredMessageID, condition)
+	}
+
+	return parseOptionalValue(c, key, value, messageID, condition, parseFn)
+}
+
+// parseOptionalValue returns zero value if the value is empty
+func parseOptionalValue[T any](c Context, key, value, messageID, condition string, parseFn func(string) (T, error)) (T, error) {
+	if value == "" {
+		return *new(T), nil
+	}
+
+	rs, err := parseFn(value)
+	if err != nil {
+		return *new(T), newParamError(c, key, value, messageID, condition)
+	}
+
+	return rs, nil
+}
+
+func newParamError(c Context, key, value, messageID, condition string) ValidationError {
+	return ValidationError{
+		key: i18n.FromContext(c).Localize(messageID, map[string]interface{}{
+			"Field":     key,
+			"Value":     value,
+			"Condition": condition,
+		}),
+	}
+}
@@ -0,0 +1,228 @@
+package lit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit/i18n"
+)
+
+func TestLitContext_TypedParams(t *testing.T) {
+	givenUUID := uuid.MustParse("3f5a8a3e-2c7b-4b8e-9d1a-6f2b1c9e7a10")
+
+	tcs := map[string]struct {
+		givenPath   string
+		givenTarget string
+		handler     func(c Context) (any, error)
+		expResult   any
+		expErr      error
+	}{
+		"ParamInt - success": {
+			givenPath:   "/orders/:id",
+			givenTarget: "/orders/42",
+			handler:     func(c Context) (any, error) { return c.ParamInt("id") },
+			expResult:   42,
+		},
+		"ParamInt - invalid": {
+			givenPath:   "/orders/:id",
+			givenTarget: "/orders/abc",
+			handler:     func(c Context) (any, error) { return c.ParamInt("id") },
+			expResult:   0,
+			expErr:      ValidationError{"id": "number"},
+		},
+		"ParamUUID - success": {
+			givenPath:   "/orders/:id",
+			givenTarget: "/orders/" + givenUUID.String(),
+			handler:     func(c Context) (any, error) { return c.ParamUUID("id") },
+			expResult:   givenUUID,
+		},
+		"ParamUUID - invalid": {
+			givenPath:   "/orders/:id",
+			givenTarget: "/orders/not-uuid",
+			handler:     func(c Context) (any, error) { return c.ParamUUID("id") },
+			expResult:   uuid.Nil,
+			expErr:      ValidationError{"id": "uuid"},
+		},
+		"ParamUUID - missing": {
+			givenPath:   "/orders/*id",
+			givenTarget: "/orders/",
+			handler:     func(c Context) (any, error) { return c.ParamUUID("missing") },
+			expResult:   uuid.Nil,
+			expErr:      ValidationError{"missing": "The missing field is required"},
+		},
+		"QueryInt - success": {
+			givenPath:   "/orders",
+			givenTarget: "/orders?page=3",
+			handler:     func(c Context) (any, error) { return c.QueryInt("page") },
+			expResult:   3,
+		},
+		"QueryInt - missing": {
+			givenPath:   "/orders",
+			givenTarget: "/orders",
+			handler:     func(c Context) (any, error) { return c.QueryInt("page") },
+			expResult:   0,
+		},
+		"QueryInt - invalid": {
+			givenPath:   "/orders",
+			givenTarget: "/orders?page=first",
+			handler:     func(c Context) (any, error) { return c.QueryInt("page") },
+			expResult:   0,
+			expErr:      ValidationError{"page": "number"},
+		},
+		"QueryBool - success": {
+			givenPath:   "/orders",
+			givenTarget: "/orders?paid=true",
+			handler:     func(c Context) (any, error) { return c.QueryBool("paid") },
+			expResult:   true,
+		},
+		"QueryBool - invalid": {
+			givenPath:   "/orders",
+			givenTarget: "/orders?paid=maybe",
+			handler:     func(c Context) (any, error) { return c.QueryBool("paid") },
+			expResult:   false,
+			expErr:      ValidationError{"paid": "boolean"},
+		},
+		"QueryUUID - success": {
+			givenPath:   "/orders",
+			givenTarget: "/orders?customer=" + givenUUID.String(),
+			handler:     func(c Context) (any, error) { return c.QueryUUID("customer") },
+			expResult:   givenUUID,
+		},
+		"QueryTime - success": {
+			givenPath:   "/orders",
+			givenTarget: "/orders?from=2025-01-02",
+			handler:     func(c Context) (any, error) { return c.QueryTime("from", time.DateOnly) },
+			expResult:   time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"QueryTime - invalid": {
+			givenPath:   "/orders",
+			givenTarget: "/orders?from=02/01/2025",
+			handler:     func(c Context) (any, error) { return c.QueryTime("from", time.DateOnly) },
+			expResult:   time.Time{},
+			expErr:      ValidationError{"from": "datetime"},
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			w := httptest.NewRecorder()
+			r, ctx, handleRequest := NewRouterForTest(w)
+			ctx.SetRequest(httptest.NewRequest(http.MethodGet, tc.givenTarget, nil))
+
+			langBundle := i18n.Init(context.Background(), i18n.BundleConfig{
+				SourcePath: "i18n/testdata",
+			})
+
+			var result any
+			var err error
+			r.Get(tc.givenPath, func(c Context) error {
+				c.SetRequestContext(i18n.SetInContext(c.Request().Context(), langBundle.GetLocalize("en")))
+
+				// When
+				result, err = tc.handler(c)
+				return nil
+			})
+			handleRequest()
+
+			// Then
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expResult, result)
+			if tc.expErr != nil {
+				require.Equal(t, http.StatusBadRequest, err.(Error).StatusCode())
+			}
+		})
+	}
+}
+
+func TestLitContext_QueryValues(t *testing.T) {
+	// Given
+	w := httptest.NewRecorder()
+	ctx := CreateTestContext(w)
+	ctx.SetRequest(httptest.NewRequest(http.MethodGet, "/search?tag=a&tag=b&filter[name]=lit&filter[type]=lib", nil))
+
+	// When & Then
+	require.Equal(t, []string{"a", "b"}, ctx.QueryArray("tag"))
+	require.Empty(t, ctx.QueryArray("missing"))
+	require.Equal(t, map[string]string{"name": "lit", "type": "lib"}, ctx.QueryMap("filter"))
+}
+
+func TestLitContext_HeaderAndCookie(t *testing.T) {
+	// Given
+	w := httptest.NewRecorder()
+	ctx := CreateTestContext(w)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Tenant", "lit")
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+	ctx.SetRequest(req)
+
+	// When
+	ctx.SetCookie(&http.Cookie{Name: "lang", Value: "en", HttpOnly: true})
+
+	// Then
+	require.Equal(t, "lit", ctx.GetHeader("X-Tenant"))
+
+	theme, err := ctx.Cookie("theme")
+	require.NoError(t, err)
+	require.Equal(t, "dark", theme)
+
+	_, err = ctx.Cookie("missing")
+	require.ErrorIs(t, err, http.ErrNoCookie)
+
+	require.Equal(t, "lang=en; Path=/; HttpOnly", w.Header().Get("Set-Cookie"))
+}
+
+func TestLitContext_Redirect(t *testing.T) {
+	tcs := map[string]struct {
+		givenCode   int
+		expStatus   int
+		expLocation string
+		expErr      bool
+	}{
+		"found": {
+			givenCode:   http.StatusFound,
+			expStatus:   http.StatusFound,
+			expLocation: "/login",
+		},
+		"created": {
+			givenCode:   http.StatusCreated,
+			expStatus:   http.StatusCreated,
+			expLocation: "/login",
+		},
+		"invalid code": {
+			givenCode: http.StatusOK,
+			expStatus: http.StatusOK,
+			expErr:    true,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			w := httptest.NewRecorder()
+			ctx := CreateTestContext(w)
+			ctx.SetRequest(httptest.NewRequest(http.MethodGet, "/", nil))
+
+			// When
+			err := ctx.Redirect(tc.givenCode, "/login")
+			ctx.Writer().WriteHeaderNow()
+
+			// Then
+			require.Equal(t, tc.expErr, err != nil)
+			require.Equal(t, tc.expStatus, w.Code)
+			require.Equal(t, tc.expLocation, w.Header().Get("Location"))
+		})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -24,6 +26,7 @@ func TestError(t *testing.T) {
 		mockWriter     mockWriter
 		expectedStatus int
 		expectedBody   string
+		expRetryAfter  string
 	}{
 		"common error": {
 			inErr:          errors.New("simulated error"),
@@ -50,6 +53,23 @@ func TestError(t *testing.T) {
 				return buf.String()
 			}(),
 		},
+		"retry after": {
+			inErr: &HTTPError{
+				Status:     http.StatusServiceUnavailable,
+				Code:       "service_unavailable",
+				Desc:       "Service is temporarily unavailable",
+				RetryAfter: 1500 * time.Millisecond,
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   "{\"error\":\"service_unavailable\",\"error_description\":\"Service is temporarily unavailable\"}\n",
+			expRetryAfter:  "2",
+		},
+		"retryable error": {
+			inErr:          fmt.Errorf("call orders: %w", testRetryableError{}),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   "{\"error\":\"circuit_open\",\"error_description\":\"orders is unavailable\"}\n",
+			expRetryAfter:  "3",
+		},
 		"error when marshal": {
 			inErr:          testErrorMarshal{},
 			expectedStatus: http.StatusBadRequest,
@@ -101,6 +121,7 @@ func TestError(t *testing.T) {
 			require.Equal(t, tc.expectedStatus, recorder.Code)
 			require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
 			require.Equal(t, tc.expectedBody, recorder.Body.String())
+			require.Equal(t, tc.expRetryAfter, recorder.Header().Get("Retry-After"))
 		})
 	}
 }
@@ -118,6 +139,25 @@ func (e testError) StatusCode() int {
 	return e.Code
 }
 
+// testRetryableError hints when to retry, as circuitbreaker.OpenError
+type testRetryableError struct{}
+
+func (e testRetryableError) Error() string {
+	return "orders is unavailable"
+}
+
+func (e testRetryableError) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+func (e testRetryableError) ErrorCode() string {
+	return "circuit_open"
+}
+
+func (e testRetryableError) RetryDelay() time.Duration {
+	return 3 * time.Second
+}
+
 type testErrorMarshal struct{}
 
 func (e testErrorMarshal) Error() string {
@@ -1,7 +1,9 @@
 package lit
 
 import (
+	"errors"
 	"fmt"
+	"time"
 )
 
 // Error represents standard error of lit framework
@@ -11,11 +13,23 @@ type Error interface {
 	StatusCode() int // Suppose return status code
 }
 
+// retryableError is the Error hinting the client when to retry, e.g. circuitbreaker.OpenError.
+// It's sent as HTTPError with the Retry-After header or the gRPC RetryInfo
+type retryableError interface {
+	Error
+
+	ErrorCode() string
+	RetryDelay() time.Duration
+}
+
 // HTTPError represents an expected error from HTTP request
 type HTTPError struct {
 	Status int    `json:"-"`
 	Code   string `json:"error"`
 	Desc   string `json:"error_description"`
+
+	// RetryAfter hints the client when to retry the request, sent in the Retry-After header
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e HTTPError) StatusCode() int {
@@ -25,3 +39,32 @@ func (e HTTPError) StatusCode() int {
 func (e HTTPError) Error() string {
 	return fmt.Sprintf("Status: [%d], Code: [%s], Desc: [%s]", e.Status, e.Code, e.Desc)
 }
+
+// asHTTPError finds the first HTTPError, by value or pointer, in the error chain, a retryableError is converted to HTTPError
+func asHTTPError(err error) (HTTPError, bool) {
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr, true
+	}
+
+	var httpErrPtr *HTTPError
+	if errors.As(err, &httpErrPtr) && httpErrPtr != nil {
+		return *httpErrPtr, true
+	}
+
+	var retryableErr retryableError
+	if errors.As(err, &retryableErr) {
+		return retryableHTTPError(retryableErr), true
+	}
+
+	return HTTPError{}, false
+}
+
+func retryableHTTPError(e retryableError) HTTPError {
+	return HTTPError{
+		Status:     e.StatusCode(),
+		Code:       e.ErrorCode(),
+		Desc:       e.Error(),
+		RetryAfter: e.RetryDelay(),
+	}
+}
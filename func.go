@@ -9,8 +9,8 @@ import (
 // AdaptGinHandler allows using gin middleware
 func AdaptGinHandler(ginHandler gin.HandlerFunc) HandlerFunc {
 	return func(ctx Context) error {
-		litCtx, ok := ctx.(*litContext)
-		if !ok {
+		litCtx, ok := ctx.(litContext)
+		if !ok || litCtx.Context == nil {
 			return HTTPError{
 				Status: http.StatusInternalServerError,
 				Code:   http.StatusText(http.StatusInternalServerError),
@@ -0,0 +1,322 @@
+package lit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/viebiz/lit/i18n"
+)
+
+const (
+	// grpcErrorDomain is the domain of the ErrorInfo details
+	grpcErrorDomain = "lit"
+
+	// grpcHTTPStatusKey keeps the original HTTP status in the ErrorInfo metadata,
+	// so the error survives HTTP→gRPC→HTTP hops with the same status
+	grpcHTTPStatusKey = "http_status"
+
+	validationErrorCode = "validation_error"
+	validationErrorDesc = "Invalid request"
+	defaultLocale       = "en"
+)
+
+var (
+	// errGRPCInternal is returned for the unexpected errors and panics, the detail is not leaked to the client
+	errGRPCInternal = HTTPError{Status: http.StatusInternalServerError, Code: "internal_error", Desc: "internal error"}
+
+	httpStatusToGRPCCode = map[int]codes.Code{
+		http.StatusBadRequest:          codes.InvalidArgument,
+		http.StatusUnauthorized:        codes.Unauthenticated,
+		http.StatusForbidden:           codes.PermissionDenied,
+		http.StatusNotFound:            codes.NotFound,
+		http.StatusConflict:            codes.Aborted,
+		http.StatusPreconditionFailed:  codes.FailedPrecondition,
+		http.StatusTooManyRequests:     codes.ResourceExhausted,
+		499:                            codes.Canceled, // Client closed request
+		http.StatusNotImplemented:      codes.Unimplemented,
+		http.StatusServiceUnavailable:  codes.Unavailable,
+		http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+		http.StatusInternalServerError: codes.Internal,
+	}
+
+	grpcCodeToHTTPStatus = map[codes.Code]int{
+		codes.OK:                 http.StatusOK,
+		codes.Canceled:           499,
+		codes.Unknown:            http.StatusInternalServerError,
+		codes.InvalidArgument:    http.StatusBadRequest,
+		codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+		codes.NotFound:           http.StatusNotFound,
+		codes.AlreadyExists:      http.StatusConflict,
+		codes.PermissionDenied:   http.StatusForbidden,
+		codes.Unauthenticated:    http.StatusUnauthorized,
+		codes.ResourceExhausted:  http.StatusTooManyRequests,
+		codes.FailedPrecondition: http.StatusBadRequest,
+		codes.Aborted:            http.StatusConflict,
+		codes.OutOfRange:         http.StatusBadRequest,
+		codes.Unimplemented:      http.StatusNotImplemented,
+		codes.Internal:           http.StatusInternalServerError,
+		codes.Unavailable:        http.StatusServiceUnavailable,
+		codes.DataLoss:           http.StatusInternalServerError,
+	}
+)
+
+// ToGRPCStatus converts the error returned by a handler to gRPC status
+//
+// ValidationError is converted to InvalidArgument with BadRequest field violations,
+// HTTPError to the code matching its status with ErrorInfo, RetryInfo and LocalizedMessage details
+// localized by the i18n localizer in context. Errors already carrying a gRPC status are kept,
+// other errors are hidden behind an Internal status
+func ToGRPCStatus(ctx context.Context, err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	// Errors converted from a gRPC status keep the original code and details
+	var statusErr grpcStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status
+	}
+
+	var validationErr ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErrorStatus(ctx, validationErr)
+	}
+
+	if httpErr, ok := asHTTPError(err); ok {
+		return httpErrorStatus(ctx, httpErr)
+	}
+
+	var litErr Error
+	if errors.As(err, &litErr) {
+		return httpErrorStatus(ctx, HTTPError{
+			Status: litErr.StatusCode(),
+			Code:   snakeCase(grpcCodeFromHTTPStatus(litErr.StatusCode()).String()),
+			Desc:   litErr.Error(),
+		})
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return grpcErr.GRPCStatus()
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err)
+	}
+
+	return httpErrorStatus(ctx, errGRPCInternal)
+}
+
+// FromGRPCError converts the gRPC status error back to lit Error, so it can be returned to the HTTP client as is
+//
+// BadRequest field violations are converted to ValidationError, other statuses to HTTPError with
+// the error code and HTTP status kept in the lit ErrorInfo or the ones matching the gRPC code.
+// ErrorInfo of other domains is ignored, its reason and metadata don't follow lit's error codes.
+// The converted error keeps the original status, so it's sent with the same code and details when it's returned by a gRPC handler.
+// Errors which are not gRPC statuses or are lit errors already, e.g. returned by a client interceptor, are returned unchanged
+func FromGRPCError(err error) error {
+	var litErr Error
+	if errors.As(err, &litErr) {
+		return err
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() == codes.OK {
+		return err
+	}
+
+	httpErr := HTTPError{
+		Status: grpcCodeToHTTPStatus[st.Code()],
+		Code:   snakeCase(st.Code().String()),
+		Desc:   st.Message(),
+	}
+	if httpErr.Status == 0 {
+		httpErr.Status = http.StatusInternalServerError
+	}
+
+	var validationErr ValidationError
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			validationErr = make(ValidationError, len(d.GetFieldViolations()))
+			for _, violation := range d.GetFieldViolations() {
+				validationErr[violation.GetField()] = violation.GetDescription()
+			}
+		case *errdetails.ErrorInfo:
+			// The reason and HTTP status of other domains don't follow lit's error codes
+			if d.GetDomain() != grpcErrorDomain {
+				continue
+			}
+
+			httpErr.Code = d.GetReason()
+			if s, err := strconv.Atoi(d.GetMetadata()[grpcHTTPStatusKey]); err == nil {
+				httpErr.Status = s
+			}
+		case *errdetails.RetryInfo:
+			httpErr.RetryAfter = d.GetRetryDelay().AsDuration()
+		case *errdetails.LocalizedMessage:
+			httpErr.Desc = d.GetMessage()
+		}
+	}
+
+	if validationErr != nil {
+		return grpcStatusError{err: validationErr, status: st}
+	}
+
+	return grpcStatusError{err: httpErr, status: st}
+}
+
+// grpcStatusError is the lit Error converted from a gRPC status by FromGRPCError.
+// The lit Error is unwrapped for the HTTP response, the original status is used for gRPC
+type grpcStatusError struct {
+	err    error
+	status *status.Status
+}
+
+func (e grpcStatusError) Error() string {
+	return e.err.Error()
+}
+
+func (e grpcStatusError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus returns the original status, so the code and the details are kept when the error is forwarded
+func (e grpcStatusError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// GRPCStatus converts the error to gRPC status, it's used by grpc to send HTTPError as is
+func (e HTTPError) GRPCStatus() *status.Status {
+	return httpErrorStatus(context.Background(), e)
+}
+
+// GRPCStatus converts the error to gRPC status, it's used by grpc to send ValidationError as is
+func (v ValidationError) GRPCStatus() *status.Status {
+	return validationErrorStatus(context.Background(), v)
+}
+
+func httpErrorStatus(ctx context.Context, e HTTPError) *status.Status {
+	st := status.New(grpcCodeFromHTTPStatus(e.Status), e.Desc)
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Reason:   e.Code,
+			Domain:   grpcErrorDomain,
+			Metadata: map[string]string{grpcHTTPStatusKey: strconv.Itoa(e.Status)},
+		},
+	}
+
+	if e.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryAfter)})
+	}
+
+	if msg, ok := localizeErrorCode(ctx, e.Code); ok {
+		details = append(details, &errdetails.LocalizedMessage{Locale: errorLocale(ctx), Message: msg})
+	}
+
+	return withDetails(st, details...)
+}
+
+func validationErrorStatus(ctx context.Context, v ValidationError) *status.Status {
+	st := status.New(codes.InvalidArgument, validationErrorDesc)
+
+	fields := make([]string, 0, len(v))
+	for field := range v {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields) // Keep the violations order stable
+
+	badRequest := &errdetails.BadRequest{FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(fields))}
+	for idx, field := range fields {
+		badRequest.FieldViolations[idx] = &errdetails.BadRequest_FieldViolation{Field: field, Description: v[field]}
+	}
+
+	details := []protoadapt.MessageV1{
+		badRequest,
+		&errdetails.ErrorInfo{
+			Reason:   validationErrorCode,
+			Domain:   grpcErrorDomain,
+			Metadata: map[string]string{grpcHTTPStatusKey: strconv.Itoa(http.StatusBadRequest)},
+		},
+	}
+
+	if msg, ok := localizeErrorCode(ctx, validationErrorCode); ok {
+		details = append(details, &errdetails.LocalizedMessage{Locale: errorLocale(ctx), Message: msg})
+	}
+
+	return withDetails(st, details...)
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	rs, err := st.WithDetails(details...)
+	if err != nil {
+		return st // Details are best effort, keep the code and message
+	}
+
+	return rs
+}
+
+// localizeErrorCode localizes the error code by i18n, the code is used as the message ID
+func localizeErrorCode(ctx context.Context, code string) (string, bool) {
+	if code == "" {
+		return "", false
+	}
+
+	msg, err := i18n.FromContext(ctx).TryLocalize(code, nil)
+	if err != nil || msg == code {
+		return "", false // Not localized
+	}
+
+	return msg, true
+}
+
+func errorLocale(ctx context.Context) string {
+	if lang := i18n.LanguageFromContext(ctx); lang != "" {
+		return lang
+	}
+
+	return defaultLocale
+}
+
+func grpcCodeFromHTTPStatus(httpStatus int) codes.Code {
+	if code, ok := httpStatusToGRPCCode[httpStatus]; ok {
+		return code
+	}
+
+	switch {
+	case httpStatus >= http.StatusInternalServerError:
+		return codes.Internal
+	case httpStatus >= http.StatusBadRequest:
+		return codes.FailedPrecondition
+	default:
+		return codes.Unknown
+	}
+}
+
+// snakeCase converts the gRPC code name to snake case, e.g. NotFound to not_found
+func snakeCase(s string) string {
+	var b strings.Builder
+	for idx, r := range s {
+		if unicode.IsUpper(r) {
+			if idx > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
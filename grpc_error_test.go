@@ -0,0 +1,226 @@
+package lit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/viebiz/lit/i18n"
+)
+
+func TestToGRPCStatus(t *testing.T) {
+	tcs := map[string]struct {
+		givenErr        error
+		givenLocalized  map[string]string
+		expCode         codes.Code
+		expMessage      string
+		expDetails      []proto.Message
+		expEmptyDetails bool
+	}{
+		"http error": {
+			givenErr:   &HTTPError{Status: http.StatusNotFound, Code: "order_not_found", Desc: "Order not found"},
+			expCode:    codes.NotFound,
+			expMessage: "Order not found",
+			expDetails: []proto.Message{
+				&errdetails.ErrorInfo{Reason: "order_not_found", Domain: "lit", Metadata: map[string]string{"http_status": "404"}},
+			},
+		},
+		"http error - retry and localized message": {
+			givenErr:       fmt.Errorf("wrapped: %w", HTTPError{Status: http.StatusTooManyRequests, Code: "rate_limited", Desc: "Too many requests", RetryAfter: 3 * time.Second}),
+			givenLocalized: map[string]string{"rate_limited": "Quá nhiều yêu cầu"},
+			expCode:        codes.ResourceExhausted,
+			expMessage:     "Too many requests",
+			expDetails: []proto.Message{
+				&errdetails.ErrorInfo{Reason: "rate_limited", Domain: "lit", Metadata: map[string]string{"http_status": "429"}},
+				&errdetails.RetryInfo{RetryDelay: durationpb.New(3 * time.Second)},
+				&errdetails.LocalizedMessage{Locale: "en", Message: "Quá nhiều yêu cầu"},
+			},
+		},
+		"retryable error": {
+			givenErr:   fmt.Errorf("call orders: %w", testRetryableError{}),
+			expCode:    codes.Unavailable,
+			expMessage: "orders is unavailable",
+			expDetails: []proto.Message{
+				&errdetails.ErrorInfo{Reason: "circuit_open", Domain: "lit", Metadata: map[string]string{"http_status": "503"}},
+				&errdetails.RetryInfo{RetryDelay: durationpb.New(3 * time.Second)},
+			},
+		},
+		"validation error": {
+			givenErr:   ValidationError{"items[1].sku": "The sku field is required", "email": "The email field is required"},
+			expCode:    codes.InvalidArgument,
+			expMessage: "Invalid request",
+			expDetails: []proto.Message{
+				&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "email", Description: "The email field is required"},
+					{Field: "items[1].sku", Description: "The sku field is required"},
+				}},
+				&errdetails.ErrorInfo{Reason: "validation_error", Domain: "lit", Metadata: map[string]string{"http_status": "400"}},
+			},
+		},
+		"grpc status": {
+			givenErr:        status.Error(codes.AlreadyExists, "order already exists"),
+			expCode:         codes.AlreadyExists,
+			expMessage:      "order already exists",
+			expEmptyDetails: true,
+		},
+		"context deadline exceeded": {
+			givenErr:        context.DeadlineExceeded,
+			expCode:         codes.DeadlineExceeded,
+			expMessage:      "context deadline exceeded",
+			expEmptyDetails: true,
+		},
+		"unexpected error": {
+			givenErr:   errors.New("pq: connection refused"),
+			expCode:    codes.Internal,
+			expMessage: "internal error",
+			expDetails: []proto.Message{
+				&errdetails.ErrorInfo{Reason: "internal_error", Domain: "lit", Metadata: map[string]string{"http_status": "500"}},
+			},
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			ctx := context.Background()
+			if tc.givenLocalized != nil {
+				localizer := new(i18n.MockLocalizable)
+				localizer.EXPECT().TryLocalize(mock.Anything, mock.Anything).RunAndReturn(func(id string, _ map[string]interface{}) (string, error) {
+					if msg, ok := tc.givenLocalized[id]; ok {
+						return msg, nil
+					}
+					return id, nil
+				})
+				ctx = i18n.SetInContext(ctx, localizer)
+			}
+
+			// When
+			st := ToGRPCStatus(ctx, tc.givenErr)
+
+			// Then
+			require.Equal(t, tc.expCode, st.Code())
+			require.Equal(t, tc.expMessage, st.Message())
+			if tc.expEmptyDetails {
+				require.Empty(t, st.Details())
+				return
+			}
+			require.Len(t, st.Details(), len(tc.expDetails))
+			for idx, detail := range st.Details() {
+				require.True(t, proto.Equal(tc.expDetails[idx], detail.(proto.Message)), "detail %d: %v", idx, detail)
+			}
+		})
+	}
+}
+
+func TestFromGRPCError(t *testing.T) {
+	tcs := map[string]struct {
+		givenErr error
+		expErr   error
+	}{
+		"round trip - http error": {
+			givenErr: ToGRPCStatus(context.Background(), HTTPError{Status: http.StatusConflict, Code: "order_locked", Desc: "Order is locked", RetryAfter: 2 * time.Second}).Err(),
+			expErr:   HTTPError{Status: http.StatusConflict, Code: "order_locked", Desc: "Order is locked", RetryAfter: 2 * time.Second},
+		},
+		"round trip - validation error": {
+			givenErr: ToGRPCStatus(context.Background(), ValidationError{"email": "The email field is required"}).Err(),
+			expErr:   ValidationError{"email": "The email field is required"},
+		},
+		"localized message": {
+			givenErr: func() error {
+				st, _ := status.New(codes.NotFound, "Order not found").WithDetails(
+					&errdetails.LocalizedMessage{Locale: "vi", Message: "Không tìm thấy đơn hàng"},
+				)
+				return st.Err()
+			}(),
+			expErr: HTTPError{Status: http.StatusNotFound, Code: "not_found", Desc: "Không tìm thấy đơn hàng"},
+		},
+		"error info of another domain": {
+			givenErr: func() error {
+				st, _ := status.New(codes.PermissionDenied, "Quota exceeded").WithDetails(
+					&errdetails.ErrorInfo{Reason: "RATE_LIMIT_EXCEEDED", Domain: "googleapis.com", Metadata: map[string]string{"http_status": "429"}},
+				)
+				return st.Err()
+			}(),
+			expErr: HTTPError{Status: http.StatusForbidden, Code: "permission_denied", Desc: "Quota exceeded"},
+		},
+		"status without details": {
+			givenErr: status.Error(codes.Unavailable, "connection refused"),
+			expErr:   HTTPError{Status: http.StatusServiceUnavailable, Code: "unavailable", Desc: "connection refused"},
+		},
+		"lit error": {
+			givenErr: fmt.Errorf("call orders: %w", HTTPError{Status: http.StatusServiceUnavailable, Code: "circuit_open", Desc: "Orders is unavailable"}),
+			expErr:   fmt.Errorf("call orders: %w", HTTPError{Status: http.StatusServiceUnavailable, Code: "circuit_open", Desc: "Orders is unavailable"}),
+		},
+		"not a status": {
+			givenErr: context.Canceled,
+			expErr:   context.Canceled,
+		},
+		"nil": {},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// When
+			err := FromGRPCError(tc.givenErr)
+
+			// Then
+			var statusErr grpcStatusError
+			if !errors.As(err, &statusErr) {
+				require.Equal(t, tc.expErr, err)
+				return
+			}
+
+			require.Equal(t, tc.expErr, statusErr.Unwrap())
+			require.True(t, proto.Equal(status.Convert(tc.givenErr).Proto(), status.Convert(err).Proto()))
+		})
+	}
+}
+
+func TestFromGRPCError_KeepsStatus(t *testing.T) {
+	for code := codes.Canceled; code <= codes.Unauthenticated; code++ {
+		code := code
+		t.Run(code.String(), func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			givenStatus, err := status.New(code, "upstream failed").WithDetails(
+				&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{Subject: "project:lit"}}},
+			)
+			require.NoError(t, err)
+
+			// When
+			err = FromGRPCError(givenStatus.Err())
+
+			// Then
+			var litErr Error
+			require.ErrorAs(t, err, &litErr)
+			require.Equal(t, code, status.Code(err))
+			require.Equal(t, code, status.Code(fmt.Errorf("forward: %w", err)))
+			require.True(t, proto.Equal(givenStatus.Proto(), ToGRPCStatus(context.Background(), err).Proto()))
+		})
+	}
+}
+
+func TestHTTPError_GRPCStatus(t *testing.T) {
+	err := error(&HTTPError{Status: http.StatusForbidden, Code: "forbidden", Desc: "Permission denied"})
+
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Equal(t, codes.InvalidArgument, status.Code(ValidationError{"name": "The name field is required"}))
+}
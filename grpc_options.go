@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 
+	"buf.build/go/protovalidate"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
@@ -24,3 +25,14 @@ func WithDefaultInterceptors(ctx context.Context) GRPCOption {
 		)
 	}
 }
+
+// WithRequestValidation validates the incoming unary and stream messages by the protovalidate rules declared in the .proto files.
+// Invalid requests are rejected with InvalidArgument status and BadRequest field violations localized by the i18n localizer in context
+func WithRequestValidation() GRPCOption {
+	return func(opts *[]grpc.ServerOption) {
+		*opts = append(*opts,
+			grpc.ChainUnaryInterceptor(unaryValidationInterceptor(protovalidate.GlobalValidator)),
+			grpc.ChainStreamInterceptor(streamValidationInterceptor(protovalidate.GlobalValidator)),
+		)
+	}
+}
@@ -53,13 +53,27 @@ func (srv GRPCServer) Run() error {
 
 // RunWithContext starts gRPC server and manages its lifecycle using given context
 func (srv GRPCServer) RunWithContext(ctx context.Context) error {
+	return srv.serve(ctx, func() (net.Listener, error) {
+		return net.Listen("tcp", srv.addr)
+	})
+}
+
+// ServeWithContext starts gRPC server on the given listener, e.g. an in-memory bufconn listener in tests,
+// and manages its lifecycle using given context
+func (srv GRPCServer) ServeWithContext(ctx context.Context, lis net.Listener) error {
+	return srv.serve(ctx, func() (net.Listener, error) {
+		return lis, nil
+	})
+}
+
+func (srv GRPCServer) serve(ctx context.Context, listen func() (net.Listener, error)) error {
 	startupErr := make(chan error)
 
 	go func() {
 		fmt.Printf("gRPC server starting at %s\n", srv.addr)
 		defer fmt.Println("gRPC server stopped")
 
-		lis, err := net.Listen("tcp", srv.addr)
+		lis, err := listen()
 		if err != nil {
 			startupErr <- err
 			return
@@ -6,8 +6,6 @@ import (
 	"runtime/debug"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
@@ -29,7 +27,7 @@ func unaryServerInterceptor(rootCtx context.Context) grpc.UnaryServerInterceptor
 				monitoring.FromContext(ctx).Errorf(rcvErr, "Caught a panic: %s", debug.Stack())
 				endInstrumentation(rcvErr)
 
-				err = status.Errorf(codes.Internal, "internal error")
+				err = ToGRPCStatus(ctx, errGRPCInternal).Err()
 			}
 		}()
 
@@ -45,7 +43,12 @@ func unaryServerInterceptor(rootCtx context.Context) grpc.UnaryServerInterceptor
 			WithTag("grpc.response_body", string(parseProtoMessage(rs))).
 			Infof("Wrote gRPC response")
 
-		return rs, err
+		if err != nil {
+			// Convert to gRPC status with error details, the unexpected error is hidden from the client
+			return rs, ToGRPCStatus(ctx, err).Err()
+		}
+
+		return rs, nil
 	}
 }
 
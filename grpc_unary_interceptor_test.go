@@ -3,6 +3,7 @@ package lit
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -68,7 +69,25 @@ func Test_unaryServerInterceptor(t *testing.T) {
 				in:    &testdata.WeatherRequest{},
 				inErr: errors.New("expected error"),
 			},
-			expErr: errors.New("expected error"),
+			expErr: status.Error(codes.Internal, "internal error"),
+			expLogs: []map[string]interface{}{
+				{
+					"grpc.service_method": "/weather.WeatherService/GetWeatherInfo",
+					"level":               "info",
+					"msg":                 "grpc.unary_incoming_call",
+					"span_id":             "0000000000000001",
+					"trace_id":            "00000000000000000000000000000001",
+				},
+			},
+		},
+		"http-error": {
+			givenReqContext: context.Background(),
+			givenRequest:    &testdata.WeatherRequest{},
+			mockSrv: mockServiceServer{
+				in:    &testdata.WeatherRequest{},
+				inErr: &HTTPError{Status: http.StatusNotFound, Code: "weather_not_found", Desc: "Weather not found"},
+			},
+			expErr: status.Error(codes.NotFound, "Weather not found"),
 			expLogs: []map[string]interface{}{
 				{
 					"grpc.service_method": "/weather.WeatherService/GetWeatherInfo",
@@ -0,0 +1,102 @@
+package lit
+
+import (
+	"context"
+	"errors"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/viebiz/lit/i18n"
+	"github.com/viebiz/lit/monitoring"
+)
+
+func unaryValidationInterceptor(validator protovalidate.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := validateMessage(ctx, validator, req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func streamValidationInterceptor(validator protovalidate.Validator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, validatingServerStream{ServerStream: ss, validator: validator})
+	}
+}
+
+// validatingServerStream validates every message received from the client
+type validatingServerStream struct {
+	grpc.ServerStream
+	validator protovalidate.Validator
+}
+
+func (s validatingServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return validateMessage(s.Context(), s.validator, m)
+}
+
+// validateMessage validates the message by the protovalidate rules and returns InvalidArgument status
+// with the field violations localized by the i18n localizer in context
+func validateMessage(ctx context.Context, validator protovalidate.Validator, m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil // Only proto messages have validation rules
+	}
+
+	err := validator.Validate(msg)
+	if err == nil {
+		return nil
+	}
+
+	var protoErr *protovalidate.ValidationError
+	if !errors.As(err, &protoErr) {
+		// Rules cannot be compiled or evaluated, it's a bug of the .proto file instead of the request
+		monitoring.FromContext(ctx).Errorf(err, "Failed to validate gRPC request")
+		return ToGRPCStatus(ctx, errGRPCInternal).Err()
+	}
+
+	return ToGRPCStatus(ctx, convertProtoValidationErr(ctx, protoErr)).Err()
+}
+
+func convertProtoValidationErr(ctx context.Context, err *protovalidate.ValidationError) ValidationError {
+	localize := i18n.FromContext(ctx)
+
+	errs := make(ValidationError, len(err.Violations))
+	for _, violation := range err.Violations {
+		path := protovalidate.FieldPathString(violation.Proto.GetField())
+
+		field := path
+		if violation.FieldDescriptor != nil {
+			field = string(violation.FieldDescriptor.Name())
+		}
+
+		params := map[string]interface{}{
+			"Field": field,
+		}
+		if violation.FieldValue.IsValid() {
+			params["Value"] = violation.FieldValue.Interface()
+		}
+		if violation.RuleValue.IsValid() {
+			params["Condition"] = violation.RuleValue.Interface()
+		}
+
+		// Rule ID is used as the message ID, e.g. string.email or repeated.min_items
+		messageID := violation.Proto.GetRuleId()
+		msg := localize.Localize(messageID, params)
+		if msg == messageID && violation.Proto.GetMessage() != "" {
+			// Message is not translated, fallback to the message of the rule
+			msg = violation.Proto.GetMessage()
+		}
+
+		errs[path] = msg
+	}
+
+	return errs
+}
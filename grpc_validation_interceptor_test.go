@@ -0,0 +1,188 @@
+package lit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"buf.build/go/protovalidate"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/viebiz/lit/i18n"
+	"github.com/viebiz/lit/testdata/orderpb"
+)
+
+func Test_unaryValidationInterceptor(t *testing.T) {
+	tcs := map[string]struct {
+		givenReq       any
+		givenLocalized map[string]string
+		expCalled      bool
+		expCode        codes.Code
+		expViolations  map[string]string
+	}{
+		"success": {
+			givenReq: &orderpb.CreateOrderRequest{
+				CustomerEmail: "guilliman@ultramar.im",
+				Items:         []*orderpb.OrderItem{{Sku: "BOLTER-01", Quantity: 2}},
+			},
+			expCalled: true,
+			expCode:   codes.OK,
+		},
+		"success - not a proto message": {
+			givenReq:  "ping",
+			expCalled: true,
+			expCode:   codes.OK,
+		},
+		"error - field violations": {
+			givenReq: &orderpb.CreateOrderRequest{
+				CustomerEmail: "guilliman",
+				Items:         []*orderpb.OrderItem{{Sku: "BOLTER-01", Quantity: 1}, {Quantity: 0}},
+				Note:          "Deliver to the Fortress of Hera",
+			},
+			expCode: codes.InvalidArgument,
+			expViolations: map[string]string{
+				"customer_email":    "value must be a valid email address",
+				"items[1].sku":      "value is required",
+				"items[1].quantity": "value must be greater than 0",
+				"note":              "value length must be at most 20 characters",
+			},
+		},
+		"error - localized violations": {
+			givenReq: &orderpb.CreateOrderRequest{
+				CustomerEmail: "guilliman@ultramar.im",
+			},
+			givenLocalized: map[string]string{
+				"repeated.min_items": "Trường items phải có ít nhất 1 phần tử",
+			},
+			expCode: codes.InvalidArgument,
+			expViolations: map[string]string{
+				"items": "Trường items phải có ít nhất 1 phần tử",
+			},
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			ctx := context.Background()
+			if tc.givenLocalized != nil {
+				localizer := new(i18n.MockLocalizable)
+				localizer.EXPECT().Localize(mock.Anything, mock.Anything).RunAndReturn(func(id string, params map[string]interface{}) string {
+					if msg, ok := tc.givenLocalized[id]; ok {
+						require.Equal(t, "items", params["Field"])
+						require.Equal(t, uint64(1), params["Condition"])
+						return msg
+					}
+					return id
+				})
+				localizer.EXPECT().TryLocalize("validation_error", mock.Anything).Return("validation_error", nil)
+				ctx = i18n.SetInContext(ctx, localizer)
+			}
+
+			var called bool
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+				return &orderpb.CreateOrderResponse{Id: "order-1"}, nil
+			}
+
+			// When
+			intercept := unaryValidationInterceptor(protovalidate.GlobalValidator)
+			_, err := intercept(ctx, tc.givenReq, &grpc.UnaryServerInfo{FullMethod: "/order.OrderService/CreateOrder"}, handler)
+
+			// Then
+			require.Equal(t, tc.expCalled, called)
+			require.Equal(t, tc.expCode, status.Code(err))
+			if tc.expViolations != nil {
+				require.Equal(t, tc.expViolations, fieldViolations(t, err))
+			}
+		})
+	}
+}
+
+func Test_streamValidationInterceptor(t *testing.T) {
+	// Given
+	stream := &recvServerStream{
+		ctx: context.Background(),
+		msgs: []proto.Message{
+			&orderpb.CreateOrderRequest{CustomerEmail: "guilliman@ultramar.im", Items: []*orderpb.OrderItem{{Sku: "BOLTER-01", Quantity: 1}}},
+			&orderpb.CreateOrderRequest{CustomerEmail: "guilliman@ultramar.im", Items: []*orderpb.OrderItem{{Sku: "BOLTER-01", Quantity: -1}}},
+		},
+	}
+
+	var received int
+	handler := func(srv any, ss grpc.ServerStream) error {
+		for {
+			var req orderpb.CreateOrderRequest
+			if err := ss.RecvMsg(&req); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+			received++
+		}
+	}
+
+	// When
+	intercept := streamValidationInterceptor(protovalidate.GlobalValidator)
+	err := intercept(nil, stream, &grpc.StreamServerInfo{FullMethod: "/order.OrderService/ImportOrders", IsClientStream: true}, handler)
+
+	// Then
+	require.Equal(t, 1, received)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, map[string]string{"items[0].quantity": "value must be greater than 0"}, fieldViolations(t, err))
+}
+
+func fieldViolations(t *testing.T, err error) map[string]string {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			rs := map[string]string{}
+			for _, violation := range badRequest.GetFieldViolations() {
+				rs[violation.GetField()] = violation.GetDescription()
+			}
+			return rs
+		}
+	}
+
+	require.Fail(t, "missing BadRequest detail")
+	return nil
+}
+
+// recvServerStream is a server stream which receives the given messages then io.EOF
+type recvServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	msgs []proto.Message
+}
+
+func (s *recvServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *recvServerStream) RecvMsg(m any) error {
+	if len(s.msgs) == 0 {
+		return io.EOF
+	}
+
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", m)
+	}
+	proto.Merge(msg, s.msgs[0])
+	s.msgs = s.msgs[1:]
+
+	return nil
+}
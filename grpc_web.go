@@ -0,0 +1,193 @@
+package lit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/grpc"
+)
+
+// GRPCWebOption customizes the gRPC-Web and Connect handlers
+type GRPCWebOption func(cfg *grpcWebConfig)
+
+type grpcWebConfig struct {
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+}
+
+// WithGRPCWebUnaryInterceptors runs the interceptors around the unary handlers, in the given order
+func WithGRPCWebUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) GRPCWebOption {
+	return func(cfg *grpcWebConfig) {
+		cfg.unaryInterceptors = append(cfg.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithGRPCWebStreamInterceptors runs the interceptors around the server streaming handlers, in the given order
+func WithGRPCWebStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) GRPCWebOption {
+	return func(cfg *grpcWebConfig) {
+		cfg.streamInterceptors = append(cfg.streamInterceptors, interceptors...)
+	}
+}
+
+// WithGRPCWebRequestValidation validates the request messages by the protovalidate rules, the same as WithRequestValidation
+func WithGRPCWebRequestValidation() GRPCWebOption {
+	return func(cfg *grpcWebConfig) {
+		cfg.unaryInterceptors = append(cfg.unaryInterceptors, unaryValidationInterceptor(protovalidate.GlobalValidator))
+		cfg.streamInterceptors = append(cfg.streamInterceptors, streamValidationInterceptor(protovalidate.GlobalValidator))
+	}
+}
+
+// NewGRPCWebRegistrar returns the ServiceRegistrar mounting the gRPC services on the router for browser clients.
+//
+// Each method is served at POST /<package>.<Service>/<Method> speaking the gRPC-Web (application/grpc-web, +proto, +json)
+// and Connect (application/proto, application/json, application/connect+proto, application/connect+json) protocols.
+// The calls go through the router middlewares, e.g. CORS, auth and the request instrumentation, and the incoming metadata
+// is built from the request headers. Unary and server streaming methods are supported, client and bidi streaming
+// need HTTP/2 full duplex and are only served by GRPCServer.
+//
+// Example:
+//
+//	pb.RegisterOrderServiceServer(grpcSrv.Registrar(), ordersServer)
+//	pb.RegisterOrderServiceServer(lit.NewGRPCWebRegistrar(r.Route("/rpc", authMiddleware)), ordersServer)
+func NewGRPCWebRegistrar(r Router, opts ...GRPCWebOption) ServiceRegistrar {
+	var cfg grpcWebConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return grpcWebRegistrar{router: r, cfg: cfg}
+}
+
+type grpcWebRegistrar struct {
+	router Router
+	cfg    grpcWebConfig
+}
+
+func (reg grpcWebRegistrar) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	if desc.HandlerType != nil {
+		ht := reflect.TypeOf(desc.HandlerType).Elem()
+		if st := reflect.TypeOf(impl); !st.Implements(ht) {
+			panic(fmt.Sprintf("lit: RegisterService found the handler of type %v that does not satisfy %v", st, ht))
+		}
+	}
+
+	for _, m := range desc.Methods {
+		fullMethod := "/" + desc.ServiceName + "/" + m.MethodName
+		reg.router.Post(fullMethod, reg.unaryHandler(impl, fullMethod, m.Handler))
+	}
+
+	for _, s := range desc.Streams {
+		if s.ClientStreams {
+			continue // Needs full duplex
+		}
+
+		fullMethod := "/" + desc.ServiceName + "/" + s.StreamName
+		reg.router.Post(fullMethod, reg.streamHandler(impl, fullMethod, s.Handler))
+	}
+}
+
+func (reg grpcWebRegistrar) unaryHandler(impl any, fullMethod string, handler grpc.MethodHandler) HandlerFunc {
+	interceptor := chainUnaryServerInterceptors(reg.cfg.unaryInterceptors)
+
+	return func(c Context) error {
+		call, err := newWebCall(c, fullMethod, false)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := call.context()
+		defer cancel()
+
+		body, err := call.readMessage()
+		if err != nil {
+			call.writeUnaryError(ToGRPCStatus(ctx, err))
+			return nil
+		}
+
+		resp, err := handler(impl, ctx, func(m any) error {
+			return call.unmarshal(body, m)
+		}, interceptor)
+		if err != nil {
+			call.writeUnaryError(ToGRPCStatus(ctx, err))
+			return nil
+		}
+
+		call.writeUnaryResponse(ctx, resp)
+		return nil
+	}
+}
+
+func (reg grpcWebRegistrar) streamHandler(impl any, fullMethod string, handler grpc.StreamHandler) HandlerFunc {
+	interceptor := chainStreamServerInterceptors(reg.cfg.streamInterceptors)
+	info := &grpc.StreamServerInfo{FullMethod: fullMethod, IsServerStream: true}
+
+	return func(c Context) error {
+		call, err := newWebCall(c, fullMethod, true)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := call.context()
+		defer cancel()
+
+		stream := &webServerStream{call: call, ctx: ctx}
+		if interceptor != nil {
+			err = interceptor(impl, stream, info, handler)
+		} else {
+			err = handler(impl, stream)
+		}
+
+		call.endStream(ToGRPCStatus(ctx, err))
+		return nil
+	}
+}
+
+// chainUnaryServerInterceptors chains the interceptors in the given order, it returns nil when there is none
+func chainUnaryServerInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for idx := len(interceptors) - 1; idx > 0; idx-- {
+			interceptor, h := interceptors[idx], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, h)
+			}
+		}
+
+		return interceptors[0](ctx, req, info, next)
+	}
+}
+
+// chainStreamServerInterceptors chains the interceptors in the given order, it returns nil when there is none
+func chainStreamServerInterceptors(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for idx := len(interceptors) - 1; idx > 0; idx-- {
+			interceptor, h := interceptors[idx], next
+			next = func(srv any, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, h)
+			}
+		}
+
+		return interceptors[0](srv, ss, info, next)
+	}
+}
+
+// grpcWebUnsupportedMediaType is returned when the content type is not a gRPC-Web or Connect one supported by the method
+func grpcWebUnsupportedMediaType(contentType string) HTTPError {
+	return HTTPError{
+		Status: http.StatusUnsupportedMediaType,
+		Code:   "unsupported_media_type",
+		Desc:   fmt.Sprintf("Unsupported content type %q", contentType),
+	}
+}
@@ -0,0 +1,534 @@
+package lit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	grpcWebContentType            = "application/grpc-web"
+	grpcWebProtoContentType       = "application/grpc-web+proto"
+	grpcWebJSONContentType        = "application/grpc-web+json"
+	connectProtoContentType       = "application/proto"
+	connectJSONContentType        = "application/json"
+	connectStreamProtoContentType = "application/connect+proto"
+	connectStreamJSONContentType  = "application/connect+json"
+
+	grpcTimeoutHeader    = "Grpc-Timeout"
+	connectTimeoutHeader = "Connect-Timeout-Ms"
+	connectTrailerPrefix = "Trailer-"
+	grpcStatusKey        = "grpc-status"
+	grpcMessageKey       = "grpc-message"
+	grpcStatusDetailsKey = "grpc-status-details-bin"
+	binaryMetadataSuffix = "-bin"
+	protoTypeURLPrefix   = "type.googleapis.com/"
+
+	envelopeHeaderSize     = 5
+	envelopeFlagCompressed = 0x01
+	envelopeFlagEndStream  = 0x02 // Connect end of stream
+	envelopeFlagTrailer    = 0x80 // gRPC-Web trailers
+
+	// maxWebCallMessageSize is the max request message size, the same as the gRPC server default
+	maxWebCallMessageSize = 4 << 20
+)
+
+type webProtocol int
+
+const (
+	webProtocolGRPCWeb webProtocol = iota
+	webProtocolConnect
+)
+
+// webCall is a gRPC-Web or Connect call served on the router,
+// it implements grpc.ServerTransportStream so the handlers can set the headers and trailers by grpc.SetHeader
+type webCall struct {
+	c           Context
+	method      string
+	protocol    webProtocol
+	json        bool
+	enveloped   bool // The messages are length-prefixed, it's false for Connect unary calls
+	contentType string
+
+	mu         sync.Mutex
+	header     metadata.MD
+	trailer    metadata.MD
+	headerSent bool
+}
+
+func newWebCall(c Context, fullMethod string, serverStream bool) (*webCall, error) {
+	contentType := c.Request().Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	call := &webCall{c: c, method: fullMethod, enveloped: true, contentType: mediaType}
+	switch mediaType {
+	case grpcWebContentType, grpcWebProtoContentType:
+		call.protocol = webProtocolGRPCWeb
+	case grpcWebJSONContentType:
+		call.protocol, call.json = webProtocolGRPCWeb, true
+	case connectProtoContentType, connectJSONContentType:
+		if serverStream {
+			return nil, grpcWebUnsupportedMediaType(contentType)
+		}
+		call.protocol, call.json, call.enveloped = webProtocolConnect, mediaType == connectJSONContentType, false
+	case connectStreamProtoContentType, connectStreamJSONContentType:
+		if !serverStream {
+			return nil, grpcWebUnsupportedMediaType(contentType)
+		}
+		call.protocol, call.json = webProtocolConnect, mediaType == connectStreamJSONContentType
+	default:
+		return nil, grpcWebUnsupportedMediaType(contentType)
+	}
+
+	return call, nil
+}
+
+// context returns the call context with the incoming metadata from the request headers and the deadline requested by the client
+func (call *webCall) context() (context.Context, context.CancelFunc) {
+	req := call.c.Request()
+
+	md := make(metadata.MD, len(req.Header))
+	for key, values := range req.Header {
+		key = strings.ToLower(key)
+		if !strings.HasSuffix(key, binaryMetadataSuffix) {
+			md[key] = values
+			continue
+		}
+
+		for _, v := range values {
+			if b, err := decodeBinaryMetadata(v); err == nil {
+				md[key] = append(md[key], string(b))
+			}
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(req.Context(), md)
+	ctx = grpc.NewContextWithServerTransportStream(ctx, call)
+
+	if timeout, ok := call.timeout(); ok {
+		return context.WithTimeout(ctx, timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+func (call *webCall) timeout() (time.Duration, bool) {
+	header := call.c.Request().Header
+	if call.protocol == webProtocolConnect {
+		ms, err := strconv.ParseInt(header.Get(connectTimeoutHeader), 10, 64)
+		return time.Duration(ms) * time.Millisecond, err == nil && ms > 0
+	}
+
+	return parseGRPCTimeout(header.Get(grpcTimeoutHeader))
+}
+
+// Method implements grpc.ServerTransportStream
+func (call *webCall) Method() string {
+	return call.method
+}
+
+// SetHeader implements grpc.ServerTransportStream
+func (call *webCall) SetHeader(md metadata.MD) error {
+	call.mu.Lock()
+	defer call.mu.Unlock()
+
+	if call.headerSent {
+		return status.Error(codes.Internal, "headers already sent")
+	}
+	call.header = metadata.Join(call.header, md)
+
+	return nil
+}
+
+// SendHeader implements grpc.ServerTransportStream
+func (call *webCall) SendHeader(md metadata.MD) error {
+	if err := call.SetHeader(md); err != nil {
+		return err
+	}
+
+	call.sendHeader(http.StatusOK, call.responseContentType())
+	return nil
+}
+
+// SetTrailer implements grpc.ServerTransportStream
+func (call *webCall) SetTrailer(md metadata.MD) error {
+	call.mu.Lock()
+	defer call.mu.Unlock()
+
+	call.trailer = metadata.Join(call.trailer, md)
+	return nil
+}
+
+// sendHeader writes the response headers once with the headers set by the handler
+func (call *webCall) sendHeader(code int, contentType string) {
+	call.mu.Lock()
+	defer call.mu.Unlock()
+
+	if call.headerSent {
+		return
+	}
+	call.headerSent = true
+
+	h := call.c.Writer().Header()
+	h.Set("Content-Type", contentType)
+	setMetadataHeaders(h, "", call.header)
+	if call.protocol == webProtocolConnect && !call.enveloped {
+		setMetadataHeaders(h, connectTrailerPrefix, call.trailer) // Connect unary sends the trailers as headers
+	}
+
+	call.c.Writer().WriteHeader(code)
+}
+
+func (call *webCall) responseContentType() string {
+	if call.protocol == webProtocolConnect && call.json && !call.enveloped {
+		return call.contentType + "; charset=utf-8"
+	}
+
+	return call.contentType
+}
+
+// readMessage reads the request message, the enveloped request must have a single uncompressed message
+func (call *webCall) readMessage() ([]byte, error) {
+	body := call.c.Request().Body
+	if !call.enveloped {
+		b, err := io.ReadAll(io.LimitReader(body, maxWebCallMessageSize+1))
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if len(b) > maxWebCallMessageSize {
+			return nil, status.Errorf(codes.ResourceExhausted, "message size %d exceeds the limit %d", len(b), maxWebCallMessageSize)
+		}
+
+		return b, nil
+	}
+
+	var prefix [envelopeHeaderSize]byte
+	if _, err := io.ReadFull(body, prefix[:]); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "missing request message")
+	}
+	if prefix[0]&envelopeFlagCompressed != 0 {
+		return nil, status.Error(codes.Unimplemented, "compressed messages are not supported")
+	}
+
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > maxWebCallMessageSize {
+		return nil, status.Errorf(codes.ResourceExhausted, "message size %d exceeds the limit %d", size, maxWebCallMessageSize)
+	}
+
+	b := make([]byte, size)
+	if _, err := io.ReadFull(body, b); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "incomplete request message")
+	}
+
+	return b, nil
+}
+
+func (call *webCall) unmarshal(b []byte, m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "message %T is not a proto message", m)
+	}
+
+	var err error
+	if call.json {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(b, msg)
+	} else {
+		err = proto.Unmarshal(b, msg)
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request message: %v", err)
+	}
+
+	return nil
+}
+
+func (call *webCall) marshal(m any) ([]byte, error) {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "message %T is not a proto message", m)
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	if call.json {
+		b, err = protojson.Marshal(msg)
+	} else {
+		b, err = proto.Marshal(msg)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid response message: %v", err)
+	}
+
+	return b, nil
+}
+
+// writeMessage writes an enveloped response message and flushes it to the client
+func (call *webCall) writeMessage(m any) error {
+	b, err := call.marshal(m)
+	if err != nil {
+		return err
+	}
+
+	call.sendHeader(http.StatusOK, call.responseContentType())
+	if err := writeEnvelope(call.c.Writer(), 0, b); err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	call.c.Writer().Flush()
+
+	return nil
+}
+
+func (call *webCall) writeUnaryResponse(ctx context.Context, resp any) {
+	if call.enveloped {
+		if err := call.writeMessage(resp); err != nil {
+			call.endStream(ToGRPCStatus(ctx, err))
+			return
+		}
+
+		call.endStream(nil)
+		return
+	}
+
+	b, err := call.marshal(resp)
+	if err != nil {
+		call.writeUnaryError(ToGRPCStatus(ctx, err))
+		return
+	}
+
+	call.sendHeader(http.StatusOK, call.responseContentType())
+	_, _ = call.c.Writer().Write(b)
+}
+
+func (call *webCall) writeUnaryError(st *status.Status) {
+	if call.enveloped {
+		call.endStream(st)
+		return
+	}
+
+	code := grpcCodeToHTTPStatus[st.Code()]
+	if code == 0 {
+		code = http.StatusInternalServerError
+	}
+
+	call.sendHeader(code, connectJSONContentType)
+	_ = json.NewEncoder(call.c.Writer()).Encode(newConnectError(st))
+}
+
+// endStream writes the status and the trailers after the response messages, st is nil or OK on success
+func (call *webCall) endStream(st *status.Status) {
+	call.sendHeader(http.StatusOK, call.responseContentType())
+
+	call.mu.Lock()
+	trailer := call.trailer
+	call.mu.Unlock()
+
+	w := call.c.Writer()
+	if call.protocol == webProtocolConnect {
+		end := connectEndStream{Metadata: trailer}
+		if st != nil && st.Code() != codes.OK {
+			end.Error = newConnectError(st)
+		}
+
+		b, _ := json.Marshal(end)
+		_ = writeEnvelope(w, envelopeFlagEndStream, b)
+		w.Flush()
+		return
+	}
+
+	h := make(http.Header, len(trailer)+3)
+	if st == nil {
+		st = status.New(codes.OK, "")
+	}
+	h.Set(grpcStatusKey, strconv.Itoa(int(st.Code())))
+	if msg := st.Message(); msg != "" {
+		h.Set(grpcMessageKey, encodeGRPCMessage(msg))
+	}
+	if st.Code() != codes.OK && len(st.Proto().GetDetails()) > 0 {
+		if b, err := proto.Marshal(st.Proto()); err == nil {
+			h.Set(grpcStatusDetailsKey, base64.RawStdEncoding.EncodeToString(b))
+		}
+	}
+	setMetadataHeaders(h, "", trailer)
+
+	var sb strings.Builder
+	for key, values := range h {
+		for _, v := range values {
+			sb.WriteString(strings.ToLower(key) + ": " + v + "\r\n")
+		}
+	}
+
+	_ = writeEnvelope(w, envelopeFlagTrailer, []byte(sb.String()))
+	w.Flush()
+}
+
+// webServerStream is the grpc.ServerStream of a server streaming call, the single request message is read on the first RecvMsg
+type webServerStream struct {
+	call     *webCall
+	ctx      context.Context
+	received bool
+}
+
+func (s *webServerStream) SetHeader(md metadata.MD) error {
+	return s.call.SetHeader(md)
+}
+
+func (s *webServerStream) SendHeader(md metadata.MD) error {
+	return s.call.SendHeader(md)
+}
+
+func (s *webServerStream) SetTrailer(md metadata.MD) {
+	_ = s.call.SetTrailer(md)
+}
+
+func (s *webServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *webServerStream) SendMsg(m any) error {
+	if err := s.ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	return s.call.writeMessage(m)
+}
+
+func (s *webServerStream) RecvMsg(m any) error {
+	if s.received {
+		return io.EOF
+	}
+	s.received = true
+
+	b, err := s.call.readMessage()
+	if err != nil {
+		return err
+	}
+
+	return s.call.unmarshal(b, m)
+}
+
+// connectError is the error of the Connect protocol
+type connectError struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message,omitempty"`
+	Details []connectErrorDetail `json:"details,omitempty"`
+}
+
+type connectErrorDetail struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// connectEndStream is the last message of a Connect stream
+type connectEndStream struct {
+	Error    *connectError `json:"error,omitempty"`
+	Metadata metadata.MD   `json:"metadata,omitempty"`
+}
+
+func newConnectError(st *status.Status) *connectError {
+	e := &connectError{
+		Code:    snakeCase(st.Code().String()),
+		Message: st.Message(),
+	}
+
+	for _, detail := range st.Proto().GetDetails() {
+		e.Details = append(e.Details, connectErrorDetail{
+			Type:  strings.TrimPrefix(detail.GetTypeUrl(), protoTypeURLPrefix),
+			Value: base64.RawStdEncoding.EncodeToString(detail.GetValue()),
+		})
+	}
+
+	return e
+}
+
+func writeEnvelope(w io.Writer, flags byte, b []byte) error {
+	var prefix [envelopeHeaderSize]byte
+	prefix[0] = flags
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(b)))
+
+	if _, err := w.Write(prefix[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+
+	return err
+}
+
+// setMetadataHeaders sets the metadata to the headers with the key prefix, the binary values are base64 encoded
+func setMetadataHeaders(h http.Header, prefix string, md metadata.MD) {
+	for key, values := range md {
+		for _, v := range values {
+			if strings.HasSuffix(key, binaryMetadataSuffix) {
+				v = base64.RawStdEncoding.EncodeToString([]byte(v))
+			}
+			h.Add(prefix+key, v)
+		}
+	}
+}
+
+func decodeBinaryMetadata(v string) ([]byte, error) {
+	if len(v)%4 == 0 {
+		return base64.StdEncoding.DecodeString(v)
+	}
+
+	return base64.RawStdEncoding.DecodeString(v)
+}
+
+// parseGRPCTimeout parses the grpc-timeout header, e.g. 100m, up to 8 digits followed by the unit
+func parseGRPCTimeout(v string) (time.Duration, bool) {
+	if len(v) < 2 || len(v) > 9 {
+		return 0, false
+	}
+
+	n, err := strconv.ParseInt(v[:len(v)-1], 10, 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+	unit, ok := units[v[len(v)-1]]
+	if !ok {
+		return 0, false
+	}
+
+	return time.Duration(n) * unit, true
+}
+
+// encodeGRPCMessage percent-encodes the grpc-message value as the gRPC spec
+func encodeGRPCMessage(msg string) string {
+	var sb strings.Builder
+	for idx := 0; idx < len(msg); idx++ {
+		ch := msg[idx]
+		if ch >= ' ' && ch <= '~' && ch != '%' {
+			sb.WriteByte(ch)
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%%%02X", ch))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,405 @@
+package lit
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/viebiz/lit/grpcclient/testdata"
+	"github.com/viebiz/lit/monitoring"
+)
+
+func TestGRPCWebRegistrar_Unary(t *testing.T) {
+	req := &testdata.WeatherRequest{Date: "M41.993.32"}
+	resp := &testdata.WeatherResponse{
+		WeatherDetails: []*testdata.WeatherDetail{
+			{Location: "Hive City, Necromunda", Date: "M41.993.32", Description: "Toxic smog", Temperature: 42.7},
+		},
+	}
+	protoReq, err := proto.Marshal(req)
+	require.NoError(t, err)
+	jsonReq, err := protojson.Marshal(req)
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		givenContentType string
+		givenBody        []byte
+		givenErr         error
+		expStatus        int
+		expContentType   string
+		expMessages      []proto.Message
+		expTrailer       string
+		expBody          string
+	}{
+		"success - connect proto": {
+			givenContentType: "application/proto",
+			givenBody:        protoReq,
+			expStatus:        http.StatusOK,
+			expContentType:   "application/proto",
+			expMessages:      []proto.Message{resp},
+		},
+		"success - connect json": {
+			givenContentType: "application/json",
+			givenBody:        jsonReq,
+			expStatus:        http.StatusOK,
+			expContentType:   "application/json; charset=utf-8",
+			expMessages:      []proto.Message{resp},
+		},
+		"success - grpc-web": {
+			givenContentType: "application/grpc-web+proto",
+			givenBody:        envelope(0, protoReq),
+			expStatus:        http.StatusOK,
+			expContentType:   "application/grpc-web+proto",
+			expMessages:      []proto.Message{resp},
+			expTrailer:       "grpc-status: 0\r\nx-served-by: lit\r\n",
+		},
+		"success - grpc-web json": {
+			givenContentType: "application/grpc-web+json",
+			givenBody:        envelope(0, jsonReq),
+			expStatus:        http.StatusOK,
+			expContentType:   "application/grpc-web+json",
+			expMessages:      []proto.Message{resp},
+			expTrailer:       "grpc-status: 0\r\nx-served-by: lit\r\n",
+		},
+		"error - connect": {
+			givenContentType: "application/json",
+			givenBody:        jsonReq,
+			givenErr:         HTTPError{Status: http.StatusNotFound, Code: "weather_not_found", Desc: "Weather not found"},
+			expStatus:        http.StatusNotFound,
+			expContentType:   "application/json",
+			expBody:          `{"code":"not_found","message":"Weather not found","details":[{"type":"google.rpc.ErrorInfo","value":"ChF3ZWF0aGVyX25vdF9mb3VuZBIDbGl0GhIKC2h0dHBfc3RhdHVzEgM0MDQ"}]}`,
+		},
+		"error - grpc-web": {
+			givenContentType: "application/grpc-web",
+			givenBody:        envelope(0, protoReq),
+			givenErr:         HTTPError{Status: http.StatusConflict, Code: "weather_locked", Desc: "Weather is 100% locked"},
+			expStatus:        http.StatusOK,
+			expContentType:   "application/grpc-web",
+			expTrailer: "grpc-message: Weather is 100%25 locked\r\n" +
+				"grpc-status-details-bin: CAoSFldlYXRoZXIgaXMgMTAwJSBsb2NrZWQaVQoodHlwZS5nb29nbGVhcGlzLmNvbS9nb29nbGUucnBjLkVycm9ySW5mbxIpCg53ZWF0aGVyX2xvY2tlZBIDbGl0GhIKC2h0dHBfc3RhdHVzEgM0MDk\r\n" +
+				"grpc-status: 10\r\nx-served-by: lit\r\n",
+		},
+		"error - invalid message": {
+			givenContentType: "application/json",
+			givenBody:        []byte(`{"date": 1}`),
+			expStatus:        http.StatusBadRequest,
+			expContentType:   "application/json",
+		},
+		"error - unsupported content type": {
+			givenContentType: "text/plain",
+			givenBody:        []byte("hello"),
+			expStatus:        http.StatusUnsupportedMediaType,
+			expContentType:   "application/json",
+			expBody:          `{"error":"unsupported_media_type","error_description":"Unsupported content type \"text/plain\""}`,
+		},
+		"error - connect stream content type on unary method": {
+			givenContentType: "application/connect+proto",
+			givenBody:        envelope(0, protoReq),
+			expStatus:        http.StatusUnsupportedMediaType,
+			expContentType:   "application/json",
+		},
+	}
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			svc := &webWeatherService{resp: resp, err: tc.givenErr}
+			handler := newGRPCWebTestHandler(t, svc)
+
+			r := httptest.NewRequest(http.MethodPost, "/weather.WeatherService/GetWeatherInfo", bytes.NewReader(tc.givenBody))
+			r.Header.Set("Content-Type", tc.givenContentType)
+			r.Header.Set("X-Client", "browser")
+			w := httptest.NewRecorder()
+
+			// When
+			handler.ServeHTTP(w, r)
+
+			// Then
+			require.Equal(t, tc.expStatus, w.Code)
+			require.Equal(t, tc.expContentType, w.Header().Get("Content-Type"))
+			if tc.expStatus != http.StatusOK {
+				if tc.expBody != "" {
+					require.JSONEq(t, tc.expBody, w.Body.String())
+				}
+				return
+			}
+
+			require.Equal(t, "M41.993.32", svc.gotDate)
+			require.Equal(t, []string{"browser"}, svc.gotClient)
+			require.Equal(t, "lit", w.Header().Get("X-Served-By"))
+
+			json := strings.Contains(tc.givenContentType, "json")
+			if !strings.HasPrefix(tc.givenContentType, "application/grpc-web") {
+				require.Equal(t, "lit", w.Header().Get("Trailer-X-Served-By"))
+				requireProtoEqual(t, json, tc.expMessages[0], w.Body.Bytes())
+				return
+			}
+
+			frames := readEnvelopes(t, w.Body.Bytes())
+			require.Len(t, frames, len(tc.expMessages)+1)
+			for idx, expMsg := range tc.expMessages {
+				require.Equal(t, byte(0), frames[idx].flags)
+				requireProtoEqual(t, json, expMsg, frames[idx].data)
+			}
+			require.Equal(t, byte(0x80), frames[len(frames)-1].flags)
+			require.Equal(t, tc.expTrailer, sortTrailer(string(frames[len(frames)-1].data)))
+		})
+	}
+}
+
+func TestGRPCWebRegistrar_ServerStream(t *testing.T) {
+	req := &testdata.WeatherRequest{Date: "M41.993.32"}
+	details := []*testdata.WeatherDetail{
+		{Location: "Hive City, Necromunda", Description: "Toxic smog"},
+		{Location: "Macragge", Description: "Freezing winds"},
+	}
+	protoReq, err := proto.Marshal(req)
+	require.NoError(t, err)
+	jsonReq, err := protojson.Marshal(req)
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		givenContentType string
+		givenBody        []byte
+		givenErr         error
+		expMessages      int
+		expEnd           string
+	}{
+		"success - grpc-web": {
+			givenContentType: "application/grpc-web+proto",
+			givenBody:        envelope(0, protoReq),
+			expMessages:      2,
+			expEnd:           "grpc-status: 0\r\nx-served-by: lit\r\n",
+		},
+		"success - connect json": {
+			givenContentType: "application/connect+json",
+			givenBody:        envelope(0, jsonReq),
+			expMessages:      2,
+			expEnd:           `{"metadata":{"x-served-by":["lit"]}}`,
+		},
+		"error - connect after messages": {
+			givenContentType: "application/connect+proto",
+			givenBody:        envelope(0, protoReq),
+			givenErr:         HTTPError{Status: http.StatusServiceUnavailable, Code: "storm", Desc: "Warp storm"},
+			expMessages:      2,
+			expEnd:           `{"error":{"code":"unavailable","message":"Warp storm","details":[{"type":"google.rpc.ErrorInfo","value":"CgVzdG9ybRIDbGl0GhIKC2h0dHBfc3RhdHVzEgM1MDM"}]},"metadata":{"x-served-by":["lit"]}}`,
+		},
+		"error - grpc-web missing request": {
+			givenContentType: "application/grpc-web",
+			expEnd:           "grpc-message: missing request message\r\ngrpc-status: 3\r\n",
+		},
+	}
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			svc := &webWeatherService{details: details, err: tc.givenErr}
+			handler := newGRPCWebTestHandler(t, svc)
+
+			r := httptest.NewRequest(http.MethodPost, "/weather.WeatherService/StreamWeather", bytes.NewReader(tc.givenBody))
+			r.Header.Set("Content-Type", tc.givenContentType)
+			w := httptest.NewRecorder()
+
+			// When
+			handler.ServeHTTP(w, r)
+
+			// Then
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, tc.givenContentType, w.Header().Get("Content-Type"))
+
+			frames := readEnvelopes(t, w.Body.Bytes())
+			require.Len(t, frames, tc.expMessages+1)
+			json := strings.HasSuffix(tc.givenContentType, "json")
+			for idx := 0; idx < tc.expMessages; idx++ {
+				requireProtoEqual(t, json, details[idx], frames[idx].data)
+			}
+
+			end := frames[len(frames)-1]
+			if strings.HasPrefix(tc.givenContentType, "application/connect") {
+				require.Equal(t, byte(0x02), end.flags)
+				require.JSONEq(t, tc.expEnd, string(end.data))
+				return
+			}
+			require.Equal(t, byte(0x80), end.flags)
+			require.Equal(t, tc.expEnd, sortTrailer(string(end.data)))
+		})
+	}
+}
+
+func TestGRPCWebRegistrar_Routes(t *testing.T) {
+	// Given
+	r := NewRouter(context.Background())
+
+	// When
+	testdata.RegisterWeatherServiceServer(NewGRPCWebRegistrar(r.Route("/rpc")), &webWeatherService{})
+
+	// Then
+	require.ElementsMatch(t, RoutesInfo{
+		{Method: http.MethodPost, Path: "/rpc/weather.WeatherService/GetWeatherInfo"},
+		{Method: http.MethodPost, Path: "/rpc/weather.WeatherService/StreamWeather"},
+	}, r.Routes())
+}
+
+func TestChainUnaryServerInterceptors(t *testing.T) {
+	// Given
+	var calls []string
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			calls = append(calls, name+":"+info.FullMethod)
+			return handler(ctx, req)
+		}
+	}
+	chain := chainUnaryServerInterceptors([]grpc.UnaryServerInterceptor{interceptor("first"), interceptor("second")})
+
+	// When
+	resp, err := chain(context.Background(), "req", &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, func(ctx context.Context, req any) (any, error) {
+		calls = append(calls, "handler")
+		return "resp", nil
+	})
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "resp", resp)
+	require.Equal(t, []string{"first:/svc/Method", "second:/svc/Method", "handler"}, calls)
+	require.Nil(t, chainUnaryServerInterceptors(nil))
+}
+
+func TestParseGRPCTimeout(t *testing.T) {
+	tcs := map[string]struct {
+		given    string
+		expected int64
+		expOK    bool
+	}{
+		"milliseconds":    {given: "100m", expected: 100_000_000, expOK: true},
+		"seconds":         {given: "2S", expected: 2_000_000_000, expOK: true},
+		"empty":           {given: ""},
+		"invalid unit":    {given: "10x"},
+		"too many digits": {given: "123456789m"},
+	}
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// When
+			d, ok := parseGRPCTimeout(tc.given)
+
+			// Then
+			require.Equal(t, tc.expOK, ok)
+			require.Equal(t, tc.expected, d.Nanoseconds())
+		})
+	}
+}
+
+// webWeatherService reads the incoming metadata and sets the header and trailer as any gRPC handler
+type webWeatherService struct {
+	testdata.UnimplementedWeatherServiceServer
+	resp    *testdata.WeatherResponse
+	details []*testdata.WeatherDetail
+	err     error
+
+	gotDate   string
+	gotClient []string
+}
+
+func (s *webWeatherService) GetWeatherInfo(ctx context.Context, req *testdata.WeatherRequest) (*testdata.WeatherResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.gotDate, s.gotClient = req.GetDate(), md.Get("x-client")
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs("x-served-by", "lit")); err != nil {
+		return nil, err
+	}
+	if err := grpc.SetTrailer(ctx, metadata.Pairs("x-served-by", "lit")); err != nil {
+		return nil, err
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.resp, nil
+}
+
+func (s *webWeatherService) StreamWeather(req *testdata.WeatherRequest, stream grpc.ServerStreamingServer[testdata.WeatherDetail]) error {
+	stream.SetTrailer(metadata.Pairs("x-served-by", "lit"))
+	for _, detail := range s.details {
+		if err := stream.Send(detail); err != nil {
+			return err
+		}
+	}
+
+	return s.err
+}
+
+func newGRPCWebTestHandler(t *testing.T, svc testdata.WeatherServiceServer) http.Handler {
+	t.Helper()
+
+	m, err := monitoring.New(monitoring.Config{Writer: &bytes.Buffer{}})
+	require.NoError(t, err)
+
+	r := NewRouter(monitoring.SetInContext(context.Background(), m))
+	testdata.RegisterWeatherServiceServer(NewGRPCWebRegistrar(r), svc)
+
+	return r.Handler()
+}
+
+type envelopeFrame struct {
+	flags byte
+	data  []byte
+}
+
+func envelope(flags byte, data []byte) []byte {
+	b := make([]byte, 5, 5+len(data))
+	b[0] = flags
+	binary.BigEndian.PutUint32(b[1:], uint32(len(data)))
+	return append(b, data...)
+}
+
+func readEnvelopes(t *testing.T, b []byte) []envelopeFrame {
+	t.Helper()
+
+	var frames []envelopeFrame
+	for len(b) > 0 {
+		require.GreaterOrEqual(t, len(b), 5)
+		size := int(binary.BigEndian.Uint32(b[1:5]))
+		require.GreaterOrEqual(t, len(b), 5+size)
+		frames = append(frames, envelopeFrame{flags: b[0], data: b[5 : 5+size]})
+		b = b[5+size:]
+	}
+
+	return frames
+}
+
+func requireProtoEqual(t *testing.T, isJSON bool, expected proto.Message, b []byte) {
+	t.Helper()
+
+	actual := expected.ProtoReflect().New().Interface()
+	if isJSON {
+		require.NoError(t, protojson.Unmarshal(b, actual))
+	} else {
+		require.NoError(t, proto.Unmarshal(b, actual))
+	}
+	require.True(t, proto.Equal(expected, actual), "expected %v, got %v", expected, actual)
+}
+
+// sortTrailer sorts the trailer lines, they are written in the map order
+func sortTrailer(trailer string) string {
+	lines := strings.SplitAfter(trailer, "\r\n")
+	sort.Strings(lines)
+
+	return strings.Join(lines, "")
+}
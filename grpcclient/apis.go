@@ -2,12 +2,84 @@ package grpcclient
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
 
-	"google.golang.org/grpc"
+	pkgerrors "github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+var (
+	// ErrInvalidCACert means the CA certificate file has no valid PEM certificate
+	ErrInvalidCACert = errors.New("invalid CA certificate")
+)
+
 // NewUnauthenticatedConnection initializes and returns a new unauthenticated grpc clientConn for unary calls
-func NewUnauthenticatedConnection(ctx context.Context, addr string) (Conn, error) {
-	return initUnaryClient(ctx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewUnauthenticatedConnection(ctx context.Context, addr string, opts ...ConnOption) (Conn, error) {
+	return initUnaryClient(ctx, addr, insecure.NewCredentials(), opts...)
+}
+
+// NewTLSConnection initializes and returns a new grpc clientConn secured by TLS,
+// the server certificate is verified by the system roots when tlsCfg.RootCAs is nil
+func NewTLSConnection(ctx context.Context, addr string, tlsCfg *tls.Config, opts ...ConnOption) (Conn, error) {
+	if tlsCfg == nil {
+		tlsCfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return initUnaryClient(ctx, addr, credentials.NewTLS(tlsCfg), opts...)
+}
+
+// MTLSConfig holds the PEM files for mutual TLS
+type MTLSConfig struct {
+	CertFile   string // Client certificate
+	KeyFile    string // Client private key
+	CAFile     string // CA verifying the server certificate, the system roots are used when empty
+	ServerName string // Overrides the server name used to verify the server certificate
+}
+
+// NewMTLSConnection initializes and returns a new grpc clientConn secured by mutual TLS
+func NewMTLSConnection(ctx context.Context, addr string, mtlsCfg MTLSConfig, opts ...ConnOption) (Conn, error) {
+	tlsCfg, err := mtlsCfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTLSConnection(ctx, addr, tlsCfg, opts...)
+}
+
+// NewWithOAuth initializes and returns a new grpc clientConn secured by TLS which attaches
+// the OAuth2 client credentials token to every call
+func NewWithOAuth(ctx context.Context, addr string, tlsCfg *tls.Config, oAuthCfg OAuthConfig, opts ...ConnOption) (Conn, error) {
+	return NewTLSConnection(ctx, addr, tlsCfg, append([]ConnOption{WithOAuth(oAuthCfg)}, opts...)...)
+}
+
+func (cfg MTLSConfig) tlsConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ServerName:   cfg.ServerName,
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.CAFile != "" {
+		b, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, pkgerrors.WithStack(err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, pkgerrors.WithStack(ErrInvalidCACert)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	return tlsCfg, nil
 }
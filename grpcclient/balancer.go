@@ -0,0 +1,127 @@
+package grpcclient
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	_ "google.golang.org/grpc/health" // Registers the client health check used by WithHealthCheck
+	"google.golang.org/grpc/resolver"
+)
+
+// LoadBalancingPolicy is the policy picking the backend of each call
+type LoadBalancingPolicy string
+
+const (
+	// PickFirst sends all the calls to the first healthy backend, it's the gRPC default
+	PickFirst LoadBalancingPolicy = "pick_first"
+	// RoundRobin spreads the calls evenly across the healthy backends
+	RoundRobin LoadBalancingPolicy = "round_robin"
+	// WeightedRoundRobin spreads the calls across the healthy backends proportionally to Endpoint.Weight
+	WeightedRoundRobin LoadBalancingPolicy = "lit_weighted_round_robin"
+)
+
+func init() {
+	balancer.Register(weightedBalancerBuilder{})
+}
+
+// weightAttributeKey is the key of the Endpoint weight in the address balancer attributes
+type weightAttributeKey struct{}
+
+// weightedBalancerBuilder builds the base balancer with the weights of the latest resolver update
+type weightedBalancerBuilder struct{}
+
+func (weightedBalancerBuilder) Name() string {
+	return string(WeightedRoundRobin)
+}
+
+func (weightedBalancerBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	b := &weightedBalancer{weights: resolver.NewAddressMapV2[uint32]()}
+	b.Balancer = base.NewBalancerBuilder(string(WeightedRoundRobin), &weightedPickerBuilder{balancer: b}, base.Config{HealthCheck: true}).
+		Build(cc, opts)
+
+	return b
+}
+
+// weightedBalancer keeps the weights of the resolved addresses. The base balancer keeps the address of the
+// SubConn it was created with, so the weight changes of the existing backends are only known from the updates
+type weightedBalancer struct {
+	balancer.Balancer
+
+	// weights is only accessed by the balancer calls, gRPC doesn't call them concurrently
+	weights *resolver.AddressMapV2[uint32]
+}
+
+func (b *weightedBalancer) UpdateClientConnState(state balancer.ClientConnState) error {
+	weights := resolver.NewAddressMapV2[uint32]()
+	for _, addr := range state.ResolverState.Addresses {
+		weights.Set(addr, addressWeight(addr))
+	}
+	b.weights = weights
+
+	// The picker is rebuilt with the new weights
+	return b.Balancer.UpdateClientConnState(state)
+}
+
+// addressWeight returns the Endpoint weight of the address, 0 means 1
+func addressWeight(addr resolver.Address) uint32 {
+	weight, _ := addr.BalancerAttributes.Value(weightAttributeKey{}).(uint32)
+	if weight == 0 {
+		return 1
+	}
+
+	return weight
+}
+
+type weightedPickerBuilder struct {
+	balancer *weightedBalancer
+}
+
+func (pb *weightedPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+
+	p := &weightedPicker{items: make([]*weightedItem, 0, len(info.ReadySCs))}
+	for sc, scInfo := range info.ReadySCs {
+		weight, ok := pb.balancer.weights.Get(scInfo.Address)
+		if !ok {
+			weight = addressWeight(scInfo.Address)
+		}
+		p.items = append(p.items, &weightedItem{subConn: sc, weight: int64(weight)})
+	}
+
+	return p
+}
+
+// weightedPicker picks the backends by smooth weighted round-robin, so the heavier backends are not picked in bursts
+type weightedPicker struct {
+	mu    sync.Mutex
+	items []*weightedItem
+}
+
+type weightedItem struct {
+	subConn balancer.SubConn
+	weight  int64
+	current int64
+}
+
+func (p *weightedPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var (
+		total int64
+		best  *weightedItem
+	)
+	for _, item := range p.items {
+		item.current += item.weight
+		total += item.weight
+		if best == nil || item.current > best.current {
+			best = item
+		}
+	}
+	best.current -= total
+
+	return balancer.PickResult{SubConn: best.subConn}, nil
+}
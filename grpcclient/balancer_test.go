@@ -0,0 +1,262 @@
+package grpcclient
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/viebiz/lit/grpcclient/testdata"
+	"github.com/viebiz/lit/monitoring"
+)
+
+func TestWithLoadBalancing(t *testing.T) {
+	tcs := map[string]struct {
+		givenPolicy  LoadBalancingPolicy
+		givenWeights []uint32
+		givenCalls   int
+		expCalls     []int
+	}{
+		"round robin": {
+			givenPolicy:  RoundRobin,
+			givenWeights: []uint32{1, 5, 0},
+			givenCalls:   30,
+			expCalls:     []int{10, 10, 10},
+		},
+		"weighted round robin": {
+			givenPolicy:  WeightedRoundRobin,
+			givenWeights: []uint32{1, 3, 0}, // 0 means 1
+			givenCalls:   50,
+			expCalls:     []int{10, 30, 10},
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			backends := make([]*namedWeatherService, len(tc.givenWeights))
+			endpoints := make([]Endpoint, len(tc.givenWeights))
+			for idx, weight := range tc.givenWeights {
+				backends[idx] = &namedWeatherService{}
+				endpoints[idx] = Endpoint{Addr: startWeatherServer(t, backends[idx]), Weight: weight}
+			}
+
+			conn, err := NewUnauthenticatedConnection(context.Background(), "weather",
+				WithStaticEndpoints(endpoints...),
+				WithLoadBalancing(tc.givenPolicy),
+			)
+			require.NoError(t, err)
+			client := testdata.NewWeatherServiceClient(conn)
+			waitAllBackendsReady(t, client, backends)
+
+			// When
+			for range tc.givenCalls {
+				_, err := client.GetWeatherInfo(context.Background(), &testdata.WeatherRequest{})
+				require.NoError(t, err)
+			}
+
+			// Then
+			calls := make([]int, len(backends))
+			for idx, b := range backends {
+				calls[idx] = b.count()
+			}
+			require.Equal(t, tc.expCalls, calls)
+		})
+	}
+}
+
+func TestWithLoadBalancing_WeightUpdate(t *testing.T) {
+	// Given
+	backends := []*namedWeatherService{{}, {}}
+	addrs := []string{startWeatherServer(t, backends[0]), startWeatherServer(t, backends[1])}
+
+	var mu sync.Mutex
+	weights := []uint32{1, 1}
+	r := ResolverFunc(func(context.Context, string) ([]Endpoint, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return []Endpoint{{Addr: addrs[0], Weight: weights[0]}, {Addr: addrs[1], Weight: weights[1]}}, nil
+	})
+
+	conn, err := NewUnauthenticatedConnection(context.Background(), "weather",
+		WithResolver(r, 20*time.Millisecond),
+		WithLoadBalancing(WeightedRoundRobin),
+	)
+	require.NoError(t, err)
+	client := testdata.NewWeatherServiceClient(conn)
+	waitAllBackendsReady(t, client, backends)
+
+	// When
+	mu.Lock()
+	weights = []uint32{1, 3}
+	mu.Unlock()
+
+	// Then
+	require.Eventually(t, func() bool {
+		before := []int{backends[0].count(), backends[1].count()}
+		for range 40 {
+			if _, err := client.GetWeatherInfo(context.Background(), &testdata.WeatherRequest{}); err != nil {
+				return false
+			}
+		}
+
+		return backends[0].count()-before[0] == 10 && backends[1].count()-before[1] == 30
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestWithHealthCheck(t *testing.T) {
+	// Given
+	healthy, unhealthy := &namedWeatherService{}, &namedWeatherService{}
+	healthyStatus, unhealthyStatus := health.NewServer(), health.NewServer()
+	unhealthyStatus.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	endpoints := []Endpoint{
+		{Addr: startHealthCheckedWeatherServer(t, unhealthy, unhealthyStatus)},
+		{Addr: startHealthCheckedWeatherServer(t, healthy, healthyStatus)},
+	}
+
+	conn, err := NewUnauthenticatedConnection(context.Background(), "weather",
+		WithStaticEndpoints(endpoints...),
+		WithLoadBalancing(RoundRobin),
+		WithHealthCheck(""),
+	)
+	require.NoError(t, err)
+	client := testdata.NewWeatherServiceClient(conn)
+
+	// When
+	for range 10 {
+		_, err := client.GetWeatherInfo(context.Background(), &testdata.WeatherRequest{}, grpc.WaitForReady(true))
+		require.NoError(t, err)
+	}
+
+	// Then
+	require.Equal(t, 10, healthy.count())
+	require.Zero(t, unhealthy.count())
+
+	// When the backend recovers
+	unhealthyStatus.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+
+	// Then
+	require.Eventually(t, func() bool {
+		_, err := client.GetWeatherInfo(context.Background(), &testdata.WeatherRequest{})
+		require.NoError(t, err)
+		return unhealthy.count() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWithStateReporting(t *testing.T) {
+	// Given
+	logBuffer := new(syncBuffer)
+	m, err := monitoring.New(monitoring.Config{ServerName: "lightning", Writer: logBuffer})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(monitoring.SetInContext(context.Background(), m))
+	defer cancel()
+
+	addr := startWeatherServer(t, &namedWeatherService{})
+
+	conn, err := NewUnauthenticatedConnection(ctx, addr, WithStateReporting())
+	require.NoError(t, err)
+
+	// When
+	_, err = testdata.NewWeatherServiceClient(conn).GetWeatherInfo(ctx, &testdata.WeatherRequest{})
+	require.NoError(t, err)
+
+	// Then
+	require.Eventually(t, func() bool {
+		logs := logBuffer.String()
+		return strings.Contains(logs, `"grpc.previous_state":"IDLE"`) && strings.Contains(logs, `"grpc.state":"READY"`)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// namedWeatherService counts the calls it receives
+type namedWeatherService struct {
+	testdata.UnimplementedWeatherServiceServer
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *namedWeatherService) GetWeatherInfo(context.Context, *testdata.WeatherRequest) (*testdata.WeatherResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+
+	return &testdata.WeatherResponse{}, nil
+}
+
+func (s *namedWeatherService) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
+
+func (s *namedWeatherService) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = 0
+}
+
+// waitAllBackendsReady calls until every backend is picked once, so the picker includes all of them, then resets the counters
+func waitAllBackendsReady(t *testing.T, client testdata.WeatherServiceClient, backends []*namedWeatherService) {
+	require.Eventually(t, func() bool {
+		_, err := client.GetWeatherInfo(context.Background(), &testdata.WeatherRequest{}, grpc.WaitForReady(true))
+		require.NoError(t, err)
+		for _, b := range backends {
+			if b.count() == 0 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, time.Millisecond)
+
+	for _, b := range backends {
+		b.reset()
+	}
+}
+
+func startHealthCheckedWeatherServer(t *testing.T, svc testdata.WeatherServiceServer, healthSrv healthpb.HealthServer) string {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	testdata.RegisterWeatherServiceServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, healthSrv)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, the connectivity state is logged in the background
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
@@ -0,0 +1,56 @@
+package grpcclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/viebiz/lit/circuitbreaker"
+)
+
+// circuitBreakerFailureCodes are the status codes counted as failures of the server
+var circuitBreakerFailureCodes = map[codes.Code]bool{
+	codes.Unknown:          true,
+	codes.DeadlineExceeded: true,
+	codes.Internal:         true,
+	codes.Unavailable:      true,
+	codes.DataLoss:         true,
+}
+
+// circuitBreakerUnaryInterceptor rejects the unary calls while the circuit breaker is open.
+// It runs before hedging, so the hedged attempts of a call are counted once
+func circuitBreakerUnaryInterceptor(cb *circuitbreaker.CircuitBreaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		done, err := cb.Allow(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		done(isFailedCall(err))
+
+		return err
+	}
+}
+
+// circuitBreakerStreamInterceptor rejects the new streams while the circuit breaker is open,
+// only the stream creation is counted as the messages are exchanged after it
+func circuitBreakerStreamInterceptor(cb *circuitbreaker.CircuitBreaker) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		done, err := cb.Allow(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		done(isFailedCall(err))
+
+		return cs, err
+	}
+}
+
+func isFailedCall(err error) bool {
+	return circuitBreakerFailureCodes[status.Code(err)]
+}
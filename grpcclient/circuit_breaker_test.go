@@ -0,0 +1,94 @@
+package grpcclient
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/viebiz/lit"
+	"github.com/viebiz/lit/circuitbreaker"
+	"github.com/viebiz/lit/grpcclient/testdata"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	tcs := map[string]struct {
+		givenFailures int32
+		expCalls      int32
+		expOpen       bool
+	}{
+		"opens on failures": {
+			givenFailures: 3,
+			expCalls:      2,
+			expOpen:       true,
+		},
+		"closed on success": {
+			givenFailures: 0,
+			expCalls:      3,
+		},
+	}
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			weatherSvc := &flakyWeatherService{failures: tc.givenFailures}
+			addr := startWeatherServer(t, weatherSvc)
+
+			cb, err := circuitbreaker.New("weather", circuitbreaker.WithMinimumCalls(2))
+			require.NoError(t, err)
+
+			conn, err := NewUnauthenticatedConnection(context.Background(), addr, WithCircuitBreaker(cb))
+			require.NoError(t, err)
+			client := testdata.NewWeatherServiceClient(conn)
+
+			// When
+			var lastErr error
+			for range 3 {
+				_, lastErr = client.GetWeatherInfo(context.Background(), &testdata.WeatherRequest{})
+			}
+
+			// Then
+			require.Equal(t, tc.expCalls, atomic.LoadInt32(&weatherSvc.calls))
+			if !tc.expOpen {
+				require.NoError(t, lastErr)
+				require.Equal(t, circuitbreaker.StateClosed, cb.State())
+				return
+			}
+
+			var openErr circuitbreaker.OpenError
+			require.ErrorAs(t, lastErr, &openErr)
+			require.Equal(t, "weather", openErr.Name)
+			require.Equal(t, codes.Unavailable, lit.ToGRPCStatus(context.Background(), lastErr).Code())
+		})
+	}
+}
+
+func TestIsFailedCall(t *testing.T) {
+	tcs := map[string]struct {
+		givenErr error
+		expected bool
+	}{
+		"success":           {},
+		"unavailable":       {givenErr: status.Error(codes.Unavailable, "down"), expected: true},
+		"deadline exceeded": {givenErr: status.Error(codes.DeadlineExceeded, "slow"), expected: true},
+		"not found":         {givenErr: status.Error(codes.NotFound, "missing")},
+		"canceled":          {givenErr: status.Error(codes.Canceled, "gone")},
+	}
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// When
+			result := isFailedCall(tc.givenErr)
+
+			// Then
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
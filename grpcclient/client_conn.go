@@ -5,23 +5,38 @@ import (
 
 	pkgerrors "github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/viebiz/lit/monitoring"
 )
 
-func initUnaryClient(ctx context.Context, addr string, opts ...grpc.DialOption) (Conn, error) {
+func initUnaryClient(ctx context.Context, addr string, transportCreds credentials.TransportCredentials, opts ...ConnOption) (Conn, error) {
 	svcInfo := monitoring.NewExternalServiceInfo(addr)
 
-	conn, err := grpc.NewClient(addr,
+	var cfg connConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	dialOpts, err := cfg.dialOptions(transportCreds)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(cfg.target(addr),
 		append(
-			commonUnaryClientDialOptions(svcInfo),
-			opts...,
+			commonClientDialOptions(svcInfo, cfg.interceptor),
+			dialOpts...,
 		)...,
 	)
 	if err != nil {
 		return nil, pkgerrors.WithStack(err)
 	}
 
+	if cfg.reportState {
+		go reportConnectivityState(ctx, conn, conn.GetState())
+	}
+
 	return &clientConn{
 		conn: conn,
 	}, nil
@@ -39,17 +54,69 @@ func (u clientConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method
 	return u.conn.NewStream(ctx, desc, method, opts...)
 }
 
-func commonUnaryClientDialOptions(svcInfo monitoring.ExternalServiceInfo) []grpc.DialOption {
+func (u clientConn) Close() error {
+	return u.conn.Close()
+}
+
+func commonClientDialOptions(svcInfo monitoring.ExternalServiceInfo, interceptor clientInterceptor) []grpc.DialOption {
 	return []grpc.DialOption{
-		// Explicitly disabling this as according to doc: Retry support is currently disabled by default, but will be enabled by default in the future.
-		grpc.WithDisableRetry(),
 		grpc.WithDefaultCallOptions(
 			externalServiceInfoOption{info: svcInfo}, // Pass service information for tracing.
 		),
-		grpc.WithChainUnaryInterceptor(unaryClientInterceptor),
+		grpc.WithChainUnaryInterceptor(interceptor.unary),
+		grpc.WithChainStreamInterceptor(interceptor.stream),
 	}
 }
 
+func (cfg connConfig) dialOptions(transportCreds credentials.TransportCredentials) ([]grpc.DialOption, error) {
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(transportCreds)}
+
+	if cfg.perRPCCreds != nil {
+		creds := *cfg.perRPCCreds
+		creds.requireTLS = !cfg.allowInsecurePerRPC
+		opts = append(opts, grpc.WithPerRPCCredentials(creds))
+	}
+
+	if cfg.circuitBreaker != nil {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(circuitBreakerUnaryInterceptor(cfg.circuitBreaker)),
+			grpc.WithChainStreamInterceptor(circuitBreakerStreamInterceptor(cfg.circuitBreaker)),
+		)
+	}
+
+	if len(cfg.methodPolicies) == 0 {
+		// Explicitly disabling this as according to doc: Retry support is currently disabled by default, but will be enabled by default in the future.
+		opts = append(opts, grpc.WithDisableRetry())
+	} else {
+		// Hedging runs after the logging interceptor, so each call is logged once
+		opts = append(opts, grpc.WithChainUnaryInterceptor(hedgingClientInterceptor(newMethodPolicies(cfg.methodPolicies))))
+	}
+
+	if cfg.hasServiceConfig() {
+		sc, err := buildServiceConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, grpc.WithDefaultServiceConfig(sc))
+	}
+
+	if cfg.resolver != nil {
+		opts = append(opts, grpc.WithResolvers(resolverBuilder{resolver: cfg.resolver, interval: cfg.resolveInterval}))
+	}
+
+	return append(opts, cfg.dialOpts...), nil
+}
+
+// target returns the dial target of the address, the address is the service name resolved by the Resolver when it's set
+func (cfg connConfig) target(addr string) string {
+	if cfg.resolver != nil {
+		return resolverScheme + ":///" + addr
+	}
+
+	return addr
+}
+
 // externalServiceInfoOption to keeps the external service info in UnaryClient for purpose monitor
 type externalServiceInfoOption struct {
 	grpc.EmptyCallOption
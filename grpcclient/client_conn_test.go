@@ -0,0 +1,436 @@
+package grpcclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/viebiz/lit/grpcclient/testdata"
+	"github.com/viebiz/lit/iam"
+)
+
+func TestNewUnauthenticatedConnection_PerRPCCredentials(t *testing.T) {
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		require.Equal(t, "weather-service", r.Form.Get("audience"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"m2m-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(tokenSrv.Close)
+
+	tcs := map[string]struct {
+		givenCtx   context.Context
+		givenOpts  []ConnOption
+		expAuth    []string
+		expDialErr bool
+	}{
+		"oauth": {
+			givenCtx: context.Background(),
+			givenOpts: []ConnOption{
+				WithOAuth(OAuthConfig{TokenURL: tokenSrv.URL, ClientID: "space_marine", ClientSecret: "secret", ReceiverAudience: "weather-service"}),
+				WithInsecurePerRPCCredentials(),
+			},
+			expAuth: []string{"Bearer m2m-token"},
+		},
+		"forwarded token - from guard": {
+			givenCtx:  iam.SetAccessTokenInContext(context.Background(), "user-token"),
+			givenOpts: []ConnOption{WithForwardedToken(), WithInsecurePerRPCCredentials()},
+			expAuth:   []string{"Bearer user-token"},
+		},
+		"forwarded token - from incoming metadata": {
+			givenCtx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer caller-token")),
+			givenOpts: []ConnOption{WithForwardedToken(), WithInsecurePerRPCCredentials()},
+			expAuth:   []string{"Bearer caller-token"},
+		},
+		"forwarded token - unauthenticated caller": {
+			givenCtx:  context.Background(),
+			givenOpts: []ConnOption{WithForwardedToken(), WithInsecurePerRPCCredentials()},
+		},
+		"error - token over insecure connection": {
+			givenCtx:   iam.SetAccessTokenInContext(context.Background(), "user-token"),
+			givenOpts:  []ConnOption{WithForwardedToken()},
+			expDialErr: true,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			var gotAuth []string
+			weatherSvc := new(weatherService)
+			weatherSvc.On("GetWeatherInfo", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					gotAuth = metadata.ValueFromIncomingContext(args.Get(0).(context.Context), "authorization")
+				}).
+				Return(&testdata.WeatherResponse{}, nil)
+			addr := startWeatherServer(t, weatherSvc)
+
+			// When
+			conn, err := NewUnauthenticatedConnection(context.Background(), addr, tc.givenOpts...)
+			if tc.expDialErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = testdata.NewWeatherServiceClient(conn).GetWeatherInfo(tc.givenCtx, &testdata.WeatherRequest{Date: "M41.993.32"})
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, tc.expAuth, gotAuth)
+		})
+	}
+}
+
+func TestNewMTLSConnection(t *testing.T) {
+	dir := t.TempDir()
+	caCert, caKey := writeCert(t, dir, "ca", nil, nil)
+	writeCert(t, dir, "server", caCert, caKey)
+	writeCert(t, dir, "client", caCert, caKey)
+	serverCert, err := tls.LoadX509KeyPair(filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"))
+	require.NoError(t, err)
+
+	caPool := x509.NewCertPool()
+	caPool.AddCert(caCert)
+	serverTLS := &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	tcs := map[string]struct {
+		givenCfg     MTLSConfig
+		expDialErr   bool
+		expCallError bool
+	}{
+		"success": {
+			givenCfg: MTLSConfig{
+				CertFile:   filepath.Join(dir, "client.crt"),
+				KeyFile:    filepath.Join(dir, "client.key"),
+				CAFile:     filepath.Join(dir, "ca.crt"),
+				ServerName: "localhost",
+			},
+		},
+		"error - server not trusted": {
+			givenCfg: MTLSConfig{
+				CertFile:   filepath.Join(dir, "client.crt"),
+				KeyFile:    filepath.Join(dir, "client.key"),
+				CAFile:     filepath.Join(dir, "client.crt"),
+				ServerName: "localhost",
+			},
+			expCallError: true,
+		},
+		"error - missing client certificate": {
+			givenCfg: MTLSConfig{
+				CertFile: filepath.Join(dir, "missing.crt"),
+				KeyFile:  filepath.Join(dir, "client.key"),
+			},
+			expDialErr: true,
+		},
+		"error - invalid CA file": {
+			givenCfg: MTLSConfig{
+				CertFile: filepath.Join(dir, "client.crt"),
+				KeyFile:  filepath.Join(dir, "client.key"),
+				CAFile:   filepath.Join(dir, "client.key"),
+			},
+			expDialErr: true,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			weatherSvc := new(weatherService)
+			weatherSvc.On("GetWeatherInfo", mock.Anything, mock.Anything).Return(&testdata.WeatherResponse{}, nil).Maybe()
+			addr := startWeatherServer(t, weatherSvc, grpc.Creds(credentials.NewTLS(serverTLS)))
+
+			// When
+			conn, err := NewMTLSConnection(context.Background(), addr, tc.givenCfg)
+			if tc.expDialErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = testdata.NewWeatherServiceClient(conn).GetWeatherInfo(context.Background(), &testdata.WeatherRequest{})
+
+			// Then
+			if tc.expCallError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWithMethodPolicies_Retry(t *testing.T) {
+	tcs := map[string]struct {
+		givenPolicies []MethodPolicy
+		expCalls      int32
+		expErr        bool
+	}{
+		"retry disabled by default": {
+			expCalls: 1,
+			expErr:   true,
+		},
+		"retry the method": {
+			givenPolicies: []MethodPolicy{{
+				Service: "weather.WeatherService",
+				Method:  "GetWeatherInfo",
+				Retry: &RetryPolicy{
+					MaxAttempts:          3,
+					InitialBackoff:       10 * time.Millisecond,
+					MaxBackoff:           50 * time.Millisecond,
+					BackoffMultiplier:    2,
+					RetryableStatusCodes: []codes.Code{codes.Unavailable},
+				},
+			}},
+			expCalls: 3,
+		},
+		"retry exhausted": {
+			givenPolicies: []MethodPolicy{{
+				Service: "weather.WeatherService",
+				Retry: &RetryPolicy{
+					MaxAttempts:          2,
+					InitialBackoff:       10 * time.Millisecond,
+					MaxBackoff:           50 * time.Millisecond,
+					BackoffMultiplier:    2,
+					RetryableStatusCodes: []codes.Code{codes.Unavailable},
+				},
+			}},
+			expCalls: 2,
+			expErr:   true,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			weatherSvc := &flakyWeatherService{failures: 2}
+			addr := startWeatherServer(t, weatherSvc)
+
+			conn, err := NewUnauthenticatedConnection(context.Background(), addr, WithMethodPolicies(tc.givenPolicies...))
+			require.NoError(t, err)
+
+			// When
+			_, err = testdata.NewWeatherServiceClient(conn).GetWeatherInfo(context.Background(), &testdata.WeatherRequest{})
+
+			// Then
+			require.Equal(t, tc.expCalls, atomic.LoadInt32(&weatherSvc.calls))
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBuildServiceConfig(t *testing.T) {
+	tcs := map[string]struct {
+		givenPolicies    []MethodPolicy
+		givenThrottling  *RetryThrottling
+		givenLBPolicy    LoadBalancingPolicy
+		givenHealthCheck *string
+		expJSON          string
+		expErr           error
+	}{
+		"retry, timeout and throttling": {
+			givenPolicies: []MethodPolicy{
+				{
+					Service: "weather.WeatherService",
+					Method:  "GetWeatherInfo",
+					Timeout: 1500 * time.Millisecond,
+					Retry: &RetryPolicy{
+						MaxAttempts:          4,
+						InitialBackoff:       100 * time.Millisecond,
+						MaxBackoff:           time.Second,
+						BackoffMultiplier:    2,
+						RetryableStatusCodes: []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+					},
+				},
+				{Timeout: 5 * time.Second},
+			},
+			givenThrottling: &RetryThrottling{MaxTokens: 10, TokenRatio: 0.1},
+			expJSON: `{"methodConfig":[` +
+				`{"name":[{"service":"weather.WeatherService","method":"GetWeatherInfo"}],"timeout":"1.5s","retryPolicy":{"maxAttempts":4,"initialBackoff":"0.1s","maxBackoff":"1s","backoffMultiplier":2,"retryableStatusCodes":[14,8]}},` +
+				`{"name":[{}],"timeout":"5s"}` +
+				`],"retryThrottling":{"maxTokens":10,"tokenRatio":0.1}}`,
+		},
+		"load balancing and health check": {
+			givenLBPolicy:    WeightedRoundRobin,
+			givenHealthCheck: pointerTo("weather.WeatherService"),
+			expJSON:          `{"loadBalancingConfig":[{"lit_weighted_round_robin":{}}],"healthCheckConfig":{"serviceName":"weather.WeatherService"}}`,
+		},
+		"hedging is left to the interceptor": {
+			givenPolicies: []MethodPolicy{{Service: "weather.WeatherService", Hedging: &HedgingPolicy{MaxAttempts: 2}}},
+			expJSON:       `{"methodConfig":[{"name":[{"service":"weather.WeatherService"}]}]}`,
+		},
+		"error - method without service": {
+			givenPolicies: []MethodPolicy{{Method: "GetWeatherInfo"}},
+			expErr:        ErrInvalidMethodPolicy,
+		},
+		"error - retry and hedging": {
+			givenPolicies: []MethodPolicy{{
+				Service: "weather.WeatherService",
+				Retry:   &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Second, MaxBackoff: time.Second, BackoffMultiplier: 1, RetryableStatusCodes: []codes.Code{codes.Unavailable}},
+				Hedging: &HedgingPolicy{MaxAttempts: 2},
+			}},
+			expErr: ErrInvalidMethodPolicy,
+		},
+		"error - retry without retryable codes": {
+			givenPolicies: []MethodPolicy{{
+				Service: "weather.WeatherService",
+				Retry:   &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Second, MaxBackoff: time.Second, BackoffMultiplier: 1},
+			}},
+			expErr: ErrInvalidMethodPolicy,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// When
+			rs, err := buildServiceConfig(connConfig{
+				methodPolicies:  tc.givenPolicies,
+				retryThrottling: tc.givenThrottling,
+				lbPolicy:        tc.givenLBPolicy,
+				healthCheck:     tc.givenHealthCheck,
+			})
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expJSON, rs)
+		})
+	}
+}
+
+func TestMethodPolicies_lookup(t *testing.T) {
+	policies := newMethodPolicies([]MethodPolicy{
+		{Service: "weather.WeatherService", Method: "GetWeatherInfo", Timeout: time.Second},
+		{Service: "weather.WeatherService", Timeout: 2 * time.Second},
+		{Timeout: 3 * time.Second},
+	})
+
+	p, ok := policies.lookup("/weather.WeatherService/GetWeatherInfo")
+	require.True(t, ok)
+	require.Equal(t, time.Second, p.Timeout)
+
+	p, ok = policies.lookup("/weather.WeatherService/StreamWeather")
+	require.True(t, ok)
+	require.Equal(t, 2*time.Second, p.Timeout)
+
+	p, ok = policies.lookup("/order.OrderService/CreateOrder")
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, p.Timeout)
+
+	_, ok = newMethodPolicies(nil).lookup("/order.OrderService/CreateOrder")
+	require.False(t, ok)
+}
+
+// flakyWeatherService fails the first calls with Unavailable
+type flakyWeatherService struct {
+	testdata.UnimplementedWeatherServiceServer
+	failures int32
+	calls    int32
+}
+
+func (s *flakyWeatherService) GetWeatherInfo(context.Context, *testdata.WeatherRequest) (*testdata.WeatherResponse, error) {
+	if atomic.AddInt32(&s.calls, 1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "warp storm")
+	}
+
+	return &testdata.WeatherResponse{}, nil
+}
+
+func startWeatherServer(t *testing.T, svc testdata.WeatherServiceServer, opts ...grpc.ServerOption) string {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(opts...)
+	testdata.RegisterWeatherServiceServer(srv, svc)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+// writeCert writes the certificate and key signed by the parent to dir, the certificate is self-signed when parent is nil
+func writeCert(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return cert, key
+}
+
+func pointerTo[T any](v T) *T {
+	return &v
+}
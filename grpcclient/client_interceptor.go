@@ -2,16 +2,28 @@ package grpcclient
 
 import (
 	"context"
+	"errors"
+	"io"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/viebiz/lit"
 	"github.com/viebiz/lit/monitoring"
 	"github.com/viebiz/lit/monitoring/instrumentgrpc"
 )
 
-func unaryClientInterceptor(
+// clientInterceptor instruments and logs the outgoing calls
+type clientInterceptor struct {
+	disableRespBodyLogging bool
+	// propagatedFields are the monitoring fields sent to the server via metadata
+	propagatedFields []string
+}
+
+func (ci clientInterceptor) unary(
 	ctx context.Context,
 	method string,
 	req, reply any,
@@ -19,32 +31,164 @@ func unaryClientInterceptor(
 	invoker grpc.UnaryInvoker,
 	opts ...grpc.CallOption,
 ) (err error) {
-	var extSvcInfo externalServiceInfoOption
-	for _, opt := range opts {
-		if v, ok := opt.(externalServiceInfoOption); ok {
-			extSvcInfo = v
-			continue
-		}
-	}
+	extSvcInfo := externalServiceInfoFromCallOptions(opts)
 
+	ctx = ci.propagateFields(ctx)
 	ctx, end := instrumentgrpc.StartUnaryCallSegment(ctx, extSvcInfo.info, method)
 	defer func() {
 		end(err)
 	}()
 
-	logRequestBody(ctx, req)
+	err = invoker(ctx, method, req, reply, clientConn, opts...)
+	ci.logUnaryCall(ctx, req, reply, err)
+	if err != nil {
+		// Convert the status back to lit.Error, so it can be returned to the HTTP client as is
+		return lit.FromGRPCError(err)
+	}
+
+	return nil
+}
+
+func (ci clientInterceptor) stream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	clientConn *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	extSvcInfo := externalServiceInfoFromCallOptions(opts)
+
+	ctx = ci.propagateFields(ctx)
+	ctx, segment := instrumentgrpc.StartStreamCallSegment(ctx, extSvcInfo.info, method)
+
+	cs, err := streamer(ctx, desc, clientConn, method, opts...)
+	if err != nil {
+		segment.End(err)
+		logStreamCall(ctx, segment, err)
+		return nil, lit.FromGRPCError(err)
+	}
+
+	s := &instrumentedClientStream{
+		ClientStream:           cs,
+		ctx:                    ctx,
+		serverStreams:          desc.ServerStreams,
+		segment:                segment,
+		disableRespBodyLogging: ci.disableRespBodyLogging,
+		done:                   make(chan struct{}),
+	}
+
+	// End the stream when the caller gives up without reading until the end
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.finish(status.FromContextError(ctx.Err()).Err())
+		case <-s.done:
+		}
+	}()
+
+	return s, nil
+}
+
+// propagateFields copies the selected monitoring fields to the outgoing metadata
+func (ci clientInterceptor) propagateFields(ctx context.Context) context.Context {
+	if len(ci.propagatedFields) == 0 {
+		return ctx
+	}
+
+	m := monitoring.FromContext(ctx)
+	kv := make([]string, 0, len(ci.propagatedFields)*2)
+	for _, key := range ci.propagatedFields {
+		if v, ok := m.Tag(key); ok {
+			kv = append(kv, key, v)
+		}
+	}
+
+	if len(kv) == 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+func (ci clientInterceptor) logUnaryCall(ctx context.Context, req, reply any, err error) {
+	fields := []monitoring.Field{
+		monitoring.StringField("grpc.request", serializeProtoMessage(req)),
+		monitoring.StringField("grpc.status_code", status.Code(err).String()),
+	}
+	if err == nil && !ci.disableRespBodyLogging {
+		fields = append(fields, monitoring.StringField("grpc.response", serializeProtoMessage(reply)))
+	}
+
+	monitoring.FromContext(ctx).Info("grpc.outgoing_request", fields...)
+}
+
+// instrumentedClientStream records the messages of the stream and ends the segment when the stream finishes
+type instrumentedClientStream struct {
+	grpc.ClientStream
+	ctx                    context.Context
+	serverStreams          bool
+	segment                *instrumentgrpc.StreamCallSegment
+	disableRespBodyLogging bool
+	done                   chan struct{}
+}
+
+func (s *instrumentedClientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.segment.MessageSent()
+	}
 
-	if err = invoker(ctx, method, req, reply, clientConn, opts...); err != nil {
+	return err // io.EOF means the stream is aborted, the status is returned by RecvMsg
+}
+
+func (s *instrumentedClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		s.segment.MessageReceived()
+		if !s.disableRespBodyLogging {
+			monitoring.FromContext(s.ctx).Info("grpc.outgoing_stream_message",
+				monitoring.StringField("grpc.response", serializeProtoMessage(m)))
+		}
+		if !s.serverStreams {
+			s.finish(nil) // Client streaming receives a single response
+		}
+		return nil
+	case errors.Is(err, io.EOF):
+		s.finish(nil)
 		return err
+	default:
+		s.finish(err)
+		return lit.FromGRPCError(err)
 	}
+}
 
-	return nil
+func (s *instrumentedClientStream) finish(err error) {
+	if !s.segment.End(err) {
+		return // Already finished
+	}
+
+	close(s.done)
+	logStreamCall(s.ctx, s.segment, err)
 }
 
-func logRequestBody(ctx context.Context, req interface{}) {
-	monitoring.FromContext(ctx).
-		WithTag("grpc.request", serializeProtoMessage(req)).
-		Infof("grpc.outgoing_request")
+func logStreamCall(ctx context.Context, segment *instrumentgrpc.StreamCallSegment, err error) {
+	monitoring.FromContext(ctx).Info("grpc.outgoing_stream",
+		monitoring.StringField("grpc.status_code", status.Code(err).String()),
+		monitoring.IntField("grpc.messages_sent", int(segment.Sent())),
+		monitoring.IntField("grpc.messages_received", int(segment.Received())),
+	)
+}
+
+func externalServiceInfoFromCallOptions(opts []grpc.CallOption) externalServiceInfoOption {
+	for _, opt := range opts {
+		if v, ok := opt.(externalServiceInfoOption); ok {
+			return v
+		}
+	}
+
+	return externalServiceInfoOption{}
 }
 
 func serializeProtoMessage(req any) string {
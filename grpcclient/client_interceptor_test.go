@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -12,6 +15,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/viebiz/lit/testutil"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/viebiz/lit"
 
 	"github.com/viebiz/lit/grpcclient/testdata"
 	"github.com/viebiz/lit/monitoring"
@@ -74,7 +83,7 @@ func TestClientConn_Invoke(t *testing.T) {
 				},
 			},
 			expLog: []map[string]string{
-				{"level": "INFO", "ts": "2025-02-23T18:18:48.186+0700", "msg": "grpc.outgoing_request", "grpc.request": `{"date":"M41.993.32"}`, "outgoing_span_id": "0000000000000000", "outgoing_trace_id": "00000000000000000000000000000000", "rpc.method": "GetWeatherInfo", "rpc.service": "weather.WeatherService", "rpc.system": "grpc", "server.address": "localhost:50052", "server.name": "lightning", "environment": "dev", "version": "1.0.0"},
+				{"level": "INFO", "ts": "2025-02-23T18:18:48.186+0700", "msg": "grpc.outgoing_request", "grpc.request": `{"date":"M41.993.32"}`, "grpc.status_code": "OK", "outgoing_span_id": "0000000000000000", "outgoing_trace_id": "00000000000000000000000000000000", "rpc.method": "GetWeatherInfo", "rpc.service": "weather.WeatherService", "rpc.system": "grpc", "server.address": "localhost:50052", "server.name": "lightning", "environment": "dev", "version": "1.0.0"},
 			},
 		},
 	}
@@ -117,6 +126,11 @@ func TestClientConn_Invoke(t *testing.T) {
 
 			pasedLogs, err := parseLog(logBuffer.Bytes(), 2)
 			require.NoError(t, err)
+			if tc.expResp != nil {
+				expRespLog, err := protojson.Marshal(tc.expResp)
+				require.NoError(t, err)
+				require.JSONEq(t, string(expRespLog), pasedLogs[0]["grpc.response"])
+			}
 			testutil.Equal(t, tc.expLog, pasedLogs, testutil.IgnoreSliceMapEntries(func(k string, v string) bool {
 				if k == "ts" {
 					return true
@@ -126,6 +140,10 @@ func TestClientConn_Invoke(t *testing.T) {
 					return true
 				}
 
+				if k == "grpc.response" {
+					return true // protojson output is unstable, it's compared below
+				}
+
 				if v == "Caught a panic" {
 					return true
 				}
@@ -164,3 +182,160 @@ func (s *weatherService) GetWeatherInfo(ctx context.Context, req *testdata.Weath
 
 	return args.Get(0).(*testdata.WeatherResponse), args.Error(1)
 }
+
+func TestUnaryClientInterceptor_ConvertError(t *testing.T) {
+	tcs := map[string]struct {
+		givenErr error
+		expErr   error
+	}{
+		"http error": {
+			givenErr: lit.ToGRPCStatus(context.Background(), lit.HTTPError{Status: http.StatusConflict, Code: "order_locked", Desc: "Order is locked"}).Err(),
+			expErr:   lit.HTTPError{Status: http.StatusConflict, Code: "order_locked", Desc: "Order is locked"},
+		},
+		"validation error": {
+			givenErr: lit.ToGRPCStatus(context.Background(), lit.ValidationError{"date": "The date field is required"}).Err(),
+			expErr:   lit.ValidationError{"date": "The date field is required"},
+		},
+		"status without details": {
+			givenErr: status.Error(codes.NotFound, "weather not found"),
+			expErr:   lit.HTTPError{Status: http.StatusNotFound, Code: "not_found", Desc: "weather not found"},
+		},
+		"code without matching http status": {
+			givenErr: status.Error(codes.AlreadyExists, "weather already exists"),
+			expErr:   lit.HTTPError{Status: http.StatusConflict, Code: "already_exists", Desc: "weather already exists"},
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return tc.givenErr
+			}
+
+			// When
+			err := clientInterceptor{}.unary(context.Background(), testdata.WeatherService_GetWeatherInfo_FullMethodName, &testdata.WeatherRequest{}, nil, nil, invoker)
+
+			// Then
+			require.Equal(t, tc.expErr, errors.Unwrap(err))
+			require.Equal(t, status.Code(tc.givenErr), status.Code(err))
+		})
+	}
+}
+
+func TestClientConn_NewStream(t *testing.T) {
+	details := []*testdata.WeatherDetail{
+		{Location: "Hive City, Necromunda", Date: "M41.993.32"},
+		{Location: "Macragge's Northern Hemisphere", Date: "M41.874.21"},
+	}
+
+	tcs := map[string]struct {
+		givenOpts   []ConnOption
+		givenErr    error
+		expMsgLogs  int
+		expStatus   string
+		expReceived float64
+		expErr      error
+	}{
+		"success": {
+			givenOpts:   []ConnOption{WithPropagatedFields("user_id", "tenant_id")},
+			expMsgLogs:  2,
+			expStatus:   "OK",
+			expReceived: 2,
+		},
+		"success - response logging disabled": {
+			givenOpts:   []ConnOption{WithPropagatedFields("user_id"), DisableResponseBodyLogging()},
+			expStatus:   "OK",
+			expReceived: 2,
+		},
+		"error": {
+			givenOpts:   []ConnOption{WithPropagatedFields("user_id")},
+			givenErr:    status.Error(codes.NotFound, "weather station not found"),
+			expMsgLogs:  2,
+			expStatus:   "NotFound",
+			expReceived: 2,
+			expErr:      lit.HTTPError{Status: http.StatusNotFound, Code: "not_found", Desc: "weather station not found"},
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			logBuffer := new(bytes.Buffer)
+			m, _ := monitoring.New(monitoring.Config{ServerName: "lightning", Writer: logBuffer})
+			reqCtx := monitoring.SetInContext(context.Background(), m.WithTag("user_id", "guilliman"))
+
+			svc := &streamWeatherService{details: details, err: tc.givenErr}
+			addr := startWeatherServer(t, svc)
+
+			conn, err := NewUnauthenticatedConnection(context.Background(), addr, tc.givenOpts...)
+			require.NoError(t, err)
+
+			// When
+			stream, err := testdata.NewWeatherServiceClient(conn).StreamWeather(reqCtx, &testdata.WeatherRequest{Date: "M41.993.32"})
+			require.NoError(t, err)
+
+			var received []*testdata.WeatherDetail
+			for {
+				detail, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					require.Equal(t, tc.expErr, errors.Unwrap(err))
+					break
+				}
+				received = append(received, detail)
+			}
+
+			// Then
+			require.Len(t, received, len(details))
+			require.Equal(t, []string{"guilliman"}, svc.md.Get("user_id"))
+			require.Empty(t, svc.md.Get("tenant_id"))
+
+			var msgLogs int
+			var endLog map[string]any
+			for _, line := range strings.Split(strings.TrimSpace(logBuffer.String()), "\n")[2:] { // Skip the initializing logs
+				var log map[string]any
+				require.NoError(t, json.Unmarshal([]byte(line), &log))
+				switch log["msg"] {
+				case "grpc.outgoing_stream_message":
+					msgLogs++
+					require.NotEmpty(t, log["grpc.response"])
+				case "grpc.outgoing_stream":
+					endLog = log
+				}
+			}
+			require.Equal(t, tc.expMsgLogs, msgLogs)
+			require.Equal(t, tc.expStatus, endLog["grpc.status_code"])
+			require.Equal(t, tc.expReceived, endLog["grpc.messages_received"])
+			require.Equal(t, float64(1), endLog["grpc.messages_sent"])
+			require.Equal(t, "StreamWeather", endLog["rpc.method"])
+		})
+	}
+}
+
+// streamWeatherService streams the details then returns err
+type streamWeatherService struct {
+	testdata.UnimplementedWeatherServiceServer
+	details []*testdata.WeatherDetail
+	err     error
+	md      metadata.MD
+}
+
+func (s *streamWeatherService) StreamWeather(_ *testdata.WeatherRequest, stream grpc.ServerStreamingServer[testdata.WeatherDetail]) error {
+	s.md, _ = metadata.FromIncomingContext(stream.Context())
+	for _, detail := range s.details {
+		if err := stream.Send(detail); err != nil {
+			return err
+		}
+	}
+
+	return s.err
+}
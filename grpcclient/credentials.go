@@ -0,0 +1,91 @@
+package grpcclient
+
+import (
+	"context"
+	neturl "net/url"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	"golang.org/x/oauth2/clientcredentials"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/viebiz/lit/iam"
+)
+
+const (
+	metadataAuthorization     = "authorization"
+	authorizationBearerPrefix = "Bearer"
+)
+
+// OAuthConfig is the config for OAuth client credentials
+type OAuthConfig struct {
+	TokenURL         string
+	ClientID         string
+	ClientSecret     string
+	ReceiverAudience string
+}
+
+// tokenCredentials implements credentials.PerRPCCredentials by attaching the authorization metadata returned by getAuthorization
+type tokenCredentials struct {
+	getAuthorization func(ctx context.Context) (string, error)
+	requireTLS       bool
+}
+
+func newOAuthCredentials(oAuthCfg OAuthConfig) *tokenCredentials {
+	oauthCfg := clientcredentials.Config{
+		ClientID:       oAuthCfg.ClientID,
+		ClientSecret:   oAuthCfg.ClientSecret,
+		TokenURL:       oAuthCfg.TokenURL,
+		EndpointParams: neturl.Values{"audience": []string{oAuthCfg.ReceiverAudience}},
+	}
+	src := oauthCfg.TokenSource(context.Background()) // Token is reused until expired
+
+	return &tokenCredentials{
+		getAuthorization: func(ctx context.Context) (string, error) {
+			tk, err := src.Token()
+			if err != nil {
+				return "", pkgerrors.WithStack(err)
+			}
+
+			return tk.Type() + " " + tk.AccessToken, nil
+		},
+		requireTLS: true,
+	}
+}
+
+func newForwardedCredentials() *tokenCredentials {
+	return &tokenCredentials{
+		getAuthorization: func(ctx context.Context) (string, error) {
+			if tk := iam.GetAccessTokenFromContext(ctx); tk != "" {
+				return authorizationBearerPrefix + " " + tk, nil
+			}
+
+			// Fallback to the incoming metadata when the caller is not authenticated by guard
+			for _, v := range metadata.ValueFromIncomingContext(ctx, metadataAuthorization) {
+				if strings.HasPrefix(v, authorizationBearerPrefix+" ") {
+					return v, nil
+				}
+			}
+
+			return "", nil
+		},
+		requireTLS: true,
+	}
+}
+
+func (c tokenCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	authorization, err := c.getAuthorization(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if authorization == "" {
+		return nil, nil
+	}
+
+	return map[string]string{metadataAuthorization: authorization}, nil
+}
+
+func (c tokenCredentials) RequireTransportSecurity() bool {
+	return c.requireTLS
+}
@@ -0,0 +1,91 @@
+package grpcclient
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// hedgingClientInterceptor sends the parallel attempts of the unary calls matching the hedging policies
+// and returns the first successful response, the remaining attempts are cancelled
+func hedgingClientInterceptor(policies methodPolicies) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		policy, ok := policies.lookup(method)
+		if !ok || policy.Hedging == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		replyMsg, ok := reply.(proto.Message)
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...) // Cannot create a reply for each attempt
+		}
+
+		return invokeHedged(ctx, *policy.Hedging, method, req, replyMsg, cc, invoker, opts...)
+	}
+}
+
+type hedgedAttempt struct {
+	reply proto.Message
+	err   error
+}
+
+func invokeHedged(
+	ctx context.Context,
+	policy HedgingPolicy,
+	method string,
+	req any,
+	reply proto.Message,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // Cancel the attempts still in flight
+
+	results := make(chan hedgedAttempt, policy.MaxAttempts)
+	send := func() {
+		attemptReply := reply.ProtoReflect().New().Interface()
+		go func() {
+			results <- hedgedAttempt{reply: attemptReply, err: invoker(ctx, method, req, attemptReply, cc, opts...)}
+		}()
+	}
+
+	send()
+	sent, done := 1, 0
+
+	timer := time.NewTimer(policy.HedgingDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if sent < policy.MaxAttempts {
+				send()
+				sent++
+				timer.Reset(policy.HedgingDelay)
+			}
+		case rs := <-results:
+			done++
+			if rs.err == nil {
+				proto.Reset(reply)
+				proto.Merge(reply, rs.reply)
+				return nil
+			}
+
+			if !slices.Contains(policy.NonFatalStatusCodes, status.Code(rs.err)) || done == policy.MaxAttempts {
+				return rs.err
+			}
+
+			if done == sent {
+				// All attempts failed with non-fatal codes, send the next one without waiting for the delay
+				send()
+				sent++
+				timer.Reset(policy.HedgingDelay)
+			}
+		}
+	}
+}
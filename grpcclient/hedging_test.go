@@ -0,0 +1,123 @@
+package grpcclient
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/viebiz/lit/grpcclient/testdata"
+)
+
+func TestHedgingClientInterceptor(t *testing.T) {
+	type attempt struct {
+		delay time.Duration
+		resp  string
+		err   error
+	}
+	tcs := map[string]struct {
+		givenMethod   string
+		givenAttempts []attempt
+		expAttempts   int32
+		expResp       string
+		expCode       codes.Code
+	}{
+		"not hedged method": {
+			givenMethod:   "/weather.WeatherService/StreamWeather",
+			givenAttempts: []attempt{{resp: "Hive City"}},
+			expAttempts:   1,
+			expResp:       "Hive City",
+		},
+		"first attempt succeeds before delay": {
+			givenMethod:   "/weather.WeatherService/GetWeatherInfo",
+			givenAttempts: []attempt{{resp: "Hive City"}},
+			expAttempts:   1,
+			expResp:       "Hive City",
+		},
+		"hedged attempt wins": {
+			givenMethod: "/weather.WeatherService/GetWeatherInfo",
+			givenAttempts: []attempt{
+				{delay: time.Second, resp: "Hive City"},
+				{resp: "Macragge"},
+			},
+			expAttempts: 2,
+			expResp:     "Macragge",
+		},
+		"non-fatal error sends the next attempt immediately": {
+			givenMethod: "/weather.WeatherService/GetWeatherInfo",
+			givenAttempts: []attempt{
+				{err: status.Error(codes.Unavailable, "warp storm")},
+				{resp: "Macragge"},
+			},
+			expAttempts: 2,
+			expResp:     "Macragge",
+		},
+		"fatal error": {
+			givenMethod: "/weather.WeatherService/GetWeatherInfo",
+			givenAttempts: []attempt{
+				{err: status.Error(codes.InvalidArgument, "invalid date")},
+			},
+			expAttempts: 1,
+			expCode:     codes.InvalidArgument,
+		},
+		"all attempts failed": {
+			givenMethod: "/weather.WeatherService/GetWeatherInfo",
+			givenAttempts: []attempt{
+				{err: status.Error(codes.Unavailable, "warp storm")},
+				{err: status.Error(codes.Unavailable, "warp storm")},
+				{err: status.Error(codes.Unavailable, "warp storm")},
+			},
+			expAttempts: 3,
+			expCode:     codes.Unavailable,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			policies := newMethodPolicies([]MethodPolicy{{
+				Service: "weather.WeatherService",
+				Method:  "GetWeatherInfo",
+				Hedging: &HedgingPolicy{
+					MaxAttempts:         3,
+					HedgingDelay:        50 * time.Millisecond,
+					NonFatalStatusCodes: []codes.Code{codes.Unavailable},
+				},
+			}})
+
+			var attempts int32
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				a := tc.givenAttempts[atomic.AddInt32(&attempts, 1)-1]
+				select {
+				case <-time.After(a.delay):
+				case <-ctx.Done():
+					return status.FromContextError(ctx.Err()).Err()
+				}
+				if a.err != nil {
+					return a.err
+				}
+				reply.(*testdata.WeatherResponse).WeatherDetails = []*testdata.WeatherDetail{{Location: a.resp}}
+				return nil
+			}
+
+			// When
+			reply := new(testdata.WeatherResponse)
+			err := hedgingClientInterceptor(policies)(context.Background(), tc.givenMethod, &testdata.WeatherRequest{}, reply, nil, invoker)
+
+			// Then
+			require.Equal(t, tc.expAttempts, atomic.LoadInt32(&attempts))
+			require.Equal(t, tc.expCode, status.Code(err))
+			if tc.expCode == codes.OK {
+				require.Equal(t, tc.expResp, reply.GetWeatherDetails()[0].GetLocation())
+			}
+		})
+	}
+}
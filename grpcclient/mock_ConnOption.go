@@ -0,0 +1,65 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package grpcclient
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockConnOption is an autogenerated mock type for the ConnOption type
+type MockConnOption struct {
+	mock.Mock
+}
+
+type MockConnOption_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockConnOption) EXPECT() *MockConnOption_Expecter {
+	return &MockConnOption_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: cfg
+func (_m *MockConnOption) Execute(cfg *connConfig) {
+	_m.Called(cfg)
+}
+
+// MockConnOption_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockConnOption_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - cfg *connConfig
+func (_e *MockConnOption_Expecter) Execute(cfg interface{}) *MockConnOption_Execute_Call {
+	return &MockConnOption_Execute_Call{Call: _e.mock.On("Execute", cfg)}
+}
+
+func (_c *MockConnOption_Execute_Call) Run(run func(cfg *connConfig)) *MockConnOption_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*connConfig))
+	})
+	return _c
+}
+
+func (_c *MockConnOption_Execute_Call) Return() *MockConnOption_Execute_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockConnOption_Execute_Call) RunAndReturn(run func(*connConfig)) *MockConnOption_Execute_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockConnOption creates a new instance of MockConnOption. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockConnOption(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockConnOption {
+	mock := &MockConnOption{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package grpcclient
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockResolver is an autogenerated mock type for the Resolver type
+type MockResolver struct {
+	mock.Mock
+}
+
+type MockResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockResolver) EXPECT() *MockResolver_Expecter {
+	return &MockResolver_Expecter{mock: &_m.Mock}
+}
+
+// Resolve provides a mock function with given fields: ctx, service
+func (_m *MockResolver) Resolve(ctx context.Context, service string) ([]Endpoint, error) {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 []Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Endpoint, error)); ok {
+		return rf(ctx, service)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Endpoint); ok {
+		r0 = rf(ctx, service)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, service)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockResolver_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type MockResolver_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockResolver_Expecter) Resolve(ctx interface{}, service interface{}) *MockResolver_Resolve_Call {
+	return &MockResolver_Resolve_Call{Call: _e.mock.On("Resolve", ctx, service)}
+}
+
+func (_c *MockResolver_Resolve_Call) Run(run func(ctx context.Context, service string)) *MockResolver_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockResolver_Resolve_Call) Return(_a0 []Endpoint, _a1 error) *MockResolver_Resolve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockResolver_Resolve_Call) RunAndReturn(run func(context.Context, string) ([]Endpoint, error)) *MockResolver_Resolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockResolver creates a new instance of MockResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockResolver {
+	mock := &MockResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package grpcclient
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockResolverFunc is an autogenerated mock type for the ResolverFunc type
+type MockResolverFunc struct {
+	mock.Mock
+}
+
+type MockResolverFunc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockResolverFunc) EXPECT() *MockResolverFunc_Expecter {
+	return &MockResolverFunc_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, service
+func (_m *MockResolverFunc) Execute(ctx context.Context, service string) ([]Endpoint, error) {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 []Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Endpoint, error)); ok {
+		return rf(ctx, service)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Endpoint); ok {
+		r0 = rf(ctx, service)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, service)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockResolverFunc_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockResolverFunc_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockResolverFunc_Expecter) Execute(ctx interface{}, service interface{}) *MockResolverFunc_Execute_Call {
+	return &MockResolverFunc_Execute_Call{Call: _e.mock.On("Execute", ctx, service)}
+}
+
+func (_c *MockResolverFunc_Execute_Call) Run(run func(ctx context.Context, service string)) *MockResolverFunc_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockResolverFunc_Execute_Call) Return(_a0 []Endpoint, _a1 error) *MockResolverFunc_Execute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockResolverFunc_Execute_Call) RunAndReturn(run func(context.Context, string) ([]Endpoint, error)) *MockResolverFunc_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockResolverFunc creates a new instance of MockResolverFunc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockResolverFunc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockResolverFunc {
+	mock := &MockResolverFunc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
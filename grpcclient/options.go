@@ -0,0 +1,138 @@
+package grpcclient
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/viebiz/lit/circuitbreaker"
+)
+
+// ConnOption customizes the client connection
+type ConnOption func(cfg *connConfig)
+
+type connConfig struct {
+	dialOpts            []grpc.DialOption
+	perRPCCreds         *tokenCredentials
+	allowInsecurePerRPC bool
+	methodPolicies      []MethodPolicy
+	retryThrottling     *RetryThrottling
+	interceptor         clientInterceptor
+	lbPolicy            LoadBalancingPolicy
+	healthCheck         *string
+	resolver            Resolver
+	resolveInterval     time.Duration
+	reportState         bool
+	circuitBreaker      *circuitbreaker.CircuitBreaker
+}
+
+// WithOAuth attaches the OAuth2 client credentials token to every call, the token is cached and refreshed when expired
+func WithOAuth(oAuthCfg OAuthConfig) ConnOption {
+	return func(cfg *connConfig) {
+		cfg.perRPCCreds = newOAuthCredentials(oAuthCfg)
+	}
+}
+
+// WithForwardedToken forwards the bearer token of the caller to every call. The token is taken from the
+// context set by guard or from the incoming gRPC metadata, calls without the caller token are sent without it
+func WithForwardedToken() ConnOption {
+	return func(cfg *connConfig) {
+		cfg.perRPCCreds = newForwardedCredentials()
+	}
+}
+
+// WithInsecurePerRPCCredentials allows sending the tokens set by WithOAuth or WithForwardedToken over an insecure connection,
+// it should only be used when the transport is secured outside the application, e.g. by a service mesh sidecar
+func WithInsecurePerRPCCredentials() ConnOption {
+	return func(cfg *connConfig) {
+		cfg.allowInsecurePerRPC = true
+	}
+}
+
+// WithKeepalive sets the keepalive parameters of the connection
+func WithKeepalive(params keepalive.ClientParameters) ConnOption {
+	return func(cfg *connConfig) {
+		cfg.dialOpts = append(cfg.dialOpts, grpc.WithKeepaliveParams(params))
+	}
+}
+
+// WithMethodPolicies enables retry or hedging for the methods matching the policies, retry is disabled by default
+func WithMethodPolicies(policies ...MethodPolicy) ConnOption {
+	return func(cfg *connConfig) {
+		cfg.methodPolicies = append(cfg.methodPolicies, policies...)
+	}
+}
+
+// WithRetryThrottling stops retrying and hedging calls when the ratio of failures to successes exceeds the threshold
+func WithRetryThrottling(throttling RetryThrottling) ConnOption {
+	return func(cfg *connConfig) {
+		cfg.retryThrottling = &throttling
+	}
+}
+
+// DisableResponseBodyLogging disables the default behaviour of logging the response body of unary calls and the messages received by streams
+func DisableResponseBodyLogging() ConnOption {
+	return func(cfg *connConfig) {
+		cfg.interceptor.disableRespBodyLogging = true
+	}
+}
+
+// WithPropagatedFields sends the monitoring fields in context, e.g. user_id, to the server via metadata with the field key
+func WithPropagatedFields(keys ...string) ConnOption {
+	return func(cfg *connConfig) {
+		cfg.interceptor.propagatedFields = append(cfg.interceptor.propagatedFields, keys...)
+	}
+}
+
+// WithCircuitBreaker rejects the calls with circuitbreaker.OpenError while the circuit breaker is open,
+// the Unknown, DeadlineExceeded, Internal, Unavailable and DataLoss statuses are counted as failures
+func WithCircuitBreaker(cb *circuitbreaker.CircuitBreaker) ConnOption {
+	return func(cfg *connConfig) {
+		cfg.circuitBreaker = cb
+	}
+}
+
+// WithLoadBalancing sets the policy spreading the calls across the backends returned by the resolver
+func WithLoadBalancing(policy LoadBalancingPolicy) ConnOption {
+	return func(cfg *connConfig) {
+		cfg.lbPolicy = policy
+	}
+}
+
+// WithResolver discovers the backends by the Resolver, the address of the connection is passed to it as the service name.
+// The endpoints are refreshed on each interval, 30 seconds by default, and when a connection fails
+func WithResolver(r Resolver, interval time.Duration) ConnOption {
+	return func(cfg *connConfig) {
+		cfg.resolver = r
+		cfg.resolveInterval = interval
+	}
+}
+
+// WithStaticEndpoints spreads the calls across the given endpoints instead of resolving the address
+func WithStaticEndpoints(endpoints ...Endpoint) ConnOption {
+	return WithResolver(NewStaticResolver(endpoints...), 0)
+}
+
+// WithHealthCheck ejects the backends which are not SERVING by the gRPC health protocol, until they are serving again.
+// The serviceName is checked by the health service of each backend, empty means the whole server.
+// It's not supported by PickFirst
+func WithHealthCheck(serviceName string) ConnOption {
+	return func(cfg *connConfig) {
+		cfg.healthCheck = &serviceName
+	}
+}
+
+// WithStateReporting logs every connectivity state change of the connection by the monitor in the context of the constructor
+func WithStateReporting() ConnOption {
+	return func(cfg *connConfig) {
+		cfg.reportState = true
+	}
+}
+
+// WithDialOptions appends the raw grpc dial options
+func WithDialOptions(opts ...grpc.DialOption) ConnOption {
+	return func(cfg *connConfig) {
+		cfg.dialOpts = append(cfg.dialOpts, opts...)
+	}
+}
@@ -0,0 +1,184 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	// resolverScheme is the target scheme of the connections using WithResolver
+	resolverScheme = "lit"
+
+	defaultResolveInterval = 30 * time.Second
+)
+
+var (
+	// ErrNoEndpoints means the resolver found no endpoint of the service
+	ErrNoEndpoints = errors.New("no endpoints")
+)
+
+// Endpoint is an address of the service
+type Endpoint struct {
+	Addr   string // host:port
+	Weight uint32 // Only used by WeightedRoundRobin, 0 means 1
+}
+
+// Resolver discovers the endpoints of a service, it can be backed by any registry, e.g. a file or Redis
+type Resolver interface {
+	// Resolve returns the current endpoints of the service
+	Resolve(ctx context.Context, service string) ([]Endpoint, error)
+}
+
+// ResolverFunc is an adapter to use the function as Resolver
+type ResolverFunc func(ctx context.Context, service string) ([]Endpoint, error)
+
+// Resolve calls f(ctx, service)
+func (f ResolverFunc) Resolve(ctx context.Context, service string) ([]Endpoint, error) {
+	return f(ctx, service)
+}
+
+// NewStaticResolver returns a Resolver which always returns the given endpoints
+func NewStaticResolver(endpoints ...Endpoint) Resolver {
+	return ResolverFunc(func(context.Context, string) ([]Endpoint, error) {
+		return endpoints, nil
+	})
+}
+
+// NewDNSResolver returns a Resolver which looks up the host of the service, e.g. orders.svc.cluster.local:50051.
+// Unlike the default gRPC DNS resolver which only re-resolves on connection failures, it's refreshed on each
+// interval, so new replicas are picked up while the existing ones are healthy
+func NewDNSResolver() Resolver {
+	return ResolverFunc(func(ctx context.Context, service string) ([]Endpoint, error) {
+		host, port, err := net.SplitHostPort(service)
+		if err != nil {
+			return nil, pkgerrors.WithStack(err)
+		}
+
+		ips, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil {
+			return nil, pkgerrors.WithStack(err)
+		}
+
+		endpoints := make([]Endpoint, len(ips))
+		for idx, ip := range ips {
+			endpoints[idx] = Endpoint{Addr: net.JoinHostPort(ip, port)}
+		}
+
+		return endpoints, nil
+	})
+}
+
+// resolverBuilder builds the grpc resolver which polls the Resolver on each interval
+type resolverBuilder struct {
+	resolver Resolver
+	interval time.Duration
+}
+
+func (b resolverBuilder) Scheme() string {
+	return resolverScheme
+}
+
+func (b resolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r := &pollingResolver{
+		resolver:   b.resolver,
+		service:    target.Endpoint(),
+		interval:   b.interval,
+		cc:         cc,
+		resolveNow: make(chan struct{}, 1),
+		cancel:     cancel,
+	}
+	if r.interval <= 0 {
+		r.interval = defaultResolveInterval
+	}
+
+	r.wg.Add(1)
+	go r.run(ctx)
+
+	return r, nil
+}
+
+type pollingResolver struct {
+	resolver   Resolver
+	service    string
+	interval   time.Duration
+	cc         resolver.ClientConn
+	resolveNow chan struct{}
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+func (r *pollingResolver) run(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.resolve(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.resolveNow:
+		}
+	}
+}
+
+func (r *pollingResolver) resolve(ctx context.Context) {
+	endpoints, err := r.resolver.Resolve(ctx, r.service)
+	if err != nil {
+		r.cc.ReportError(err)
+		return
+	}
+
+	if len(endpoints) == 0 {
+		r.cc.ReportError(pkgerrors.WithStack(ErrNoEndpoints))
+		return
+	}
+
+	state := resolver.State{
+		Addresses: make([]resolver.Address, len(endpoints)),
+		Endpoints: make([]resolver.Endpoint, len(endpoints)),
+	}
+	for idx, ep := range endpoints {
+		addr := resolver.Address{
+			Addr:               ep.Addr,
+			BalancerAttributes: attributes.New(weightAttributeKey{}, ep.weight()),
+		}
+		state.Addresses[idx] = addr
+		state.Endpoints[idx] = resolver.Endpoint{Addresses: []resolver.Address{addr}}
+	}
+
+	_ = r.cc.UpdateState(state) // Errors are reported by the balancer, it's resolved again on ResolveNow
+}
+
+// ResolveNow is called by gRPC when a connection fails, the resolution is skipped if one is already pending
+func (r *pollingResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+func (r *pollingResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (ep Endpoint) weight() uint32 {
+	if ep.Weight == 0 {
+		return 1
+	}
+
+	return ep.Weight
+}
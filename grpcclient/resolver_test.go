@@ -0,0 +1,117 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/viebiz/lit/grpcclient/testdata"
+)
+
+func TestWithResolver(t *testing.T) {
+	// Given
+	first, second := &namedWeatherService{}, &namedWeatherService{}
+	firstAddr, secondAddr := startWeatherServer(t, first), startWeatherServer(t, second)
+
+	var (
+		resolved atomic.Value
+		service  atomic.Value
+	)
+	resolved.Store(firstAddr)
+	r := ResolverFunc(func(_ context.Context, svc string) ([]Endpoint, error) {
+		service.Store(svc)
+		return []Endpoint{{Addr: resolved.Load().(string)}}, nil
+	})
+
+	conn, err := NewUnauthenticatedConnection(context.Background(), "weather.svc", WithResolver(r, 10*time.Millisecond))
+	require.NoError(t, err)
+	client := testdata.NewWeatherServiceClient(conn)
+
+	// When
+	_, err = client.GetWeatherInfo(context.Background(), &testdata.WeatherRequest{})
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "weather.svc", service.Load())
+	require.Equal(t, 1, first.count())
+
+	// When the registry is updated
+	resolved.Store(secondAddr)
+
+	// Then
+	require.Eventually(t, func() bool {
+		_, err := client.GetWeatherInfo(context.Background(), &testdata.WeatherRequest{})
+		require.NoError(t, err)
+		return second.count() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWithResolver_Error(t *testing.T) {
+	tcs := map[string]struct {
+		givenEndpoints []Endpoint
+		givenErr       error
+	}{
+		"resolver error": {
+			givenErr: errors.New("registry is unavailable"),
+		},
+		"no endpoints": {},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			r := ResolverFunc(func(context.Context, string) ([]Endpoint, error) {
+				return tc.givenEndpoints, tc.givenErr
+			})
+
+			conn, err := NewUnauthenticatedConnection(context.Background(), "weather.svc", WithResolver(r, time.Minute))
+			require.NoError(t, err)
+
+			// When
+			_, err = testdata.NewWeatherServiceClient(conn).GetWeatherInfo(context.Background(), &testdata.WeatherRequest{})
+
+			// Then
+			require.Equal(t, codes.Unavailable, status.Code(err))
+		})
+	}
+}
+
+func TestNewDNSResolver(t *testing.T) {
+	// When
+	endpoints, err := NewDNSResolver().Resolve(context.Background(), "localhost:50051")
+
+	// Then
+	require.NoError(t, err)
+	require.NotEmpty(t, endpoints)
+	for _, ep := range endpoints {
+		host, port, err := net.SplitHostPort(ep.Addr)
+		require.NoError(t, err)
+		require.Equal(t, "50051", port)
+		require.True(t, net.ParseIP(host).IsLoopback())
+	}
+
+	// When the port is missing
+	_, err = NewDNSResolver().Resolve(context.Background(), "localhost")
+
+	// Then
+	require.Error(t, err)
+}
+
+func TestNewStaticResolver(t *testing.T) {
+	endpoints := []Endpoint{{Addr: "10.0.0.1:50051", Weight: 2}, {Addr: "10.0.0.2:50051"}}
+
+	rs, err := NewStaticResolver(endpoints...).Resolve(context.Background(), "weather.svc")
+
+	require.NoError(t, err)
+	require.Equal(t, endpoints, rs)
+}
@@ -0,0 +1,210 @@
+package grpcclient
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	// ErrInvalidMethodPolicy means the method policy cannot be applied
+	ErrInvalidMethodPolicy = errors.New("invalid method policy")
+)
+
+// MethodPolicy configures the calls of the methods matching Service and Method
+//
+// Policies are matched by the exact method first, then by the service (empty Method) and
+// finally by the default policy (empty Service and Method)
+type MethodPolicy struct {
+	Service string // Full service name, e.g. weather.WeatherService
+	Method  string // Method name, e.g. GetWeatherInfo
+	Timeout time.Duration
+	Retry   *RetryPolicy   // Retry the failed attempt, cannot be combined with Hedging
+	Hedging *HedgingPolicy // Send the parallel attempts for unary calls, cannot be combined with Retry
+}
+
+// RetryPolicy retries the failed attempts with exponential backoff
+type RetryPolicy struct {
+	MaxAttempts          int // Including the original attempt, gRPC caps it at 5
+	InitialBackoff       time.Duration
+	MaxBackoff           time.Duration
+	BackoffMultiplier    float64
+	RetryableStatusCodes []codes.Code
+}
+
+// HedgingPolicy sends an attempt each HedgingDelay until one of them succeeds or MaxAttempts is reached
+type HedgingPolicy struct {
+	MaxAttempts  int // Including the original attempt
+	HedgingDelay time.Duration
+	// NonFatalStatusCodes are the codes which let the other attempts continue, any other code fails the call immediately
+	NonFatalStatusCodes []codes.Code
+}
+
+// RetryThrottling is the token bucket throttling the retries and hedged attempts per server
+type RetryThrottling struct {
+	MaxTokens  int
+	TokenRatio float64
+}
+
+// grpcServiceConfig is the JSON service config, see https://github.com/grpc/grpc/blob/master/doc/service_config.md
+type grpcServiceConfig struct {
+	LoadBalancingConfig []map[string]struct{}  `json:"loadBalancingConfig,omitempty"`
+	HealthCheckConfig   *grpcHealthCheckConfig `json:"healthCheckConfig,omitempty"`
+	MethodConfig        []grpcMethodConfig     `json:"methodConfig,omitempty"`
+	RetryThrottling     *grpcRetryThrottling   `json:"retryThrottling,omitempty"`
+}
+
+type grpcHealthCheckConfig struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type grpcMethodConfig struct {
+	Name        []grpcMethodName `json:"name"`
+	Timeout     string           `json:"timeout,omitempty"`
+	RetryPolicy *grpcRetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type grpcMethodName struct {
+	Service string `json:"service,omitempty"`
+	Method  string `json:"method,omitempty"`
+}
+
+type grpcRetryPolicy struct {
+	MaxAttempts          int          `json:"maxAttempts"`
+	InitialBackoff       string       `json:"initialBackoff"`
+	MaxBackoff           string       `json:"maxBackoff"`
+	BackoffMultiplier    float64      `json:"backoffMultiplier"`
+	RetryableStatusCodes []codes.Code `json:"retryableStatusCodes"`
+}
+
+type grpcRetryThrottling struct {
+	MaxTokens  int     `json:"maxTokens"`
+	TokenRatio float64 `json:"tokenRatio"`
+}
+
+// hasServiceConfig reports whether the connection needs a service config
+func (cfg connConfig) hasServiceConfig() bool {
+	return len(cfg.methodPolicies) > 0 || cfg.retryThrottling != nil || cfg.lbPolicy != "" || cfg.healthCheck != nil
+}
+
+// buildServiceConfig converts the connection config to the JSON service config. Hedging is not supported by grpc-go,
+// so it's left out of the service config and handled by hedgingClientInterceptor
+func buildServiceConfig(cfg connConfig) (string, error) {
+	var sc grpcServiceConfig
+	if cfg.lbPolicy != "" {
+		sc.LoadBalancingConfig = []map[string]struct{}{{string(cfg.lbPolicy): {}}}
+	}
+
+	if cfg.healthCheck != nil {
+		sc.HealthCheckConfig = &grpcHealthCheckConfig{ServiceName: *cfg.healthCheck}
+	}
+
+	for _, p := range cfg.methodPolicies {
+		if err := p.validate(); err != nil {
+			return "", err
+		}
+
+		mc := grpcMethodConfig{
+			Name: []grpcMethodName{{Service: p.Service, Method: p.Method}},
+		}
+		if p.Timeout > 0 {
+			mc.Timeout = formatDuration(p.Timeout)
+		}
+		if p.Retry != nil {
+			mc.RetryPolicy = &grpcRetryPolicy{
+				MaxAttempts:          p.Retry.MaxAttempts,
+				InitialBackoff:       formatDuration(p.Retry.InitialBackoff),
+				MaxBackoff:           formatDuration(p.Retry.MaxBackoff),
+				BackoffMultiplier:    p.Retry.BackoffMultiplier,
+				RetryableStatusCodes: p.Retry.RetryableStatusCodes,
+			}
+		}
+
+		sc.MethodConfig = append(sc.MethodConfig, mc)
+	}
+
+	if cfg.retryThrottling != nil {
+		sc.RetryThrottling = &grpcRetryThrottling{MaxTokens: cfg.retryThrottling.MaxTokens, TokenRatio: cfg.retryThrottling.TokenRatio}
+	}
+
+	b, err := json.Marshal(sc)
+	if err != nil {
+		return "", pkgerrors.WithStack(err)
+	}
+
+	return string(b), nil
+}
+
+func (p MethodPolicy) validate() error {
+	if p.Service == "" && p.Method != "" {
+		return pkgerrors.Wrap(ErrInvalidMethodPolicy, "method requires service")
+	}
+
+	if p.Retry != nil && p.Hedging != nil {
+		return pkgerrors.Wrap(ErrInvalidMethodPolicy, "retry and hedging cannot be combined")
+	}
+
+	if p.Retry != nil {
+		if p.Retry.MaxAttempts < 2 {
+			return pkgerrors.Wrap(ErrInvalidMethodPolicy, "retry max attempts must be greater than 1")
+		}
+		if p.Retry.InitialBackoff <= 0 || p.Retry.MaxBackoff <= 0 || p.Retry.BackoffMultiplier <= 0 {
+			return pkgerrors.Wrap(ErrInvalidMethodPolicy, "retry backoff must be greater than 0")
+		}
+		if len(p.Retry.RetryableStatusCodes) == 0 {
+			return pkgerrors.Wrap(ErrInvalidMethodPolicy, "retry requires retryable status codes")
+		}
+	}
+
+	if p.Hedging != nil && p.Hedging.MaxAttempts < 2 {
+		return pkgerrors.Wrap(ErrInvalidMethodPolicy, "hedging max attempts must be greater than 1")
+	}
+
+	return nil
+}
+
+// formatDuration formats the duration in the service config format, e.g. 0.1s
+func formatDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+// methodPolicies looks up the policy of the full method name
+type methodPolicies map[string]MethodPolicy
+
+func newMethodPolicies(policies []MethodPolicy) methodPolicies {
+	rs := make(methodPolicies, len(policies))
+	for _, p := range policies {
+		rs[p.key()] = p
+	}
+
+	return rs
+}
+
+func (p MethodPolicy) key() string {
+	if p.Service == "" {
+		return ""
+	}
+
+	return "/" + p.Service + "/" + p.Method
+}
+
+func (m methodPolicies) lookup(fullMethod string) (MethodPolicy, bool) {
+	if p, ok := m[fullMethod]; ok {
+		return p, true
+	}
+
+	// Fallback to the service policy, e.g. /weather.WeatherService/
+	if idx := strings.LastIndex(fullMethod, "/"); idx > 0 {
+		if p, ok := m[fullMethod[:idx+1]]; ok {
+			return p, true
+		}
+	}
+
+	p, ok := m[""]
+	return p, ok
+}
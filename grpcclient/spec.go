@@ -11,4 +11,7 @@ type Conn interface {
 	Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error
 
 	NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error)
+
+	// Close tears down the connection, the pending calls are canceled
+	Close() error
 }
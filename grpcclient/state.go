@@ -0,0 +1,37 @@
+package grpcclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/viebiz/lit/monitoring"
+)
+
+// reportConnectivityState logs every connectivity state change of the connection from the given state until it's shut down or ctx is done.
+// The changes happening before the goroutine gets the new state are merged, e.g. CONNECTING is skipped when it's READY already
+func reportConnectivityState(ctx context.Context, conn *grpc.ClientConn, state connectivity.State) {
+	for {
+		if !conn.WaitForStateChange(ctx, state) {
+			return // ctx is done
+		}
+
+		prev := state
+		state = conn.GetState()
+
+		level := monitoring.InfoLevel
+		if state == connectivity.TransientFailure {
+			level = monitoring.WarnLevel
+		}
+		monitoring.FromContext(ctx).Log(level, "grpc.connectivity_state_changed",
+			monitoring.StringField("grpc.target", conn.Target()),
+			monitoring.StringField("grpc.previous_state", prev.String()),
+			monitoring.StringField("grpc.state", state.String()),
+		)
+
+		if state == connectivity.Shutdown {
+			return
+		}
+	}
+}
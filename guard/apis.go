@@ -4,9 +4,14 @@ import (
 	"github.com/viebiz/lit/iam"
 )
 
-func New(validator iam.Validator, enforcer iam.Enforcer) AuthGuard {
-	return AuthGuard{
+func New(validator iam.Validator, enforcer iam.Enforcer, opts ...Option) AuthGuard {
+	guard := AuthGuard{
 		validator: validator,
 		enforcer:  enforcer,
 	}
+	for _, opt := range opts {
+		opt(&guard)
+	}
+
+	return guard
 }
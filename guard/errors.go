@@ -4,6 +4,9 @@ import (
 	"errors"
 	"net/http"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/viebiz/lit"
 )
 
@@ -13,10 +16,20 @@ const (
 )
 
 var (
+	// ErrInvalidGRPCMethodRule means a GRPCMethodRule can't be enforced, e.g. Scopes without M2M
+	ErrInvalidGRPCMethodRule = errors.New("invalid gRPC method rule")
+)
+
+var (
+	errMissingEnforcer     = errors.New("enforcer is not configured")
 	errUserProfileNotInCtx = errors.New("user profile not in context")
 	errM2MProfileNotInCtx  = errors.New("m2m profile not in context")
 	errMissingAccessToken  = &lit.HTTPError{Status: http.StatusUnauthorized, Code: unAuthorizedKey, Desc: "Access token is required"}
 	errForbidden           = &lit.HTTPError{Status: http.StatusForbidden, Code: forbiddenKey, Desc: "Permission denied"}
+
+	errGRPCMissingAccessToken = status.Error(codes.Unauthenticated, "Access token is required")
+	errGRPCPermissionDenied   = status.Error(codes.PermissionDenied, "Permission denied")
+	errGRPCInternal           = status.Error(codes.Internal, "internal error")
 )
 
 func unauthorizedErr(err error) *lit.HTTPError {
@@ -0,0 +1,177 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/viebiz/lit/iam"
+	"github.com/viebiz/lit/monitoring"
+)
+
+const (
+	metadataAuthorization = "authorization"
+)
+
+// UnaryServerInterceptor authenticates and authorizes the unary calls by the method rules.
+// It returns ErrInvalidGRPCMethodRule if a rule can't be enforced, e.g. Scopes without M2M or a Resource without enforcer
+//
+// Example:
+//
+//	rules := guard.GRPCMethodRules{
+//		"/grpc.health.v1.Health/*":           {Public: true},
+//		"/orders.v1.OrderService/GetOrder":   {Resource: "orders", Action: guard.ActionRead},
+//		"/orders.v1.OrderService/SyncOrders": {M2M: true, Scopes: []string{"orders:sync"}},
+//	}
+//	interceptor, err := authGuard.UnaryServerInterceptor(rules)
+//	if err != nil {
+//		return err
+//	}
+//	grpc.NewServer(grpc.ChainUnaryInterceptor(interceptor))
+func (guard AuthGuard) UnaryServerInterceptor(rules GRPCMethodRules) (grpc.UnaryServerInterceptor, error) {
+	if err := guard.validateGRPCMethodRules(rules); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := guard.authorizeGRPCCall(ctx, rules, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}, nil
+}
+
+// StreamServerInterceptor authenticates and authorizes the stream calls by the method rules,
+// the rules are validated as by UnaryServerInterceptor
+func (guard AuthGuard) StreamServerInterceptor(rules GRPCMethodRules) (grpc.StreamServerInterceptor, error) {
+	if err := guard.validateGRPCMethodRules(rules); err != nil {
+		return nil, err
+	}
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := guard.authorizeGRPCCall(ss.Context(), rules, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, authServerStream{ServerStream: ss, ctx: ctx})
+	}, nil
+}
+
+// validateGRPCMethodRules rejects the rules which would be ignored silently or fail every call
+func (guard AuthGuard) validateGRPCMethodRules(rules GRPCMethodRules) error {
+	for method, rule := range rules {
+		if len(rule.Scopes) > 0 && !rule.M2M {
+			return fmt.Errorf("%w: %s requires scopes without M2M", ErrInvalidGRPCMethodRule, method)
+		}
+
+		if rule.Resource != "" && guard.enforcer == nil {
+			return fmt.Errorf("%w: %s requires a resource permission without enforcer", ErrInvalidGRPCMethodRule, method)
+		}
+	}
+
+	return nil
+}
+
+// authServerStream overrides the stream context with the authenticated one
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (guard AuthGuard) authorizeGRPCCall(ctx context.Context, rules GRPCMethodRules, fullMethod string) (context.Context, error) {
+	// 1. Find the method rule
+	rule, ok := rules.lookup(fullMethod)
+	if !ok {
+		return ctx, errGRPCPermissionDenied
+	}
+
+	if rule.Public {
+		return ctx, nil
+	}
+
+	// 2. Get access token from request metadata
+	tokenStr := getGRPCTokenString(ctx)
+	if tokenStr == "" {
+		return ctx, errGRPCMissingAccessToken
+	}
+
+	// 3. Validate access token
+	tk, err := guard.validator.Validate(tokenStr)
+	if err != nil {
+		return ctx, convertGRPCError(ctx, err)
+	}
+
+	// 4. Extract the profile from token claims, inject it to request context and check the permission
+	if rule.M2M {
+		profile, err := guard.claimMapping.ExtractM2MProfile(tk.Claims)
+		if err != nil {
+			return ctx, convertGRPCError(ctx, err)
+		}
+
+		ctx = iam.SetM2MProfileInContext(ctx, profile)
+		ctx = iam.SetAccessTokenInContext(ctx, tokenStr)
+		ctx = monitoring.InjectField(ctx, m2mIDKey, profile.ID())
+
+		if len(rule.Scopes) > 0 && !profile.HasAnyScope(rule.Scopes...) {
+			return ctx, errGRPCPermissionDenied
+		}
+
+		return ctx, nil
+	}
+
+	profile, err := guard.claimMapping.ExtractUserProfile(tk.Claims)
+	if err != nil {
+		return ctx, convertGRPCError(ctx, err)
+	}
+
+	ctx = iam.SetUserProfileInContext(ctx, profile)
+	ctx = iam.SetAccessTokenInContext(ctx, tokenStr)
+	ctx = monitoring.InjectFields(ctx, map[string]string{
+		userIDKey: profile.ID(),
+		roleKey:   profile.GetRoleString(),
+	})
+
+	if rule.Resource != "" {
+		if err := guard.enforceRole(profile, rule.Resource, rule.Action); err != nil {
+			return ctx, convertGRPCError(ctx, err)
+		}
+	}
+
+	return ctx, nil
+}
+
+func getGRPCTokenString(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, metadataAuthorization)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return parseBearerToken(values[0])
+}
+
+func convertGRPCError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, iam.ErrMissingRequiredClaim),
+		errors.Is(err, iam.ErrTokenExpired),
+		errors.Is(err, iam.ErrTokenRevoked),
+		errors.Is(err, iam.ErrInvalidToken):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, iam.ErrActionIsNotAllowed):
+		return errGRPCPermissionDenied
+	default:
+		monitoring.FromContext(ctx).Errorf(err, "Failed to authorize gRPC call")
+		return errGRPCInternal
+	}
+}
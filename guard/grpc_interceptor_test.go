@@ -0,0 +1,353 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/viebiz/lit/iam"
+	"github.com/viebiz/lit/jwt"
+)
+
+func TestAuthGuard_UnaryServerInterceptor(t *testing.T) {
+	rules := GRPCMethodRules{
+		"/grpc.health.v1.Health/*":          {Public: true},
+		"/orders.v1.OrderService/GetOrder":  {Resource: "orders", Action: ActionRead},
+		"/orders.v1.OrderService/SyncOrder": {M2M: true, Scopes: []string{"orders:sync"}},
+		"/orders.v1.OrderService/Ping":      {},
+	}
+	userToken := jwt.Token[iam.Claims]{
+		Claims: iam.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "imperium|space_marine"},
+			ExtraClaims:      map[string]interface{}{"https://lightning.app/roles": []string{"primarch"}},
+		},
+	}
+	m2mToken := jwt.Token[iam.Claims]{
+		Claims: iam.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "imperium|ultra_marine"},
+			ExtraClaims:      map[string]interface{}{"scope": "orders:read orders:sync"},
+		},
+	}
+
+	type mockValidator struct {
+		expCall  bool
+		outToken jwt.Token[iam.Claims]
+		outErr   error
+	}
+	type mockEnforcer struct {
+		expCall bool
+		outErr  error
+	}
+	tcs := map[string]struct {
+		givenMethod   string
+		givenAuth     string
+		mockValidator mockValidator
+		mockEnforcer  mockEnforcer
+		expUser       iam.UserProfile
+		expM2M        iam.M2MProfile
+		expCode       codes.Code
+	}{
+		"success - public method": {
+			givenMethod: "/grpc.health.v1.Health/Check",
+			expCode:     codes.OK,
+		},
+		"success - user permitted": {
+			givenMethod:   "/orders.v1.OrderService/GetOrder",
+			givenAuth:     "Bearer user-token",
+			mockValidator: mockValidator{expCall: true, outToken: userToken},
+			mockEnforcer:  mockEnforcer{expCall: true},
+			expUser:       iam.NewUserProfile("imperium|space_marine", []string{"primarch"}, nil),
+			expCode:       codes.OK,
+		},
+		"success - authenticated user without permission rule": {
+			givenMethod:   "/orders.v1.OrderService/Ping",
+			givenAuth:     "Bearer user-token",
+			mockValidator: mockValidator{expCall: true, outToken: userToken},
+			expUser:       iam.NewUserProfile("imperium|space_marine", []string{"primarch"}, nil),
+			expCode:       codes.OK,
+		},
+		"success - m2m scope": {
+			givenMethod:   "/orders.v1.OrderService/SyncOrder",
+			givenAuth:     "Bearer m2m-token",
+			mockValidator: mockValidator{expCall: true, outToken: m2mToken},
+			expM2M:        iam.NewM2MProfile("imperium|ultra_marine", []string{"orders:read", "orders:sync"}),
+			expCode:       codes.OK,
+		},
+		"error - method not configured": {
+			givenMethod: "/orders.v1.OrderService/DeleteOrder",
+			givenAuth:   "Bearer user-token",
+			expCode:     codes.PermissionDenied,
+		},
+		"error - missing access token": {
+			givenMethod: "/orders.v1.OrderService/GetOrder",
+			expCode:     codes.Unauthenticated,
+		},
+		"error - invalid authorization scheme": {
+			givenMethod: "/orders.v1.OrderService/GetOrder",
+			givenAuth:   "Basic dXNlcjpwYXNz",
+			expCode:     codes.Unauthenticated,
+		},
+		"error - token expired": {
+			givenMethod:   "/orders.v1.OrderService/GetOrder",
+			givenAuth:     "Bearer user-token",
+			mockValidator: mockValidator{expCall: true, outErr: iam.ErrTokenExpired},
+			expCode:       codes.Unauthenticated,
+		},
+		"error - token revoked": {
+			givenMethod:   "/orders.v1.OrderService/GetOrder",
+			givenAuth:     "Bearer user-token",
+			mockValidator: mockValidator{expCall: true, outErr: iam.ErrTokenRevoked},
+			expCode:       codes.Unauthenticated,
+		},
+		"error - missing role claim": {
+			givenMethod:   "/orders.v1.OrderService/GetOrder",
+			givenAuth:     "Bearer m2m-token",
+			mockValidator: mockValidator{expCall: true, outToken: m2mToken},
+			expCode:       codes.Unauthenticated,
+		},
+		"error - action is not allowed": {
+			givenMethod:   "/orders.v1.OrderService/GetOrder",
+			givenAuth:     "Bearer user-token",
+			mockValidator: mockValidator{expCall: true, outToken: userToken},
+			mockEnforcer:  mockEnforcer{expCall: true, outErr: iam.ErrActionIsNotAllowed},
+			expCode:       codes.PermissionDenied,
+		},
+		"error - missing m2m scope": {
+			givenMethod: "/orders.v1.OrderService/SyncOrder",
+			givenAuth:   "Bearer m2m-token",
+			mockValidator: mockValidator{expCall: true, outToken: jwt.Token[iam.Claims]{
+				Claims: iam.Claims{
+					RegisteredClaims: jwt.RegisteredClaims{Subject: "imperium|dark_angel"},
+					ExtraClaims:      map[string]interface{}{"scope": "orders:read"},
+				},
+			}},
+			expCode: codes.PermissionDenied,
+		},
+		"error - unexpected error": {
+			givenMethod:   "/orders.v1.OrderService/GetOrder",
+			givenAuth:     "Bearer user-token",
+			mockValidator: mockValidator{expCall: true, outErr: errors.New("simulated error")},
+			expCode:       codes.Internal,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			ctx := context.Background()
+			if tc.givenAuth != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tc.givenAuth))
+			}
+
+			validator := new(iam.MockValidator)
+			if tc.mockValidator.expCall {
+				validator.EXPECT().Validate(tc.givenAuth[len("Bearer "):]).
+					Return(tc.mockValidator.outToken, tc.mockValidator.outErr)
+			}
+
+			enforcer := new(iam.MockEnforcer)
+			if tc.mockEnforcer.expCall {
+				enforcer.EXPECT().Enforce("primarch", "orders", "R").Return(tc.mockEnforcer.outErr)
+			}
+
+			var handlerCtx context.Context
+			handler := func(ctx context.Context, req any) (any, error) {
+				handlerCtx = ctx
+				return "pong", nil
+			}
+
+			interceptor, err := New(validator, enforcer).UnaryServerInterceptor(rules)
+			require.NoError(t, err)
+
+			// When
+			rs, err := interceptor(ctx, "ping", &grpc.UnaryServerInfo{FullMethod: tc.givenMethod}, handler)
+
+			// Then
+			require.Equal(t, tc.expCode, status.Code(err))
+			if tc.expCode == codes.OK {
+				require.Equal(t, "pong", rs)
+				require.Equal(t, tc.expUser, iam.GetUserProfileFromContext(handlerCtx))
+				require.Equal(t, tc.expM2M, iam.GetM2MProfileFromContext(handlerCtx))
+				if tc.mockValidator.expCall {
+					require.Equal(t, tc.givenAuth[len("Bearer "):], iam.GetAccessTokenFromContext(handlerCtx))
+				}
+			}
+			validator.AssertExpectations(t)
+			enforcer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthGuard_StreamServerInterceptor(t *testing.T) {
+	rules := GRPCMethodRules{
+		"/orders.v1.OrderService/*": {M2M: true, Scopes: []string{"orders:watch"}},
+	}
+
+	tcs := map[string]struct {
+		givenScope string
+		expCode    codes.Code
+	}{
+		"success": {
+			givenScope: "orders:watch",
+			expCode:    codes.OK,
+		},
+		"error - missing scope": {
+			givenScope: "orders:read",
+			expCode:    codes.PermissionDenied,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer m2m-token"))
+
+			validator := new(iam.MockValidator)
+			validator.EXPECT().Validate("m2m-token").Return(jwt.Token[iam.Claims]{
+				Claims: iam.Claims{
+					RegisteredClaims: jwt.RegisteredClaims{Subject: "imperium|ultra_marine"},
+					ExtraClaims:      map[string]interface{}{"scope": tc.givenScope},
+				},
+			}, nil)
+
+			var streamCtx context.Context
+			handler := func(srv any, stream grpc.ServerStream) error {
+				streamCtx = stream.Context()
+				return nil
+			}
+
+			interceptor, err := New(validator, nil).StreamServerInterceptor(rules)
+			require.NoError(t, err)
+
+			// When
+			err = interceptor(nil, fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/orders.v1.OrderService/WatchOrders"}, handler)
+
+			// Then
+			require.Equal(t, tc.expCode, status.Code(err))
+			if tc.expCode == codes.OK {
+				require.Equal(t, "imperium|ultra_marine", iam.GetM2MProfileFromContext(streamCtx).ID())
+			}
+			validator.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthGuard_ServerInterceptor_InvalidRules(t *testing.T) {
+	tcs := map[string]struct {
+		givenRules    GRPCMethodRules
+		givenEnforcer iam.Enforcer
+		expErr        error
+	}{
+		"success": {
+			givenRules: GRPCMethodRules{
+				"/orders.v1.OrderService/GetOrder":  {Resource: "orders", Action: ActionRead},
+				"/orders.v1.OrderService/SyncOrder": {M2M: true, Scopes: []string{"orders:sync"}},
+			},
+			givenEnforcer: new(iam.MockEnforcer),
+		},
+		"error - scopes without m2m": {
+			givenRules: GRPCMethodRules{
+				"/orders.v1.OrderService/SyncOrder": {Scopes: []string{"orders:sync"}},
+			},
+			givenEnforcer: new(iam.MockEnforcer),
+			expErr:        ErrInvalidGRPCMethodRule,
+		},
+		"error - resource without enforcer": {
+			givenRules: GRPCMethodRules{
+				"/orders.v1.OrderService/GetOrder": {Resource: "orders", Action: ActionRead},
+			},
+			expErr: ErrInvalidGRPCMethodRule,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			authGuard := New(new(iam.MockValidator), tc.givenEnforcer)
+
+			// When
+			unaryInterceptor, unaryErr := authGuard.UnaryServerInterceptor(tc.givenRules)
+			streamInterceptor, streamErr := authGuard.StreamServerInterceptor(tc.givenRules)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, unaryErr, tc.expErr)
+				require.ErrorIs(t, streamErr, tc.expErr)
+				return
+			}
+
+			require.NoError(t, unaryErr)
+			require.NoError(t, streamErr)
+			require.NotNil(t, unaryInterceptor)
+			require.NotNil(t, streamInterceptor)
+		})
+	}
+}
+
+func TestAuthGuard_authorizeGRPCCall_MissingEnforcer(t *testing.T) {
+	// Given
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer user-token"))
+	rules := GRPCMethodRules{"/orders.v1.OrderService/GetOrder": {Resource: "orders", Action: ActionRead}}
+
+	validator := new(iam.MockValidator)
+	validator.EXPECT().Validate("user-token").Return(jwt.Token[iam.Claims]{
+		Claims: iam.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "imperium|space_marine"},
+			ExtraClaims:      map[string]interface{}{"https://lightning.app/roles": []string{"primarch"}},
+		},
+	}, nil)
+
+	// When
+	_, err := New(validator, nil).authorizeGRPCCall(ctx, rules, "/orders.v1.OrderService/GetOrder")
+
+	// Then
+	require.Equal(t, codes.Internal, status.Code(err))
+	validator.AssertExpectations(t)
+}
+
+func TestGRPCMethodRules_lookup(t *testing.T) {
+	rules := GRPCMethodRules{
+		"/orders.v1.OrderService/GetOrder": {Resource: "orders"},
+		"/orders.v1.OrderService/*":        {Resource: "order_service"},
+		"*":                                {Resource: "default"},
+	}
+
+	rule, ok := rules.lookup("/orders.v1.OrderService/GetOrder")
+	require.True(t, ok)
+	require.Equal(t, "orders", rule.Resource)
+
+	rule, ok = rules.lookup("/orders.v1.OrderService/ListOrders")
+	require.True(t, ok)
+	require.Equal(t, "order_service", rule.Resource)
+
+	rule, ok = rules.lookup("/payments.v1.PaymentService/Pay")
+	require.True(t, ok)
+	require.Equal(t, "default", rule.Resource)
+
+	_, ok = GRPCMethodRules{}.lookup("/payments.v1.PaymentService/Pay")
+	require.False(t, ok)
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s fakeServerStream) Context() context.Context {
+	return s.ctx
+}
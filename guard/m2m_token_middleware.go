@@ -19,13 +19,13 @@ func (guard AuthGuard) AuthenticateM2MMiddleware() lit.HandlerFunc {
 		}
 
 		// 2. Validate access token
-		tk, err := guard.validator.Validate(getTokenString(c.Request()))
+		tk, err := guard.validator.Validate(tokenStr)
 		if err != nil {
 			return convertError(err)
 		}
 
 		// 3. Extract M2M profile from token claims
-		profile, err := iam.ExtractM2MProfileFromClaims(tk.Claims)
+		profile, err := guard.claimMapping.ExtractM2MProfile(tk.Claims)
 		if err != nil {
 			return convertError(err)
 		}
@@ -33,6 +33,7 @@ func (guard AuthGuard) AuthenticateM2MMiddleware() lit.HandlerFunc {
 		// 4. Inject user information to request context
 		ctx := c.Request().Context()
 		ctx = iam.SetM2MProfileInContext(ctx, profile)
+		ctx = iam.SetAccessTokenInContext(ctx, tokenStr)
 		ctx = monitoring.InjectField(ctx, m2mIDKey, profile.ID())
 		c.SetRequestContext(ctx)
 
@@ -0,0 +1,16 @@
+package guard
+
+import (
+	"github.com/viebiz/lit/iam"
+)
+
+// Option customizes the AuthGuard
+type Option func(guard *AuthGuard)
+
+// WithClaimMapping reads the user and M2M profiles from the claims of the mapping, e.g. the `realm_access.roles`
+// and `scp` claims of the IdP, instead of the default claims
+func WithClaimMapping(mapping iam.ClaimMapping) Option {
+	return func(guard *AuthGuard) {
+		guard.claimMapping = mapping
+	}
+}
@@ -0,0 +1,58 @@
+package guard
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/viebiz/lit"
+	"github.com/viebiz/lit/iam"
+)
+
+type revokeTokenRequest struct {
+	JTI       string `json:"jti" binding:"required"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type revokeSubjectRequest struct {
+	Subject string `json:"sub" binding:"required"`
+}
+
+// RevokeTokenHandler is the admin API revoking a token by its `jti`, e.g. a leaked token.
+// The body is {"jti": "...", "exp": 1721782800}, the token is revoked until its `exp`, or the max token lifetime if it's not given.
+// The handler must be mounted behind the admin authorization, e.g. RolePermissionHandler
+func RevokeTokenHandler(revoker iam.Revoker) lit.HandlerFunc {
+	return func(c lit.Context) error {
+		var req revokeTokenRequest
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+
+		var expiresAt time.Time
+		if req.ExpiresAt > 0 {
+			expiresAt = time.Unix(req.ExpiresAt, 0)
+		}
+
+		if err := revoker.RevokeToken(c.Request().Context(), req.JTI, expiresAt); err != nil {
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// RevokeSubjectHandler is the admin API revoking all tokens of a subject issued until now, e.g. to log out a user everywhere.
+// The body is {"sub": "..."}. The handler must be mounted behind the admin authorization, e.g. RolePermissionHandler
+func RevokeSubjectHandler(revoker iam.Revoker) lit.HandlerFunc {
+	return func(c lit.Context) error {
+		var req revokeSubjectRequest
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+
+		if err := revoker.RevokeSubject(c.Request().Context(), req.Subject); err != nil {
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
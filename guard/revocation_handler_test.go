@@ -0,0 +1,144 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit"
+	"github.com/viebiz/lit/iam"
+)
+
+func TestRevokeTokenHandler(t *testing.T) {
+	type mockStore struct {
+		expCall bool
+		jti     string
+		outErr  error
+	}
+	tcs := map[string]struct {
+		givenBody        string
+		givenZeroRevoker bool
+		mockStore        mockStore
+		expStatus        int
+		expErr           bool
+	}{
+		"success": {
+			givenBody: `{"jti":"GalacticPhantom","exp":4102444800}`,
+			mockStore: mockStore{expCall: true, jti: "GalacticPhantom"},
+			expStatus: http.StatusNoContent,
+		},
+		"success - without exp": {
+			givenBody: `{"jti":"GalacticPhantom"}`,
+			mockStore: mockStore{expCall: true, jti: "GalacticPhantom"},
+			expStatus: http.StatusNoContent,
+		},
+		"error - missing jti": {
+			givenBody: `{"exp":4102444800}`,
+			expErr:    true,
+		},
+		"error - store error": {
+			givenBody: `{"jti":"GalacticPhantom"}`,
+			mockStore: mockStore{expCall: true, jti: "GalacticPhantom", outErr: errors.New("simulated error")},
+			expErr:    true,
+		},
+		"error - revoker without max token lifetime": {
+			givenBody:        `{"jti":"GalacticPhantom"}`,
+			givenZeroRevoker: true,
+			expErr:           true,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			_, ctx, _ := lit.NewRouterForTest(httptest.NewRecorder())
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/admin/revocations/tokens", strings.NewReader(tc.givenBody))
+			req.Header.Set("Content-Type", "application/json")
+			ctx.SetRequest(req)
+
+			store := iam.NewMockRevocationStore(t)
+			if tc.mockStore.expCall {
+				store.EXPECT().RevokeToken(mock.Anything, tc.mockStore.jti, mock.Anything).Return(tc.mockStore.outErr)
+			}
+
+			revoker, err := iam.NewRevoker(store, time.Hour)
+			require.NoError(t, err)
+			if tc.givenZeroRevoker {
+				revoker = iam.Revoker{}
+			}
+
+			// When
+			err = RevokeTokenHandler(revoker)(ctx)
+
+			// Then
+			if tc.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expStatus, ctx.Writer().Status())
+			}
+		})
+	}
+}
+
+func TestRevokeSubjectHandler(t *testing.T) {
+	tcs := map[string]struct {
+		givenBody string
+		expSub    string
+		expErr    bool
+	}{
+		"success": {
+			givenBody: `{"sub":"imperium|space_marine"}`,
+			expSub:    "imperium|space_marine",
+		},
+		"error - missing sub": {
+			givenBody: `{}`,
+			expErr:    true,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			_, ctx, _ := lit.NewRouterForTest(httptest.NewRecorder())
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/admin/revocations/subjects", strings.NewReader(tc.givenBody))
+			req.Header.Set("Content-Type", "application/json")
+			ctx.SetRequest(req)
+
+			store := iam.NewMemoryRevocationStore()
+
+			revoker, err := iam.NewRevoker(store, time.Hour)
+			require.NoError(t, err)
+
+			// When
+			err = RevokeSubjectHandler(revoker)(ctx)
+
+			// Then
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, http.StatusNoContent, ctx.Writer().Status())
+			before, err := store.SubjectRevokedBefore(context.Background(), tc.expSub)
+			require.NoError(t, err)
+			require.False(t, before.IsZero())
+		})
+	}
+}
@@ -18,15 +18,8 @@ func (guard AuthGuard) RolePermissionHandler(handler lit.HandlerFunc, resource s
 			return errForbidden
 		}
 
-		// TODO: Multiple role not supported yet, use the first role
-		var r string
-		for _, role := range profile.GetRoles() {
-			r = role
-			break
-		}
-
 		// 2. Check if profile permitted to do this action
-		if err := guard.enforcer.Enforce(r, resource, permissions.String()); err != nil {
+		if err := guard.enforceRole(profile, resource, permissions); err != nil {
 			if errors.Is(err, iam.ErrActionIsNotAllowed) {
 				return errForbidden
 			}
@@ -37,3 +30,18 @@ func (guard AuthGuard) RolePermissionHandler(handler lit.HandlerFunc, resource s
 		return handler(c)
 	}
 }
+
+func (guard AuthGuard) enforceRole(profile iam.UserProfile, resource string, action Action) error {
+	if guard.enforcer == nil {
+		return errMissingEnforcer
+	}
+
+	// TODO: Multiple role not supported yet, use the first role
+	var r string
+	for _, role := range profile.GetRoles() {
+		r = role
+		break
+	}
+
+	return guard.enforcer.Enforce(r, resource, action.String())
+}
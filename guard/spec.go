@@ -7,4 +7,7 @@ import (
 type AuthGuard struct {
 	validator iam.Validator
 	enforcer  iam.Enforcer
+
+	// claimMapping configures the claims the profiles are extracted from
+	claimMapping iam.ClaimMapping
 }
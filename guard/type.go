@@ -1,5 +1,9 @@
 package guard
 
+import (
+	"strings"
+)
+
 type Action string
 
 const (
@@ -16,3 +20,39 @@ func (a Action) String() string {
 func (a Action) IsValid() bool {
 	return a == ActionRead || a == ActionCreate || a == ActionUpdate || a == ActionDelete
 }
+
+// GRPCMethodRule describes how a gRPC method is authenticated and authorized
+type GRPCMethodRule struct {
+	// Public skips the authentication
+	Public bool
+
+	// M2M authenticates the caller as a machine-to-machine client instead of a user
+	M2M bool
+
+	// Scopes requires the M2M client to have any of the scopes, they are only allowed on the M2M rules
+	Scopes []string
+
+	// Resource and Action are enforced against the user role by the Casbin enforcer, the guard must have one
+	Resource string
+	Action   Action
+}
+
+// GRPCMethodRules maps the full method name, e.g. "/orders.v1.OrderService/GetOrder", to its rule.
+// A service wide rule is declared with "/orders.v1.OrderService/*" and the fallback rule with "*".
+// Methods without any matching rule are denied
+type GRPCMethodRules map[string]GRPCMethodRule
+
+func (rules GRPCMethodRules) lookup(fullMethod string) (GRPCMethodRule, bool) {
+	if rule, ok := rules[fullMethod]; ok {
+		return rule, true
+	}
+
+	if idx := strings.LastIndex(fullMethod, "/"); idx > 0 {
+		if rule, ok := rules[fullMethod[:idx+1]+"*"]; ok {
+			return rule, true
+		}
+	}
+
+	rule, ok := rules["*"]
+	return rule, ok
+}
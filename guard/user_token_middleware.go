@@ -31,7 +31,7 @@ func (guard AuthGuard) AuthenticateUserMiddleware() lit.HandlerFunc {
 		}
 
 		// 3. Extract user profile from token claims
-		profile, err := iam.ExtractUserProfileFromClaims(tk.Claims)
+		profile, err := guard.claimMapping.ExtractUserProfile(tk.Claims)
 		if err != nil {
 			return convertError(err)
 		}
@@ -39,6 +39,7 @@ func (guard AuthGuard) AuthenticateUserMiddleware() lit.HandlerFunc {
 		// 4. Inject user information to request context
 		ctx := c.Request().Context()
 		ctx = iam.SetUserProfileInContext(ctx, profile)
+		ctx = iam.SetAccessTokenInContext(ctx, tokenStr)
 		ctx = monitoring.InjectFields(ctx, map[string]string{
 			userIDKey: profile.ID(),
 			roleKey:   profile.GetRoleString(),
@@ -53,7 +54,11 @@ func (guard AuthGuard) AuthenticateUserMiddleware() lit.HandlerFunc {
 }
 
 func getTokenString(r *http.Request) string {
-	authHeaderParts := strings.Split(r.Header.Get(headerAuthorization), " ")
+	return parseBearerToken(r.Header.Get(headerAuthorization))
+}
+
+func parseBearerToken(authHeader string) string {
+	authHeaderParts := strings.Split(authHeader, " ")
 	if len(authHeaderParts) != 2 || authHeaderParts[0] != authorizationBearerPrefix {
 		return ""
 	}
@@ -65,6 +70,7 @@ func convertError(err error) error {
 	switch err.Error() {
 	case iam.ErrMissingRequiredClaim.Error(),
 		iam.ErrTokenExpired.Error(),
+		iam.ErrTokenRevoked.Error(),
 		iam.ErrInvalidToken.Error():
 		return unauthorizedErr(err)
 	case iam.ErrActionIsNotAllowed.Error():
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	pkgerrors "github.com/pkg/errors"
+
+	"github.com/viebiz/lit/circuitbreaker"
 	"github.com/viebiz/lit/monitoring"
 )
 
@@ -39,6 +41,10 @@ type Client struct {
 
 	timeoutAndRetryOption timeoutAndRetryOption
 
+	// Circuit breaker guarding each attempt
+	// Default: nil
+	circuitBreaker *circuitbreaker.CircuitBreaker
+
 	// Disable request body logging
 	// Default: false,
 	disableReqBodyLogging bool
@@ -2,6 +2,8 @@ package httpclient
 
 import (
 	"strings"
+
+	"github.com/viebiz/lit/circuitbreaker"
 )
 
 // ClientOption alters behaviour of the Client
@@ -46,3 +48,12 @@ func DisableResponseBodyLogging() ClientOption {
 		c.disableRespBodyLogging = true
 	}
 }
+
+// WithCircuitBreaker method guards each attempt with the circuit breaker, the transport errors and 5xx responses are
+// counted as failures. The attempts are not retried while it's open. The circuit breaker can be shared by the clients
+// of the same downstream
+func WithCircuitBreaker(cb *circuitbreaker.CircuitBreaker) ClientOption {
+	return func(c *Client) {
+		c.circuitBreaker = cb
+	}
+}
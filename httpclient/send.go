@@ -108,6 +108,11 @@ func (c *Client) execute(
 			defer func() { segEnd(status, err) }()
 			monitor := monitoring.FromContext(ctx)
 
+			cbDone, err := c.allowAttempt(reqCtx)
+			if err != nil {
+				return backoff.Permanent(err) // stop retry by returning backoff.Permanent error
+			}
+
 			reqCtx, cancelReqCtx := context.WithTimeout(reqCtx, c.timeoutAndRetryOption.maxWaitPerTry)
 			defer cancelReqCtx()
 			req = req.WithContext(reqCtx) // limit each HTTP request timeout option for per try
@@ -117,6 +122,7 @@ func (c *Client) execute(
 			// start sending request
 			start := time.Now()
 			resp, err := c.underlyingClient.Do(req)
+			cbDone(isFailedAttempt(resp, err))
 
 			monitor = monitor.
 				WithTag("duration", fmt.Sprintf("%dms", time.Since(start).Milliseconds()))
@@ -196,6 +202,24 @@ func (c *Client) execute(
 	return resultResp, nil
 }
 
+// allowAttempt checks the attempt against the circuit breaker, the returned func records the attempt result
+func (c *Client) allowAttempt(ctx context.Context) (func(failed bool), error) {
+	if c.circuitBreaker == nil {
+		return func(bool) {}, nil
+	}
+
+	return c.circuitBreaker.Allow(ctx)
+}
+
+// isFailedAttempt tells whether the attempt counts as a failure of the downstream, the canceled calls are not
+func isFailedAttempt(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
 // constructURL returns the full URL with query params and path variables substitution
 func (c *Client) constructURL(p Payload) string {
 	u := c.url
@@ -7,9 +7,12 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit/circuitbreaker"
 )
 
 func TestClient_Send(t *testing.T) {
@@ -128,3 +131,62 @@ func BenchmarkClient_Send(b *testing.B) {
 		}
 	})
 }
+
+func TestClient_Send_WithCircuitBreaker(t *testing.T) {
+	tcs := map[string]struct {
+		givenStatus int
+		expCalls    int32
+		expOpen     bool
+	}{
+		"opens on 5xx responses": {
+			givenStatus: http.StatusBadGateway,
+			expCalls:    2,
+			expOpen:     true,
+		},
+		"closed on 4xx responses": {
+			givenStatus: http.StatusNotFound,
+			expCalls:    3,
+		},
+	}
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			var calls int32
+			mockSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tc.givenStatus)
+			}))
+			t.Cleanup(mockSvr.Close)
+
+			cb, err := circuitbreaker.New("svc", circuitbreaker.WithMinimumCalls(2))
+			require.NoError(t, err)
+
+			c, err := NewUnauthenticated(
+				Config{URL: mockSvr.URL, Method: http.MethodGet, ServiceName: "svc"},
+				NewSharedCustomPool(),
+				WithCircuitBreaker(cb),
+			)
+			require.NoError(t, err)
+
+			// When
+			var lastErr error
+			for range 3 {
+				_, lastErr = c.Send(context.Background(), Payload{})
+			}
+
+			// Then
+			require.Equal(t, tc.expCalls, atomic.LoadInt32(&calls))
+			if !tc.expOpen {
+				require.NoError(t, lastErr)
+				return
+			}
+
+			var openErr circuitbreaker.OpenError
+			require.ErrorAs(t, lastErr, &openErr)
+			require.Equal(t, "svc", openErr.Name)
+		})
+	}
+}
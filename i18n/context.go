@@ -17,3 +17,12 @@ func FromContext(ctx context.Context) Localizable {
 func SetInContext(parentCtx context.Context, lc Localizable) context.Context {
 	return context.WithValue(parentCtx, contextKey{}, lc)
 }
+
+// LanguageFromContext returns the language key of the localizer in context, empty if it's not set
+func LanguageFromContext(ctx context.Context) string {
+	if l, ok := FromContext(ctx).(interface{ Language() string }); ok {
+		return l.Language()
+	}
+
+	return ""
+}
@@ -32,3 +32,10 @@ func TestFromContext_Noop(t *testing.T) {
 	require.NotNil(t, retrieved)
 	require.Equal(t, retrieved, localizer{})
 }
+
+func TestLanguageFromContext(t *testing.T) {
+	require.Equal(t, "", LanguageFromContext(context.Background()))
+
+	ctx := SetInContext(context.Background(), &localizer{lang: "vi"})
+	require.Equal(t, "vi", LanguageFromContext(ctx))
+}
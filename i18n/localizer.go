@@ -6,17 +6,24 @@ import (
 )
 
 type localizer struct {
+	lang                string
 	underlyingLocalizer *i18n.Localizer
 	getLocalizedMessage func(localizer *i18n.Localizer, cfg *i18n.LocalizeConfig) (string, error)
 }
 
 func newLocalizer(langKey string, bundle *i18n.Bundle) Localizable {
 	return &localizer{
+		lang:                langKey,
 		underlyingLocalizer: i18n.NewLocalizer(bundle, langKey),
 		getLocalizedMessage: getLocalizedMessage,
 	}
 }
 
+// Language returns the language key of the localizer, empty for the noop localizer
+func (l localizer) Language() string {
+	return l.lang
+}
+
 func (l localizer) Localize(messageID string, params map[string]interface{}) string {
 	msg, err := l.TryLocalize(messageID, params)
 	if err != nil {
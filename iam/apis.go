@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
 
 	pkgerrors "github.com/pkg/errors"
 
+	"github.com/viebiz/lit/caching/redis"
 	"github.com/viebiz/lit/jwt"
 	"github.com/viebiz/lit/monitoring"
 )
@@ -55,39 +57,129 @@ func NewM2MProfile(id string, scopes []string) M2MProfile {
 	}
 }
 
+// ExtractM2MProfileFromClaims extracts the M2M profile from the default claims, see ClaimMapping to read other claims
 func ExtractM2MProfileFromClaims(claims Claims) (M2MProfile, error) {
-	sub := claims.RegisteredClaims.Subject
+	return ClaimMapping{}.ExtractM2MProfile(claims)
+}
+
+// NewRFC9068Validator returns the RFC 9068 validator of the issuer, the signing keys are downloaded from
+// `{issuer}/.well-known/jwks.json` and refreshed in background until the ctx is done
+func NewRFC9068Validator(ctx context.Context, issuer, audience string, client HTTPClient, opts ...JWKSOption) (Validator, error) {
+	jwksURI := fmt.Sprintf("%s/.well-known/jwks.json", strings.TrimSuffix(issuer, "/"))
 
-	scopeSet, err := extractScopeFromClaims(claims)
+	keySet, err := NewJWKSCache(ctx, jwksURI, client, opts...)
 	if err != nil {
-		return M2MProfile{}, err
+		return nil, err
 	}
 
-	return M2MProfile{
-		id:     sub,
-		scopes: scopeSet,
+	return &rfc9068Validator{
+		issuer:      issuer,
+		audience:    audience,
+		keySet:      keySet,
+		tokenParser: jwt.NewParser[Claims](rfc9068ParserOptions(issuer, audience)...),
 	}, nil
 }
 
-func NewRFC9068Validator(issuer, audience string, client HTTPClient) (Validator, error) {
-	jwksURI := fmt.Sprintf("%s/.well-known/jwks.json", strings.TrimSuffix(issuer, "/"))
-	v := rfc9068Validator{
-		jwksURI:     jwksURI,
+// NewOIDCValidator returns the RFC 9068 validator of the issuer, the JWKS URI is discovered from the OpenID Provider
+// metadata. The signing keys are refreshed in background until the ctx is done.
+//
+// The `id_token_signing_alg_values_supported` of the metadata applies to the ID tokens only, so the access tokens are
+// not restricted to it and every algorithm supported by the parser is accepted, the same as NewRFC9068Validator
+func NewOIDCValidator(ctx context.Context, issuer, audience string, client HTTPClient, opts ...JWKSOption) (Validator, error) {
+	metadata, err := DiscoverProvider(ctx, issuer, client)
+	if err != nil {
+		return nil, err
+	}
+
+	keySet, err := NewJWKSCache(ctx, metadata.JWKSURI, client, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rfc9068Validator{
 		issuer:      issuer,
 		audience:    audience,
-		httpClient:  client,
-		tokenParser: jwt.NewParser[Claims](),
+		keySet:      keySet,
+		tokenParser: jwt.NewParser[Claims](rfc9068ParserOptions(issuer, audience)...),
+	}, nil
+}
+
+// NewMultiIssuerValidator returns the validator accepting the tokens of the trusted issuers, each token is validated by
+// the validator of its unverified `iss` claim then its claims are mapped by the MapClaims of the issuer
+func NewMultiIssuerValidator(issuers ...TrustedIssuer) (Validator, error) {
+	v := multiIssuerValidator{
+		issuers: make(map[string]TrustedIssuer, len(issuers)),
 	}
 
-	// Download signing key
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+	for _, iss := range issuers {
+		if iss.Issuer == "" || iss.Validator == nil {
+			return nil, ErrInvalidTrustedIssuer
+		}
 
-	if err := v.downloadSigningKey(ctx); err != nil {
-		return nil, err
+		key := strings.TrimSuffix(iss.Issuer, "/")
+		if _, exists := v.issuers[key]; exists {
+			return nil, fmt.Errorf("%w: duplicated issuer %q", ErrInvalidTrustedIssuer, iss.Issuer)
+		}
+
+		v.issuers[key] = iss
+	}
+
+	return v, nil
+}
+
+// NewIntrospectionValidator returns the validator of the opaque access tokens by the OAuth 2.0 token introspection
+// endpoint of the authorization server, the introspection response is mapped to the claims
+func NewIntrospectionValidator(cfg IntrospectionConfig, client HTTPClient) (Validator, error) {
+	if cfg.Endpoint == "" {
+		return nil, ErrMissingIntrospectionEndpoint
+	}
+
+	return introspectionValidator{
+		cfg:    prepareIntrospectionConfig(cfg),
+		client: client,
+	}, nil
+}
+
+// NewRevocationValidator returns the validator rejecting the tokens of the next validator that are revoked in the store
+func NewRevocationValidator(next Validator, store RevocationStore) Validator {
+	return revocationValidator{
+		next:  next,
+		store: store,
+	}
+}
+
+// NewRevoker returns the Revoker of the store, maxTokenLifetime is the longest lifetime of the tokens issued to a subject.
+// It must be positive, the revocation entries expire after it
+func NewRevoker(store RevocationStore, maxTokenLifetime time.Duration) (Revoker, error) {
+	if maxTokenLifetime <= 0 {
+		return Revoker{}, ErrInvalidTokenLifetime
+	}
+
+	return Revoker{
+		store:            store,
+		maxTokenLifetime: maxTokenLifetime,
+	}, nil
+}
+
+// NewMemoryRevocationStore creates an in-memory RevocationStore, it's intended for testing and local development
+func NewMemoryRevocationStore() RevocationStore {
+	return &memoryRevocationStore{
+		tokens:   make(map[string]time.Time),
+		subjects: make(map[string]memoryRevocationEntry),
+	}
+}
+
+// NewRedisRevocationStore creates a RevocationStore backed by Redis, keys are prefixed by keyPrefix
+// Default key prefix: iam:revoked:
+func NewRedisRevocationStore(client redis.Client, keyPrefix string) RevocationStore {
+	if keyPrefix == "" {
+		keyPrefix = defaultRevocationKeyPrefix
 	}
 
-	return &v, nil
+	return redisRevocationStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+	}
 }
 
 func NewUserProfile(id string, roles []string, permissions []string) UserProfile {
@@ -98,17 +190,7 @@ func NewUserProfile(id string, roles []string, permissions []string) UserProfile
 	}
 }
 
+// ExtractUserProfileFromClaims extracts the user profile from the default claims, see ClaimMapping to read other claims
 func ExtractUserProfileFromClaims(claims Claims) (UserProfile, error) {
-	sub := claims.RegisteredClaims.Subject
-
-	roles, err := extractRolesFromClaims(claims)
-	if err != nil {
-		return UserProfile{}, err
-	}
-
-	return UserProfile{
-		id:      sub,
-		roles:   roles,
-		profile: extractUserProfileFromClaims(claims),
-	}, nil
+	return ClaimMapping{}.ExtractUserProfile(claims)
 }
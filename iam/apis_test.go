@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -156,6 +157,20 @@ func TestExtractUserProfileFromClaims(t *testing.T) {
 				roles: []string{"admin", "user"},
 			},
 		},
+		"with permissions": {
+			claims: Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "u1"},
+				ExtraClaims: map[string]interface{}{
+					"https://lightning.app/roles":       []string{"admin"},
+					"https://lightning.app/permissions": []interface{}{"orders:read", "orders:write"},
+				},
+			},
+			expResult: UserProfile{
+				id:          "u1",
+				roles:       []string{"admin"},
+				permissions: []string{"orders:read", "orders:write"},
+			},
+		},
 		"no scopes": {
 			claims: Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "x"}},
 			expErr: ErrMissingRequiredClaim,
@@ -209,7 +224,7 @@ func TestNewRFC9068Validator_Error(t *testing.T) {
 			cli := tc.client
 
 			// WHEN
-			v, err := NewRFC9068Validator(iss, aud, cli)
+			v, err := NewRFC9068Validator(context.Background(), iss, aud, cli)
 
 			// THEN
 			require.Nil(t, v)
@@ -218,6 +233,41 @@ func TestNewRFC9068Validator_Error(t *testing.T) {
 	}
 }
 
+func TestNewRevoker(t *testing.T) {
+	tcs := map[string]struct {
+		givenLifetime time.Duration
+		expErr        error
+	}{
+		"success": {
+			givenLifetime: time.Hour,
+		},
+		"error - zero lifetime": {
+			expErr: ErrInvalidTokenLifetime,
+		},
+		"error - negative lifetime": {
+			givenLifetime: -time.Hour,
+			expErr:        ErrInvalidTokenLifetime,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// When
+			_, err := NewRevoker(NewMemoryRevocationStore(), tc.givenLifetime)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestNewEnforcer(t *testing.T) {
 	tests := map[string]struct {
 		cfg     EnforcerConfig
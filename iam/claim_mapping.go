@@ -0,0 +1,190 @@
+package iam
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ClaimMapping configures the claims the user and M2M profiles are extracted from, the zero value reads the default claims.
+//
+// A claim path walks the nested objects by ".", e.g. "realm_access.roles", a claim named with dots such as
+// "https://lightning.app/roles" is matched as is first. A claim value is either an array or a string of values,
+// the roles and permissions are separated by commas, e.g. "Super Admin,Owner", and the scopes by spaces.
+type ClaimMapping struct {
+	// RoleClaims are the paths of the role claims, the values of all the present claims are merged
+	// Default: https://lightning.app/roles
+	RoleClaims []string
+
+	// PermissionClaims are the paths of the permission claims, the values of all the present claims are merged
+	// Default: https://lightning.app/permissions
+	PermissionClaims []string
+
+	// ScopeClaims are the paths of the scope claims, e.g. "scp", the values of all the present claims are merged
+	// Default: scope
+	ScopeClaims []string
+
+	// ProfileClaim is the path of the user profile object
+	// Default: https://lightning.app/profile
+	ProfileClaim string
+
+	// StripPrefixes are removed from the roles, permissions and scopes, e.g. "ROLE_" or the "/" of the group paths
+	StripPrefixes []string
+}
+
+// ExtractUserProfile extracts the user profile from the claims, the roles are required
+func (m ClaimMapping) ExtractUserProfile(claims Claims) (UserProfile, error) {
+	m = m.withDefaults()
+
+	roles, err := extractClaimValues(claims, m.RoleClaims, m.StripPrefixes, splitCommaValues)
+	if err != nil {
+		return UserProfile{}, err
+	}
+
+	permissions, err := extractClaimValues(claims, m.PermissionClaims, m.StripPrefixes, splitCommaValues)
+	if err != nil && !errors.Is(err, ErrMissingRequiredClaim) {
+		return UserProfile{}, err
+	}
+
+	profile, _ := lookupClaim(claims.ExtraClaims, m.ProfileClaim)
+	pfMap, _ := profile.(map[string]any)
+
+	return UserProfile{
+		id:          claims.RegisteredClaims.Subject,
+		roles:       roles,
+		permissions: permissions,
+		profile:     pfMap,
+	}, nil
+}
+
+// ExtractM2MProfile extracts the M2M profile from the claims, the scopes are required
+func (m ClaimMapping) ExtractM2MProfile(claims Claims) (M2MProfile, error) {
+	m = m.withDefaults()
+
+	scopes, err := extractClaimValues(claims, m.ScopeClaims, m.StripPrefixes, strings.Fields)
+	if err != nil {
+		return M2MProfile{}, err
+	}
+
+	return NewM2MProfile(claims.RegisteredClaims.Subject, scopes), nil
+}
+
+func (m ClaimMapping) withDefaults() ClaimMapping {
+	if len(m.RoleClaims) == 0 {
+		m.RoleClaims = []string{roleClaimKey}
+	}
+
+	if len(m.PermissionClaims) == 0 {
+		m.PermissionClaims = []string{permissionClaimKey}
+	}
+
+	if len(m.ScopeClaims) == 0 {
+		m.ScopeClaims = []string{scopeClaimKey}
+	}
+
+	if m.ProfileClaim == "" {
+		m.ProfileClaim = profileClaimKey
+	}
+
+	return m
+}
+
+// extractClaimValues returns the merged values of the claims at the paths without duplicates, a string claim is split by split.
+// It returns ErrMissingRequiredClaim if none of the claims is present and ErrInvalidToken if a claim is not a string or an array
+func extractClaimValues(claims Claims, paths []string, stripPrefixes []string, split func(string) []string) ([]string, error) {
+	var rs []string
+	found := false
+	for _, path := range paths {
+		v, exists := lookupClaim(claims.ExtraClaims, path)
+		if !exists {
+			continue
+		}
+		found = true
+
+		values, err := claimValues(v, split)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, value := range values {
+			value = stripPrefix(value, stripPrefixes)
+			if value != "" && !slices.Contains(rs, value) {
+				rs = append(rs, value)
+			}
+		}
+	}
+
+	if !found {
+		return nil, ErrMissingRequiredClaim
+	}
+
+	return rs, nil
+}
+
+// lookupClaim returns the claim at the path, the objects are walked by "." when the claim is not named as is
+func lookupClaim(claims map[string]interface{}, path string) (interface{}, bool) {
+	if v, exists := claims[path]; exists {
+		return v, true
+	}
+
+	for idx := 0; idx < len(path); idx++ {
+		if path[idx] != '.' {
+			continue
+		}
+
+		nested, ok := claims[path[:idx]].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if v, exists := lookupClaim(nested, path[idx+1:]); exists {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+func claimValues(v interface{}, split func(string) []string) ([]string, error) {
+	switch value := v.(type) {
+	case string:
+		return split(value), nil
+	case []string:
+		return value, nil
+	case []interface{}:
+		rs := make([]string, len(value))
+		for idx, item := range value {
+			s, ok := item.(string)
+			if !ok {
+				s = fmt.Sprintf("%v", item)
+			}
+
+			rs[idx] = s
+		}
+
+		return rs, nil
+	default:
+		return nil, ErrInvalidToken
+	}
+}
+
+// splitCommaValues splits the comma separated values, the values keep their inner spaces, e.g. "Super Admin"
+func splitCommaValues(value string) []string {
+	rs := strings.Split(value, ",")
+	for idx := range rs {
+		rs[idx] = strings.TrimSpace(rs[idx])
+	}
+
+	return rs
+}
+
+func stripPrefix(value string, prefixes []string) string {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return strings.TrimPrefix(value, prefix)
+		}
+	}
+
+	return value
+}
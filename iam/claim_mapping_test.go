@@ -0,0 +1,188 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit/jwt"
+)
+
+func TestClaimMapping_ExtractUserProfile(t *testing.T) {
+	keycloakClaims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "u1"},
+		ExtraClaims: map[string]interface{}{
+			"realm_access": map[string]interface{}{
+				"roles": []interface{}{"ROLE_admin", "ROLE_user"},
+			},
+			"groups":      []interface{}{"/admin", "/ops"},
+			"permissions": "orders:read, orders:write",
+			"profile":     map[string]any{"name": "Juso"},
+		},
+	}
+
+	tcs := map[string]struct {
+		givenMapping ClaimMapping
+		givenClaims  Claims
+		expResult    UserProfile
+		expErr       error
+	}{
+		"default claims": {
+			givenClaims: Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "u1"},
+				ExtraClaims: map[string]interface{}{
+					"https://lightning.app/roles":       []interface{}{"admin"},
+					"https://lightning.app/permissions": []interface{}{"orders:read"},
+					"https://lightning.app/profile":     map[string]any{"name": "Juso"},
+				},
+			},
+			expResult: UserProfile{
+				id:          "u1",
+				roles:       []string{"admin"},
+				permissions: []string{"orders:read"},
+				profile:     map[string]any{"name": "Juso"},
+			},
+		},
+		"nested paths, merged claims and stripped prefixes": {
+			givenMapping: ClaimMapping{
+				RoleClaims:       []string{"realm_access.roles", "groups"},
+				PermissionClaims: []string{"permissions"},
+				ProfileClaim:     "profile",
+				StripPrefixes:    []string{"ROLE_", "/"},
+			},
+			givenClaims: keycloakClaims,
+			expResult: UserProfile{
+				id:          "u1",
+				roles:       []string{"admin", "user", "ops"},
+				permissions: []string{"orders:read", "orders:write"},
+				profile:     map[string]any{"name": "Juso"},
+			},
+		},
+		"comma separated roles with spaces": {
+			givenClaims: Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "u1"},
+				ExtraClaims: map[string]interface{}{
+					"https://lightning.app/roles":       "Super Admin, Owner",
+					"https://lightning.app/permissions": "orders:read",
+				},
+			},
+			expResult: UserProfile{
+				id:          "u1",
+				roles:       []string{"Super Admin", "Owner"},
+				permissions: []string{"orders:read"},
+			},
+		},
+		"dotted claim name inside nested object": {
+			givenMapping: ClaimMapping{RoleClaims: []string{"resource_access.orders.example.com.roles"}},
+			givenClaims: Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "u1"},
+				ExtraClaims: map[string]interface{}{
+					"resource_access": map[string]interface{}{
+						"orders.example.com": map[string]interface{}{"roles": []string{"viewer"}},
+					},
+				},
+			},
+			expResult: UserProfile{id: "u1", roles: []string{"viewer"}},
+		},
+		"missing permissions are optional": {
+			givenMapping: ClaimMapping{RoleClaims: []string{"groups"}, PermissionClaims: []string{"missing"}},
+			givenClaims:  keycloakClaims,
+			expResult:    UserProfile{id: "u1", roles: []string{"/admin", "/ops"}},
+		},
+		"error - missing roles": {
+			givenMapping: ClaimMapping{RoleClaims: []string{"realm_access.missing", "missing"}},
+			givenClaims:  keycloakClaims,
+			expErr:       ErrMissingRequiredClaim,
+		},
+		"error - roles wrong type": {
+			givenMapping: ClaimMapping{RoleClaims: []string{"realm_access"}},
+			givenClaims:  keycloakClaims,
+			expErr:       ErrInvalidToken,
+		},
+		"error - permissions wrong type": {
+			givenMapping: ClaimMapping{RoleClaims: []string{"groups"}, PermissionClaims: []string{"profile"}},
+			givenClaims:  keycloakClaims,
+			expErr:       ErrInvalidToken,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+
+			// When
+			rs, err := tc.givenMapping.ExtractUserProfile(tc.givenClaims)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, rs)
+			}
+		})
+	}
+}
+
+func TestClaimMapping_ExtractM2MProfile(t *testing.T) {
+	tcs := map[string]struct {
+		givenMapping ClaimMapping
+		givenClaims  Claims
+		expResult    M2MProfile
+		expErr       error
+	}{
+		"default scope string": {
+			givenClaims: Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "m1"},
+				ExtraClaims:      map[string]interface{}{"scope": "orders:read orders:write"},
+			},
+			expResult: NewM2MProfile("m1", []string{"orders:read", "orders:write"}),
+		},
+		"scp array with prefix": {
+			givenMapping: ClaimMapping{ScopeClaims: []string{"scp", "scope"}, StripPrefixes: []string{"api://orders/"}},
+			givenClaims: Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "m1"},
+				ExtraClaims:      map[string]interface{}{"scp": []interface{}{"api://orders/orders:read", "orders:sync"}},
+			},
+			expResult: NewM2MProfile("m1", []string{"orders:read", "orders:sync"}),
+		},
+		"error - missing scopes": {
+			givenMapping: ClaimMapping{ScopeClaims: []string{"scp"}},
+			givenClaims: Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "m1"},
+				ExtraClaims:      map[string]interface{}{"scope": "orders:read"},
+			},
+			expErr: ErrMissingRequiredClaim,
+		},
+		"error - scopes wrong type": {
+			givenClaims: Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "m1"},
+				ExtraClaims:      map[string]interface{}{"scope": 42},
+			},
+			expErr: ErrInvalidToken,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+
+			// When
+			rs, err := tc.givenMapping.ExtractM2MProfile(tc.givenClaims)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, rs)
+			}
+		})
+	}
+}
@@ -17,6 +17,11 @@ func (c Claims) Valid() error {
 	return nil
 }
 
+// GetRegisteredClaims returns the registered claims for the jwt.Parser policy
+func (c Claims) GetRegisteredClaims() jwt.RegisteredClaims {
+	return c.RegisteredClaims
+}
+
 func (c Claims) MarshalJSON() ([]byte, error) {
 	// Initial claim map, that contains all claims in plain
 	claimMap := make(map[string]interface{})
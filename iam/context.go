@@ -8,6 +8,8 @@ type m2mProfileContextKey struct{}
 
 type userProfileContextKey struct{}
 
+type accessTokenContextKey struct{}
+
 func SetM2MProfileInContext(ctx context.Context, profile M2MProfile) context.Context {
 	return context.WithValue(ctx, m2mProfileContextKey{}, profile)
 }
@@ -31,3 +33,17 @@ func GetUserProfileFromContext(ctx context.Context) UserProfile {
 
 	return UserProfile{}
 }
+
+// SetAccessTokenInContext keeps the raw access token of the caller, so it can be forwarded to the downstream services
+func SetAccessTokenInContext(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, accessTokenContextKey{}, token)
+}
+
+// GetAccessTokenFromContext returns the raw access token of the caller or empty if the request is not authenticated
+func GetAccessTokenFromContext(ctx context.Context) string {
+	if tk, ok := ctx.Value(accessTokenContextKey{}).(string); ok {
+		return tk
+	}
+
+	return ""
+}
@@ -53,3 +53,14 @@ func TestSetAndGetUserProfileInContext(t *testing.T) {
 		t.Errorf("After Set/Get, got = %v; want %v", got, profile)
 	}
 }
+
+func TestSetAndGetAccessTokenInContext(t *testing.T) {
+	if got := GetAccessTokenFromContext(context.Background()); got != "" {
+		t.Errorf("GetAccessTokenFromContext without value = %q; want empty", got)
+	}
+
+	ctx := SetAccessTokenInContext(context.Background(), "access-token")
+	if got := GetAccessTokenFromContext(ctx); got != "access-token" {
+		t.Errorf("After Set/Get, got = %q; want %q", got, "access-token")
+	}
+}
@@ -12,4 +12,18 @@ var (
 	ErrTokenExpired = errors.New("token expired")
 
 	ErrActionIsNotAllowed = errors.New("action is not allowed")
+
+	ErrMissingJWKSURI = errors.New("missing JWKS URI")
+
+	ErrKeyNotFound = errors.New("signing key not found")
+
+	ErrInvalidProviderMetadata = errors.New("invalid provider metadata")
+
+	ErrInvalidTrustedIssuer = errors.New("invalid trusted issuer")
+
+	ErrMissingIntrospectionEndpoint = errors.New("missing introspection endpoint")
+
+	ErrTokenRevoked = errors.New("token revoked")
+
+	ErrInvalidTokenLifetime = errors.New("invalid max token lifetime")
 )
@@ -0,0 +1,217 @@
+package iam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/viebiz/lit/caching/redis"
+	"github.com/viebiz/lit/jwt"
+)
+
+const (
+	defaultIntrospectionCacheMaxTTL    = 5 * time.Minute
+	defaultIntrospectionCacheKeyPrefix = "iam:introspection:"
+
+	introspectionActiveKey = "active"
+)
+
+// IntrospectionConfig holds the configuration of the OAuth 2.0 token introspection validator
+type IntrospectionConfig struct {
+	// Endpoint is the introspection endpoint of the authorization server, e.g. ProviderMetadata.IntrospectionEndpoint
+	Endpoint string
+
+	// ClientID and ClientSecret authenticate the service to the authorization server by HTTP Basic
+	ClientID     string
+	ClientSecret string
+
+	// Issuer is the expected `iss` of the introspected tokens, skip checking if it's not provided
+	Issuer string
+
+	// Audience is the expected `aud` of the introspected tokens, skip checking if it's not provided
+	Audience string
+
+	// Cache keeps the active introspection responses until the token expires or the CacheMaxTTL passes,
+	// skip caching if it's not provided. The tokens are hashed in the cache keys
+	Cache redis.Client
+
+	// CacheMaxTTL bounds how long a revoked token is still accepted from the cache
+	// Default: 5m
+	CacheMaxTTL time.Duration
+
+	// CacheKeyPrefix is the prefix of the cache keys
+	// Default: iam:introspection:
+	CacheKeyPrefix string
+}
+
+// introspectionValidator represents validator for opaque access tokens by the introspection endpoint
+// Refer https://datatracker.ietf.org/doc/html/rfc7662
+type introspectionValidator struct {
+	cfg    IntrospectionConfig
+	client HTTPClient
+}
+
+// Validate introspects the token, the response is mapped to the claims
+func (v introspectionValidator) Validate(tokenString string) (jwt.Token[Claims], error) {
+	if tokenString == "" {
+		return jwt.Token[Claims]{}, ErrInvalidToken
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// 1. Get introspection response from cache or the authorization server
+	cacheKey := v.cacheKey(tokenString)
+	body, cached := v.getCachedResponse(ctx, cacheKey)
+	if !cached {
+		var err error
+		if body, err = v.introspect(ctx, tokenString); err != nil {
+			return jwt.Token[Claims]{}, err
+		}
+	}
+
+	// 2. Map response to claims and verify them
+	claims, err := parseIntrospectionResponse(body)
+	if err != nil {
+		return jwt.Token[Claims]{}, err
+	}
+
+	if err := v.verifyClaims(claims); err != nil {
+		return jwt.Token[Claims]{}, err
+	}
+
+	// 3. Cache the active response, a cache failure only costs another introspection
+	if !cached {
+		v.cacheResponse(ctx, cacheKey, body, claims)
+	}
+
+	return jwt.Token[Claims]{Claims: claims}, nil
+}
+
+func (v introspectionValidator) introspect(ctx context.Context, tokenString string) ([]byte, error) {
+	form := url.Values{
+		"token":           {tokenString},
+		"token_type_hint": {"access_token"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.cfg.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// The client credentials are form-encoded before HTTP Basic, refer RFC 6749 section 2.3.1
+	req.SetBasicAuth(url.QueryEscape(v.cfg.ClientID), url.QueryEscape(v.cfg.ClientSecret))
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got unexpected status code: %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// parseIntrospectionResponse maps the introspection response to the claims, inactive tokens are invalid.
+// The response members are the claims of the token, e.g. `scope`, `client_id` and `sub`
+func parseIntrospectionResponse(body []byte) (Claims, error) {
+	var claims Claims
+	if err := json.Unmarshal(body, &claims); err != nil {
+		return Claims{}, fmt.Errorf("could not decode introspection response: %w", err)
+	}
+
+	if active, _ := claims.ExtraClaims[introspectionActiveKey].(bool); !active {
+		return Claims{}, ErrInvalidToken
+	}
+
+	delete(claims.ExtraClaims, introspectionActiveKey)
+
+	return claims, nil
+}
+
+func (v introspectionValidator) verifyClaims(claims Claims) error {
+	now := timeNowFunc().Unix()
+	rc := claims.RegisteredClaims
+
+	if rc.ExpiresAt != nil && now >= *rc.ExpiresAt {
+		return ErrTokenExpired
+	}
+
+	if rc.NotBefore != nil && now < *rc.NotBefore {
+		return ErrInvalidToken
+	}
+
+	if v.cfg.Issuer != "" && strings.TrimSuffix(rc.Issuer, "/") != strings.TrimSuffix(v.cfg.Issuer, "/") {
+		return ErrInvalidToken
+	}
+
+	if v.cfg.Audience != "" && !slices.Contains(rc.Audience, v.cfg.Audience) {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+func (v introspectionValidator) getCachedResponse(ctx context.Context, key string) ([]byte, bool) {
+	if v.cfg.Cache == nil {
+		return nil, false
+	}
+
+	data, err := v.cfg.Cache.GetString(ctx, key)
+	// Redis client returns empty string if the key does not exist
+	if err != nil || data == "" {
+		return nil, false
+	}
+
+	return []byte(data), true
+}
+
+func (v introspectionValidator) cacheResponse(ctx context.Context, key string, body []byte, claims Claims) {
+	if v.cfg.Cache == nil {
+		return
+	}
+
+	ttl := v.cfg.CacheMaxTTL
+	if exp := claims.RegisteredClaims.ExpiresAt; exp != nil {
+		ttl = min(ttl, time.Unix(*exp, 0).Sub(timeNowFunc()))
+	}
+
+	if ttl <= 0 {
+		return
+	}
+
+	_ = v.cfg.Cache.SetString(ctx, key, string(body), ttl)
+}
+
+// cacheKey returns the cache key of the token, the token itself is never stored
+func (v introspectionValidator) cacheKey(tokenString string) string {
+	sum := sha256.Sum256([]byte(tokenString))
+
+	return v.cfg.CacheKeyPrefix + hex.EncodeToString(sum[:])
+}
+
+func prepareIntrospectionConfig(cfg IntrospectionConfig) IntrospectionConfig {
+	if cfg.CacheMaxTTL <= 0 {
+		cfg.CacheMaxTTL = defaultIntrospectionCacheMaxTTL
+	}
+
+	if cfg.CacheKeyPrefix == "" {
+		cfg.CacheKeyPrefix = defaultIntrospectionCacheKeyPrefix
+	}
+
+	return cfg
+}
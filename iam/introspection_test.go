@@ -0,0 +1,203 @@
+package iam
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit/caching/redis"
+	"github.com/viebiz/lit/jwt"
+)
+
+func TestIntrospectionValidator_Validate(t *testing.T) {
+	staticTimeNow := time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC)
+	const (
+		endpoint = "https://mukagen.com/oauth2/introspect"
+		cacheKey = "iam:introspection:84d3f23da9b5f51b3269566eff05d3fb23607eeef89567f9cd280b90ca0dbc5c"
+	)
+	activeResponse := `{"active":true,"iss":"https://mukagen.com/","sub":"juso-shi@clients","aud":"https://limitless.mukagen.com",` +
+		`"client_id":"juso-shi","scope":"skills:read skills:write","exp":1721782800,"iat":1721779200,"token_type":"Bearer"}`
+	activeClaims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "https://mukagen.com/",
+			Subject:   "juso-shi@clients",
+			Audience:  []string{"https://limitless.mukagen.com"},
+			IssuedAt:  pointerTo(staticTimeNow.Unix()),
+			ExpiresAt: pointerTo(staticTimeNow.Add(time.Hour).Unix()),
+			ClientID:  "juso-shi",
+		},
+		ExtraClaims: map[string]interface{}{
+			"scope":      "skills:read skills:write",
+			"token_type": "Bearer",
+		},
+	}
+
+	type mockHTTP struct {
+		expCall    bool
+		statusCode int
+		body       string
+		err        error
+	}
+	type mockCache struct {
+		getValue string
+		getErr   error
+		expSet   bool
+		setTTL   time.Duration
+	}
+	tcs := map[string]struct {
+		givenToken string
+		givenCfg   IntrospectionConfig
+		mockHTTP   mockHTTP
+		mockCache  *mockCache
+		expResult  Claims
+		expErr     error
+	}{
+		"success - without cache": {
+			givenToken: "opaque-token",
+			givenCfg:   IntrospectionConfig{Issuer: "https://mukagen.com", Audience: "https://limitless.mukagen.com"},
+			mockHTTP:   mockHTTP{expCall: true, statusCode: http.StatusOK, body: activeResponse},
+			expResult:  activeClaims,
+		},
+		"success - cached until max TTL": {
+			givenToken: "opaque-token",
+			mockHTTP:   mockHTTP{expCall: true, statusCode: http.StatusOK, body: activeResponse},
+			mockCache:  &mockCache{expSet: true, setTTL: 5 * time.Minute},
+			expResult:  activeClaims,
+		},
+		"success - cached until token expiry": {
+			givenToken: "opaque-token",
+			givenCfg:   IntrospectionConfig{CacheMaxTTL: 2 * time.Hour},
+			mockHTTP:   mockHTTP{expCall: true, statusCode: http.StatusOK, body: activeResponse},
+			mockCache:  &mockCache{expSet: true, setTTL: time.Hour},
+			expResult:  activeClaims,
+		},
+		"success - from cache": {
+			givenToken: "opaque-token",
+			mockCache:  &mockCache{getValue: activeResponse},
+			expResult:  activeClaims,
+		},
+		"success - cache failure falls back to introspection": {
+			givenToken: "opaque-token",
+			mockHTTP:   mockHTTP{expCall: true, statusCode: http.StatusOK, body: activeResponse},
+			mockCache:  &mockCache{getErr: errors.New("simulated error"), expSet: true, setTTL: 5 * time.Minute},
+			expResult:  activeClaims,
+		},
+		"error - missing token": {
+			expErr: ErrInvalidToken,
+		},
+		"error - inactive token": {
+			givenToken: "opaque-token",
+			mockHTTP:   mockHTTP{expCall: true, statusCode: http.StatusOK, body: `{"active":false}`},
+			mockCache:  &mockCache{},
+			expErr:     ErrInvalidToken,
+		},
+		"error - expired token": {
+			givenToken: "opaque-token",
+			mockHTTP:   mockHTTP{expCall: true, statusCode: http.StatusOK, body: `{"active":true,"exp":1721692800}`},
+			expErr:     ErrTokenExpired,
+		},
+		"error - token not valid yet": {
+			givenToken: "opaque-token",
+			mockHTTP:   mockHTTP{expCall: true, statusCode: http.StatusOK, body: `{"active":true,"nbf":1721782800}`},
+			expErr:     ErrInvalidToken,
+		},
+		"error - issuer not match": {
+			givenToken: "opaque-token",
+			givenCfg:   IntrospectionConfig{Issuer: "https://evil.com"},
+			mockHTTP:   mockHTTP{expCall: true, statusCode: http.StatusOK, body: activeResponse},
+			expErr:     ErrInvalidToken,
+		},
+		"error - audience not match": {
+			givenToken: "opaque-token",
+			givenCfg:   IntrospectionConfig{Audience: "https://other.mukagen.com"},
+			mockHTTP:   mockHTTP{expCall: true, statusCode: http.StatusOK, body: activeResponse},
+			expErr:     ErrInvalidToken,
+		},
+		"error - unexpected status code": {
+			givenToken: "opaque-token",
+			mockHTTP:   mockHTTP{expCall: true, statusCode: http.StatusUnauthorized},
+			expErr:     errors.New("got unexpected status code: 401"),
+		},
+		"error - invalid response": {
+			givenToken: "opaque-token",
+			mockHTTP:   mockHTTP{expCall: true, statusCode: http.StatusOK, body: `{`},
+			expErr:     errors.New("could not decode introspection response: unexpected end of JSON input"),
+		},
+		"error - http client error": {
+			givenToken: "opaque-token",
+			mockHTTP:   mockHTTP{expCall: true, err: errors.New("network fail")},
+			expErr:     errors.New("network fail"),
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			defer func(origin func() time.Time) { timeNowFunc = origin }(timeNowFunc)
+			timeNowFunc = func() time.Time { return staticTimeNow }
+
+			mockClient := new(mockHTTPClient)
+			if tc.mockHTTP.expCall {
+				mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+					id, secret, _ := req.BasicAuth()
+					require.NoError(t, req.ParseForm())
+
+					return req.Method == http.MethodPost && req.URL.String() == endpoint &&
+						id == "lit%3Aservice" && secret == "s3cret" &&
+						req.PostForm.Get("token") == tc.givenToken && req.PostForm.Get("token_type_hint") == "access_token"
+				})).Return(&http.Response{
+					StatusCode: tc.mockHTTP.statusCode,
+					Body:       io.NopCloser(strings.NewReader(tc.mockHTTP.body)),
+				}, tc.mockHTTP.err)
+			}
+
+			cfg := tc.givenCfg
+			cfg.Endpoint, cfg.ClientID, cfg.ClientSecret = endpoint, "lit:service", "s3cret"
+			if tc.mockCache != nil {
+				cache := redis.NewMockClient(t)
+				cache.EXPECT().GetString(mock.Anything, cacheKey).Return(tc.mockCache.getValue, tc.mockCache.getErr)
+				if tc.mockCache.expSet {
+					cache.EXPECT().SetString(mock.Anything, cacheKey, tc.mockHTTP.body, tc.mockCache.setTTL).Return(nil)
+				}
+				cfg.Cache = cache
+			}
+
+			validator, err := NewIntrospectionValidator(cfg, mockClient)
+			require.NoError(t, err)
+
+			// When
+			tk, err := validator.Validate(tc.givenToken)
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, tk.Claims)
+
+				// The introspected claims keep working with the profile extraction
+				profile, err := ExtractM2MProfileFromClaims(tk.Claims)
+				require.NoError(t, err)
+				require.True(t, profile.HasScope("skills:write"))
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewIntrospectionValidator(t *testing.T) {
+	// Given
+	cfg := IntrospectionConfig{}
+
+	// When
+	_, err := NewIntrospectionValidator(cfg, http.DefaultClient)
+
+	// Then
+	require.ErrorIs(t, err, ErrMissingIntrospectionEndpoint)
+}
@@ -1,20 +1,15 @@
 package iam
 
-// JWK represents a JSON Web Key
+import (
+	"github.com/viebiz/lit/jwt"
+)
+
+// JWK represents a JSON Web Key of the RSA, EC and OKP key types
 // Refer https://datatracker.ietf.org/doc/html/rfc7517
-type JWK struct {
-	KID string   `json:"kid"`
-	Kty string   `json:"kty"`
-	Use string   `json:"use"`
-	N   string   `json:"n"`
-	E   string   `json:"e"`
-	X5c []string `json:"x5c"`
-	X5t string   `json:"x5t"`
-	Alg string   `json:"alg"`
-}
+type JWK = jwt.JWK
 
 // JWKSet set of keys containing the public keys used to verify any JSON Web Token (JWT)
-// issued by the Authorization Server and signed using the RS256 signing algorithm
+// issued by the Authorization Server
 type JWKSet struct {
 	Keys []JWK `json:"keys"`
 }
@@ -0,0 +1,295 @@
+package iam
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/viebiz/lit/monitoring"
+)
+
+const (
+	meterName = "github.com/viebiz/lit/iam"
+
+	jwksRefreshFailedEventName = "iam.jwks.refresh_failed"
+)
+
+var (
+	jwksRefreshFailures, _ = otel.Meter(meterName).Int64Counter("iam.jwks.refresh.failures",
+		metric.WithDescription("Number of the JWKS refreshes that failed, the previous keys are kept"),
+		metric.WithUnit("{refresh}"),
+	)
+)
+
+// JWKSCache keeps the signing keys of a JWKS endpoint, refreshed in background as the Cache-Control of the
+// response says and on demand when a token is signed by an unknown key, e.g. after a key rotation.
+// The keys of the last successful fetch are kept when a refresh fails
+type JWKSCache struct {
+	uri    string
+	client HTTPClient
+
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+	maxRefreshInterval time.Duration
+	refetchInterval    time.Duration
+	now                func() time.Time
+
+	// monitor logs the refresh failures, the refreshes outlive the request contexts
+	monitor *monitoring.Monitor
+
+	fetchMu sync.Mutex // Only one fetch at a time
+
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	nextRefresh time.Time
+	lastFetch   time.Time
+	refetching  chan struct{} // Closed when the in-flight refetch of an unknown key is done
+}
+
+// NewJWKSCache fetches the keys of the JWKS endpoint and refreshes them in background until the ctx is done.
+// The refresh failures are logged by the monitor of the ctx. It fails if the first fetch fails
+func NewJWKSCache(ctx context.Context, uri string, client HTTPClient, opts ...JWKSOption) (*JWKSCache, error) {
+	if uri == "" {
+		return nil, ErrMissingJWKSURI
+	}
+
+	c := &JWKSCache{
+		uri:                uri,
+		client:             client,
+		refreshInterval:    time.Hour,
+		minRefreshInterval: 5 * time.Minute,
+		maxRefreshInterval: 24 * time.Hour,
+		refetchInterval:    30 * time.Second,
+		now:                time.Now,
+		monitor:            monitoring.FromContext(ctx),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if err := c.refresh(ctx, true); err != nil {
+		return nil, err
+	}
+
+	go c.run(ctx)
+
+	return c, nil
+}
+
+// Key returns the key with the given `kid`, the keys are refetched when it's unknown unless
+// they were fetched within the refetch interval. The concurrent callers wait for the in-flight refetch
+func (c *JWKSCache) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if key, ok := c.lookup(kid); ok {
+		return key, nil
+	}
+
+	c.refetch(ctx)
+
+	if key, ok := c.lookup(kid); ok {
+		return key, nil
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+func (c *JWKSCache) lookup(kid string) (crypto.PublicKey, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	key, ok := c.keys[kid]
+	return key, ok
+}
+
+// refetch fetches the keys for an unknown key unless they were fetched within the refetch interval,
+// or waits for the in-flight refetch of another caller
+func (c *JWKSCache) refetch(ctx context.Context) {
+	c.mu.Lock()
+	if done := c.refetching; done != nil {
+		c.mu.Unlock()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+		}
+		return
+	}
+
+	now := c.now()
+	if now.Sub(c.lastFetch) < c.refetchInterval {
+		c.mu.Unlock()
+		return
+	}
+
+	done := make(chan struct{})
+	c.lastFetch = now
+	c.refetching = done
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.refetching = nil
+		c.mu.Unlock()
+		close(done)
+	}()
+
+	// The error is recorded by refresh, the known keys are still served
+	_ = c.refresh(ctx, false)
+}
+
+func (c *JWKSCache) run(ctx context.Context) {
+	for {
+		c.mu.RLock()
+		wait := c.nextRefresh.Sub(c.now())
+		c.mu.RUnlock()
+
+		timer := time.NewTimer(max(wait, 0))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			_ = c.refresh(ctx, true)
+		}
+	}
+}
+
+// refresh fetches the keys and schedules the next background refresh. A failed scheduled refresh is retried
+// after the min refresh interval, a failed on demand fetch keeps the schedule of the last successful fetch
+func (c *JWKSCache) refresh(ctx context.Context, scheduled bool) error {
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+
+	fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	keys, maxAge, err := c.fetch(fetchCtx)
+	now := c.now()
+
+	c.mu.Lock()
+	c.lastFetch = now
+	if err != nil {
+		if scheduled {
+			c.nextRefresh = now.Add(c.minRefreshInterval)
+		}
+		staleKeys := len(c.keys)
+		c.mu.Unlock()
+
+		c.recordRefreshFailure(ctx, staleKeys, err)
+		return err
+	}
+
+	c.keys = keys
+	c.nextRefresh = now.Add(c.refreshAfter(maxAge))
+	c.mu.Unlock()
+
+	return nil
+}
+
+// refreshAfter returns the interval until the next refresh, the Cache-Control max-age bounded by the min and max interval
+func (c *JWKSCache) refreshAfter(maxAge time.Duration) time.Duration {
+	if maxAge < 0 {
+		return c.refreshInterval
+	}
+
+	return min(max(maxAge, c.minRefreshInterval), c.maxRefreshInterval)
+}
+
+func (c *JWKSCache) fetch(ctx context.Context) (map[string]crypto.PublicKey, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.uri, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("got unexpected status code: %d", resp.StatusCode)
+	}
+
+	var jwks JWKSet
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, 0, fmt.Errorf("could not decode jwks: %w", err)
+	}
+
+	keys, err := parseJWKS(jwks)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return keys, cacheMaxAge(resp.Header.Get("Cache-Control")), nil
+}
+
+// parseJWKS returns the signature keys of the set by `kid`, the keys of unsupported or invalid types are skipped
+func parseJWKS(jwks JWKSet) (map[string]crypto.PublicKey, error) {
+	keys := map[string]crypto.PublicKey{}
+
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != jwkKeyUseSig {
+			continue
+		}
+
+		if k.KID == "" {
+			continue
+		}
+
+		key, err := k.PublicKey()
+		if err != nil {
+			continue
+		}
+
+		keys[k.KID] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no appropriate JWK found")
+	}
+
+	return keys, nil
+}
+
+// cacheMaxAge returns the max-age directive of the Cache-Control header, no-cache and no-store count as 0.
+// It returns -1 when the header does not say
+func cacheMaxAge(cacheControl string) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-cache", "no-store":
+			return 0
+		case "max-age":
+			seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+			if err != nil || seconds < 0 {
+				return -1
+			}
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	return -1
+}
+
+// recordRefreshFailure counts the failed refresh and logs it, the stale keys are still served
+func (c *JWKSCache) recordRefreshFailure(ctx context.Context, staleKeys int, err error) {
+	jwksRefreshFailures.Add(ctx, 1, metric.WithAttributes(attribute.String("jwks.uri", c.uri)))
+
+	c.monitor.Log(monitoring.WarnLevel, jwksRefreshFailedEventName,
+		monitoring.StringField("jwks.uri", c.uri),
+		monitoring.IntField("jwks.stale_keys", staleKeys),
+		monitoring.StringField("error.message", err.Error()),
+	)
+}
@@ -0,0 +1,40 @@
+package iam
+
+import (
+	"time"
+)
+
+// JWKSOption alters behaviour of the JWKSCache
+type JWKSOption func(c *JWKSCache)
+
+// WithJWKSRefreshInterval overrides the interval between the background refreshes when the response
+// has no Cache-Control max-age, 1 hour by default
+func WithJWKSRefreshInterval(d time.Duration) JWKSOption {
+	return func(c *JWKSCache) {
+		c.refreshInterval = d
+	}
+}
+
+// WithJWKSMinRefreshInterval overrides the shortest interval between the background refreshes, it bounds
+// the Cache-Control max-age and the retry after a failed refresh, 5 minutes by default
+func WithJWKSMinRefreshInterval(d time.Duration) JWKSOption {
+	return func(c *JWKSCache) {
+		c.minRefreshInterval = d
+	}
+}
+
+// WithJWKSMaxRefreshInterval overrides the longest interval between the background refreshes, it bounds
+// the Cache-Control max-age, 24 hours by default
+func WithJWKSMaxRefreshInterval(d time.Duration) JWKSOption {
+	return func(c *JWKSCache) {
+		c.maxRefreshInterval = d
+	}
+}
+
+// WithJWKSRefetchInterval overrides the shortest interval between the fetches triggered by an unknown `kid`,
+// so the tokens with random key IDs can't flood the issuer, 30 seconds by default
+func WithJWKSRefetchInterval(d time.Duration) JWKSOption {
+	return func(c *JWKSCache) {
+		c.refetchInterval = d
+	}
+}
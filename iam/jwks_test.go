@@ -0,0 +1,432 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/viebiz/lit/ioutil"
+	"github.com/viebiz/lit/monitoring"
+)
+
+func TestNewJWKSCache(t *testing.T) {
+	type args struct {
+		statusCode int
+		body       string
+		doErr      error
+	}
+	tcs := map[string]struct {
+		args   args
+		expErr string
+	}{
+		"success": {
+			args: args{statusCode: http.StatusOK, body: `{"keys":[{"kid":"kid1","kty":"EC","crv":"P-256","x":"gI0GAILBdu7T53akrFmMyGcsF3n5dO7MmwNBHKW5SV0","y":"SLW_xSffzlPWrHEVI30DHM_4egVwt3NQqeUD7nMFpps"}]}`},
+		},
+		"no keys": {
+			args:   args{statusCode: http.StatusOK, body: `{"keys":[]}`},
+			expErr: "no appropriate JWK found",
+		},
+		"non-200 status": {
+			args:   args{statusCode: http.StatusInternalServerError, body: ``},
+			expErr: "got unexpected status code: 500",
+		},
+		"invalid JSON": {
+			args:   args{statusCode: http.StatusOK, body: `{`},
+			expErr: "could not decode jwks: unexpected EOF",
+		},
+		"http client error": {
+			args:   args{doErr: fmt.Errorf("network fail")},
+			expErr: "network fail",
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockClient := new(mockHTTPClient)
+			mockClient.On("Do", mock.Anything).Return(&http.Response{
+				StatusCode: tc.args.statusCode,
+				Body:       io.NopCloser(strings.NewReader(tc.args.body)),
+			}, tc.args.doErr)
+
+			// When
+			c, err := NewJWKSCache(ctx, "http://example.com/jwks", mockClient)
+
+			// Then
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+				_, err = c.Key(ctx, "kid1")
+				require.NoError(t, err)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	rsaKey := readRSAPrivateKey(t, "sample_rsa_private_key")
+	cert := readCertificate(t, "sample_rsa_certificate")
+	rsaJWK := constructJWKSForTest(rsaKey.PublicKey, *cert).Keys[0]
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	enc := base64.RawURLEncoding.EncodeToString
+	x5c := base64.StdEncoding.EncodeToString(cert.Raw)
+
+	tcs := map[string]struct {
+		given   JWKSet
+		expKIDs []string
+		expErr  string
+	}{
+		"no keys": {
+			given:  JWKSet{Keys: []JWK{}},
+			expErr: "no appropriate JWK found",
+		},
+		"skip non-sig, missing kid or invalid keys": {
+			given: JWKSet{Keys: []JWK{
+				{Use: "enc", Kty: "RSA", KID: "k1", X5c: []string{x5c}},
+				{Use: "sig", Kty: "EC", KID: "k2", X5c: []string{x5c}},
+				{Use: "sig", Kty: "RSA", KID: "", X5c: []string{x5c}},
+				{Use: "sig", Kty: "RSA", KID: "k4", X5c: []string{}},
+				{Use: "sig", Kty: "RSA", KID: "k5", X5c: []string{"not-base64"}},
+				{Use: "sig", Kty: "oct", KID: "k6"},
+			}},
+			expErr: "no appropriate JWK found",
+		},
+		"x5c only": {
+			given:   JWKSet{Keys: []JWK{{Use: "sig", Kty: "RSA", KID: "kid1", X5c: []string{x5c}}}},
+			expKIDs: []string{"kid1"},
+		},
+		"RSA n/e, EC and OKP keys": {
+			given: JWKSet{Keys: []JWK{
+				{KID: "rsa", Kty: "RSA", N: rsaJWK.N, E: rsaJWK.E},
+				{KID: "ec", Kty: "EC", Crv: "P-256", X: enc(ecKey.X.FillBytes(make([]byte, 32))), Y: enc(ecKey.Y.FillBytes(make([]byte, 32)))},
+				{KID: "okp", Kty: "OKP", Crv: "Ed25519", X: enc(edPublic)},
+			}},
+			expKIDs: []string{"rsa", "ec", "okp"},
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+
+			// When
+			keys, err := parseJWKS(tc.given)
+
+			// Then
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, keys, len(tc.expKIDs))
+				for _, kid := range tc.expKIDs {
+					require.Contains(t, keys, kid)
+				}
+			}
+		})
+	}
+}
+
+func TestJWKSCache_Key(t *testing.T) {
+	rotatedKeys := `{"keys":[{"kid":"kid1","kty":"EC","crv":"P-256","x":"gI0GAILBdu7T53akrFmMyGcsF3n5dO7MmwNBHKW5SV0","y":"SLW_xSffzlPWrHEVI30DHM_4egVwt3NQqeUD7nMFpps"},` +
+		`{"kid":"kid2","kty":"EC","crv":"P-256","x":"weNJy2HscCSM6AEDTDg04biOvhFhyyWvOHQfeF_PxMQ","y":"e8lnCO-AlStT-NJVX-crhB7QRYhiix03illJOVAOyck"}]}`
+
+	tcs := map[string]struct {
+		givenSecondStatus int
+		givenElapsed      time.Duration
+		givenKID          string
+		expFetches        int32
+		expNextRefresh    time.Duration // Since the first fetch
+		expErr            error
+	}{
+		"known key": {
+			givenKID:       "kid1",
+			expFetches:     1,
+			expNextRefresh: time.Hour,
+		},
+		"unknown key - refetched after rotation": {
+			givenSecondStatus: http.StatusOK,
+			givenElapsed:      time.Minute,
+			givenKID:          "kid2",
+			expFetches:        2,
+			expNextRefresh:    time.Minute + time.Hour,
+		},
+		"unknown key - refetch rate limited": {
+			givenSecondStatus: http.StatusOK,
+			givenElapsed:      time.Second,
+			givenKID:          "kid2",
+			expFetches:        1,
+			expNextRefresh:    time.Hour,
+			expErr:            ErrKeyNotFound,
+		},
+		"unknown key - refetch failed keeps the refresh schedule": {
+			givenSecondStatus: http.StatusBadGateway,
+			givenElapsed:      time.Minute,
+			givenKID:          "kid2",
+			expFetches:        2,
+			expNextRefresh:    time.Hour,
+			expErr:            ErrKeyNotFound,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			var fetches atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if fetches.Add(1) == 1 {
+					_, _ = w.Write([]byte(`{"keys":[{"kid":"kid1","kty":"EC","crv":"P-256","x":"gI0GAILBdu7T53akrFmMyGcsF3n5dO7MmwNBHKW5SV0","y":"SLW_xSffzlPWrHEVI30DHM_4egVwt3NQqeUD7nMFpps"}]}`))
+					return
+				}
+
+				w.WriteHeader(tc.givenSecondStatus)
+				_, _ = w.Write([]byte(rotatedKeys))
+			}))
+			defer srv.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			now := time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC)
+			var mu sync.Mutex
+			clock := func() time.Time {
+				mu.Lock()
+				defer mu.Unlock()
+				return now
+			}
+
+			c, err := NewJWKSCache(ctx, srv.URL, srv.Client(), withJWKSClockForTest(clock))
+			require.NoError(t, err)
+
+			mu.Lock()
+			firstFetch := now
+			now = now.Add(tc.givenElapsed)
+			mu.Unlock()
+
+			// When
+			key, err := c.Key(ctx, tc.givenKID)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, key)
+			}
+			require.Equal(t, tc.expFetches, fetches.Load())
+			c.mu.RLock()
+			require.Equal(t, firstFetch.Add(tc.expNextRefresh), c.nextRefresh)
+			c.mu.RUnlock()
+
+			// The keys of the first fetch are kept
+			_, err = c.Key(ctx, "kid1")
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestJWKSCache_Key_ConcurrentRefetch(t *testing.T) {
+	// Given
+	var fetches atomic.Int32
+	refetchStarted := make(chan struct{})
+	releaseRefetch := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kid := "kid1"
+		if fetches.Add(1) > 1 {
+			close(refetchStarted)
+			<-releaseRefetch
+			kid = "kid2"
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kid": kid, "kty": "EC", "crv": "P-256",
+			"x": "gI0GAILBdu7T53akrFmMyGcsF3n5dO7MmwNBHKW5SV0",
+			"y": "SLW_xSffzlPWrHEVI30DHM_4egVwt3NQqeUD7nMFpps",
+		}}})
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, err := NewJWKSCache(ctx, srv.URL, srv.Client(), WithJWKSRefetchInterval(0))
+	require.NoError(t, err)
+
+	// When
+	const callers = 5
+	errs := make(chan error, callers)
+	go func() {
+		_, err := c.Key(ctx, "kid2")
+		errs <- err
+	}()
+	<-refetchStarted
+
+	// The other callers arrive while the first refetch is in flight
+	for i := 1; i < callers; i++ {
+		go func() {
+			_, err := c.Key(ctx, "kid2")
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(releaseRefetch)
+
+	// Then
+	for i := 0; i < callers; i++ {
+		require.NoError(t, <-errs)
+	}
+	require.Equal(t, int32(2), fetches.Load())
+}
+
+func TestJWKSCache_BackgroundRefresh(t *testing.T) {
+	// Given
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := fetches.Add(1)
+		if n == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable) // The refresh fails once, the keys are kept
+			return
+		}
+
+		w.Header().Set("Cache-Control", "public, max-age=0")
+		kid := "kid1"
+		if n > 2 {
+			kid = "kid2"
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kid": kid, "kty": "EC", "crv": "P-256",
+			"x": "gI0GAILBdu7T53akrFmMyGcsF3n5dO7MmwNBHKW5SV0",
+			"y": "SLW_xSffzlPWrHEVI30DHM_4egVwt3NQqeUD7nMFpps",
+		}}})
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// When
+	c, err := NewJWKSCache(ctx, srv.URL, srv.Client(),
+		WithJWKSMinRefreshInterval(10*time.Millisecond),
+		WithJWKSRefetchInterval(time.Hour),
+	)
+	require.NoError(t, err)
+
+	// Then
+	require.Eventually(t, func() bool {
+		_, err := c.Key(ctx, "kid2")
+		return err == nil
+	}, 5*time.Second, 5*time.Millisecond)
+	require.GreaterOrEqual(t, fetches.Load(), int32(3))
+}
+
+func TestJWKSCache_RefreshFailureLogged(t *testing.T) {
+	// Given
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fetches.Add(1) > 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"keys":[{"kid":"kid1","kty":"EC","crv":"P-256","x":"gI0GAILBdu7T53akrFmMyGcsF3n5dO7MmwNBHKW5SV0","y":"SLW_xSffzlPWrHEVI30DHM_4egVwt3NQqeUD7nMFpps"}]}`))
+	}))
+	defer srv.Close()
+
+	logBuffer := new(bytes.Buffer)
+	m, err := monitoring.New(monitoring.Config{ServerName: "lightning", Writer: logBuffer})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(monitoring.SetInContext(context.Background(), m))
+	defer cancel()
+
+	c, err := NewJWKSCache(ctx, srv.URL, srv.Client(), WithJWKSRefetchInterval(0))
+	require.NoError(t, err)
+
+	// When
+	_, err = c.Key(context.Background(), "kid2")
+
+	// Then
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Contains(t, logBuffer.String(), jwksRefreshFailedEventName)
+}
+
+func TestCacheMaxAge(t *testing.T) {
+	tcs := map[string]struct {
+		given     string
+		expResult time.Duration
+	}{
+		"max-age":           {given: "public, max-age=600", expResult: 10 * time.Minute},
+		"no-cache":          {given: "no-cache", expResult: 0},
+		"no-store":          {given: "no-store, max-age=600", expResult: 0},
+		"missing":           {given: "", expResult: -1},
+		"malformed max-age": {given: "max-age=ten", expResult: -1},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+
+			// When
+			rs := cacheMaxAge(tc.given)
+
+			// Then
+			require.Equal(t, tc.expResult, rs)
+		})
+	}
+}
+
+func TestJWKSCache_refreshAfter(t *testing.T) {
+	// Given
+	c := &JWKSCache{refreshInterval: time.Hour, minRefreshInterval: 5 * time.Minute, maxRefreshInterval: 24 * time.Hour}
+
+	// When
+	// Then
+	require.Equal(t, time.Hour, c.refreshAfter(-1))
+	require.Equal(t, 5*time.Minute, c.refreshAfter(0))
+	require.Equal(t, 10*time.Minute, c.refreshAfter(10*time.Minute))
+	require.Equal(t, 24*time.Hour, c.refreshAfter(48*time.Hour))
+}
+
+func withJWKSClockForTest(now func() time.Time) JWKSOption {
+	return func(c *JWKSCache) {
+		c.now = now
+	}
+}
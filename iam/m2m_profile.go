@@ -1,13 +1,11 @@
 package iam
 
 import (
-	"fmt"
 	"strings"
 )
 
 const (
-	scopeClaimKey  string = "scope"
-	scopeSeparator string = " "
+	scopeClaimKey string = "scope"
 )
 
 type M2MProfile struct {
@@ -47,18 +45,13 @@ func (p M2MProfile) HasAnyScope(scopes ...string) bool {
 }
 
 func extractScopeFromClaims(claims Claims) (map[string]bool, error) {
-	scopeClaim, exists := claims.ExtraClaims[scopeClaimKey]
-	if !exists {
-		return nil, ErrMissingRequiredClaim
-	}
-
-	scopes, ok := scopeClaim.(string)
-	if !ok {
-		scopes = fmt.Sprintf("%s", scopeClaim)
+	scopes, err := extractClaimValues(claims, []string{scopeClaimKey}, nil, strings.Fields)
+	if err != nil {
+		return nil, err
 	}
 
 	scopeSet := make(map[string]bool)
-	for _, scope := range strings.Split(scopes, scopeSeparator) {
+	for _, scope := range scopes {
 		scopeSet[scope] = true
 	}
 
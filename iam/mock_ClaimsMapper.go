@@ -0,0 +1,88 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package iam
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockClaimsMapper is an autogenerated mock type for the ClaimsMapper type
+type MockClaimsMapper struct {
+	mock.Mock
+}
+
+type MockClaimsMapper_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockClaimsMapper) EXPECT() *MockClaimsMapper_Expecter {
+	return &MockClaimsMapper_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: claims
+func (_m *MockClaimsMapper) Execute(claims Claims) (Claims, error) {
+	ret := _m.Called(claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 Claims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(Claims) (Claims, error)); ok {
+		return rf(claims)
+	}
+	if rf, ok := ret.Get(0).(func(Claims) Claims); ok {
+		r0 = rf(claims)
+	} else {
+		r0 = ret.Get(0).(Claims)
+	}
+
+	if rf, ok := ret.Get(1).(func(Claims) error); ok {
+		r1 = rf(claims)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClaimsMapper_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockClaimsMapper_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - claims Claims
+func (_e *MockClaimsMapper_Expecter) Execute(claims interface{}) *MockClaimsMapper_Execute_Call {
+	return &MockClaimsMapper_Execute_Call{Call: _e.mock.On("Execute", claims)}
+}
+
+func (_c *MockClaimsMapper_Execute_Call) Run(run func(claims Claims)) *MockClaimsMapper_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(Claims))
+	})
+	return _c
+}
+
+func (_c *MockClaimsMapper_Execute_Call) Return(_a0 Claims, _a1 error) *MockClaimsMapper_Execute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClaimsMapper_Execute_Call) RunAndReturn(run func(Claims) (Claims, error)) *MockClaimsMapper_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockClaimsMapper creates a new instance of MockClaimsMapper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockClaimsMapper(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockClaimsMapper {
+	mock := &MockClaimsMapper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
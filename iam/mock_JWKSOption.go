@@ -0,0 +1,65 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package iam
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockJWKSOption is an autogenerated mock type for the JWKSOption type
+type MockJWKSOption struct {
+	mock.Mock
+}
+
+type MockJWKSOption_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJWKSOption) EXPECT() *MockJWKSOption_Expecter {
+	return &MockJWKSOption_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: c
+func (_m *MockJWKSOption) Execute(c *JWKSCache) {
+	_m.Called(c)
+}
+
+// MockJWKSOption_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockJWKSOption_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - c *JWKSCache
+func (_e *MockJWKSOption_Expecter) Execute(c interface{}) *MockJWKSOption_Execute_Call {
+	return &MockJWKSOption_Execute_Call{Call: _e.mock.On("Execute", c)}
+}
+
+func (_c *MockJWKSOption_Execute_Call) Run(run func(c *JWKSCache)) *MockJWKSOption_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*JWKSCache))
+	})
+	return _c
+}
+
+func (_c *MockJWKSOption_Execute_Call) Return() *MockJWKSOption_Execute_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockJWKSOption_Execute_Call) RunAndReturn(run func(*JWKSCache)) *MockJWKSOption_Execute_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockJWKSOption creates a new instance of MockJWKSOption. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJWKSOption(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJWKSOption {
+	mock := &MockJWKSOption{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
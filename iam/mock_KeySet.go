@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package iam
+
+import (
+	context "context"
+	crypto "crypto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockKeySet is an autogenerated mock type for the KeySet type
+type MockKeySet struct {
+	mock.Mock
+}
+
+type MockKeySet_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKeySet) EXPECT() *MockKeySet_Expecter {
+	return &MockKeySet_Expecter{mock: &_m.Mock}
+}
+
+// Key provides a mock function with given fields: ctx, kid
+func (_m *MockKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	ret := _m.Called(ctx, kid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Key")
+	}
+
+	var r0 crypto.PublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (crypto.PublicKey, error)); ok {
+		return rf(ctx, kid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) crypto.PublicKey); ok {
+		r0 = rf(ctx, kid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(crypto.PublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, kid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeySet_Key_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Key'
+type MockKeySet_Key_Call struct {
+	*mock.Call
+}
+
+// Key is a helper method to define mock.On call
+//   - ctx context.Context
+//   - kid string
+func (_e *MockKeySet_Expecter) Key(ctx interface{}, kid interface{}) *MockKeySet_Key_Call {
+	return &MockKeySet_Key_Call{Call: _e.mock.On("Key", ctx, kid)}
+}
+
+func (_c *MockKeySet_Key_Call) Run(run func(ctx context.Context, kid string)) *MockKeySet_Key_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockKeySet_Key_Call) Return(_a0 crypto.PublicKey, _a1 error) *MockKeySet_Key_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeySet_Key_Call) RunAndReturn(run func(context.Context, string) (crypto.PublicKey, error)) *MockKeySet_Key_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockKeySet creates a new instance of MockKeySet. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeySet(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeySet {
+	mock := &MockKeySet{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
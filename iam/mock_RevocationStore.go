@@ -0,0 +1,248 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package iam
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRevocationStore is an autogenerated mock type for the RevocationStore type
+type MockRevocationStore struct {
+	mock.Mock
+}
+
+type MockRevocationStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRevocationStore) EXPECT() *MockRevocationStore_Expecter {
+	return &MockRevocationStore_Expecter{mock: &_m.Mock}
+}
+
+// IsTokenRevoked provides a mock function with given fields: ctx, jti
+func (_m *MockRevocationStore) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	ret := _m.Called(ctx, jti)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTokenRevoked")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, jti)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, jti)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jti)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRevocationStore_IsTokenRevoked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsTokenRevoked'
+type MockRevocationStore_IsTokenRevoked_Call struct {
+	*mock.Call
+}
+
+// IsTokenRevoked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jti string
+func (_e *MockRevocationStore_Expecter) IsTokenRevoked(ctx interface{}, jti interface{}) *MockRevocationStore_IsTokenRevoked_Call {
+	return &MockRevocationStore_IsTokenRevoked_Call{Call: _e.mock.On("IsTokenRevoked", ctx, jti)}
+}
+
+func (_c *MockRevocationStore_IsTokenRevoked_Call) Run(run func(ctx context.Context, jti string)) *MockRevocationStore_IsTokenRevoked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRevocationStore_IsTokenRevoked_Call) Return(_a0 bool, _a1 error) *MockRevocationStore_IsTokenRevoked_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRevocationStore_IsTokenRevoked_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockRevocationStore_IsTokenRevoked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSubject provides a mock function with given fields: ctx, sub, before, ttl
+func (_m *MockRevocationStore) RevokeSubject(ctx context.Context, sub string, before time.Time, ttl time.Duration) error {
+	ret := _m.Called(ctx, sub, before, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSubject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) error); ok {
+		r0 = rf(ctx, sub, before, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRevocationStore_RevokeSubject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSubject'
+type MockRevocationStore_RevokeSubject_Call struct {
+	*mock.Call
+}
+
+// RevokeSubject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sub string
+//   - before time.Time
+//   - ttl time.Duration
+func (_e *MockRevocationStore_Expecter) RevokeSubject(ctx interface{}, sub interface{}, before interface{}, ttl interface{}) *MockRevocationStore_RevokeSubject_Call {
+	return &MockRevocationStore_RevokeSubject_Call{Call: _e.mock.On("RevokeSubject", ctx, sub, before, ttl)}
+}
+
+func (_c *MockRevocationStore_RevokeSubject_Call) Run(run func(ctx context.Context, sub string, before time.Time, ttl time.Duration)) *MockRevocationStore_RevokeSubject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRevocationStore_RevokeSubject_Call) Return(_a0 error) *MockRevocationStore_RevokeSubject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRevocationStore_RevokeSubject_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Duration) error) *MockRevocationStore_RevokeSubject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeToken provides a mock function with given fields: ctx, jti, ttl
+func (_m *MockRevocationStore) RevokeToken(ctx context.Context, jti string, ttl time.Duration) error {
+	ret := _m.Called(ctx, jti, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, jti, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRevocationStore_RevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeToken'
+type MockRevocationStore_RevokeToken_Call struct {
+	*mock.Call
+}
+
+// RevokeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jti string
+//   - ttl time.Duration
+func (_e *MockRevocationStore_Expecter) RevokeToken(ctx interface{}, jti interface{}, ttl interface{}) *MockRevocationStore_RevokeToken_Call {
+	return &MockRevocationStore_RevokeToken_Call{Call: _e.mock.On("RevokeToken", ctx, jti, ttl)}
+}
+
+func (_c *MockRevocationStore_RevokeToken_Call) Run(run func(ctx context.Context, jti string, ttl time.Duration)) *MockRevocationStore_RevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRevocationStore_RevokeToken_Call) Return(_a0 error) *MockRevocationStore_RevokeToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRevocationStore_RevokeToken_Call) RunAndReturn(run func(context.Context, string, time.Duration) error) *MockRevocationStore_RevokeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubjectRevokedBefore provides a mock function with given fields: ctx, sub
+func (_m *MockRevocationStore) SubjectRevokedBefore(ctx context.Context, sub string) (time.Time, error) {
+	ret := _m.Called(ctx, sub)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubjectRevokedBefore")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return rf(ctx, sub)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = rf(ctx, sub)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sub)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRevocationStore_SubjectRevokedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubjectRevokedBefore'
+type MockRevocationStore_SubjectRevokedBefore_Call struct {
+	*mock.Call
+}
+
+// SubjectRevokedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sub string
+func (_e *MockRevocationStore_Expecter) SubjectRevokedBefore(ctx interface{}, sub interface{}) *MockRevocationStore_SubjectRevokedBefore_Call {
+	return &MockRevocationStore_SubjectRevokedBefore_Call{Call: _e.mock.On("SubjectRevokedBefore", ctx, sub)}
+}
+
+func (_c *MockRevocationStore_SubjectRevokedBefore_Call) Run(run func(ctx context.Context, sub string)) *MockRevocationStore_SubjectRevokedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRevocationStore_SubjectRevokedBefore_Call) Return(_a0 time.Time, _a1 error) *MockRevocationStore_SubjectRevokedBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRevocationStore_SubjectRevokedBefore_Call) RunAndReturn(run func(context.Context, string) (time.Time, error)) *MockRevocationStore_SubjectRevokedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRevocationStore creates a new instance of MockRevocationStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRevocationStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRevocationStore {
+	mock := &MockRevocationStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,88 @@
+package iam
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/viebiz/lit/jwt"
+)
+
+// ClaimsMapper maps the claims of an issuer to the claims the service understands, e.g. copies
+// the roles of a partner IdP to the claim read by ExtractUserProfileFromClaims
+type ClaimsMapper func(claims Claims) (Claims, error)
+
+// TrustedIssuer represents an issuer accepted by the multi-issuer validator
+type TrustedIssuer struct {
+	// Issuer is the `iss` claim of the tokens of the issuer
+	Issuer string
+
+	// Validator validates the tokens of the issuer with its own keys and audience, e.g. NewOIDCValidator
+	Validator Validator
+
+	// MapClaims maps the claims of the validated tokens, optional
+	MapClaims ClaimsMapper
+}
+
+// multiIssuerValidator routes the tokens to the validator of their issuer
+type multiIssuerValidator struct {
+	// issuers contains the trusted issuers by their identifier without trailing slash
+	issuers map[string]TrustedIssuer
+}
+
+// Validate validates the token by the validator of its issuer
+func (v multiIssuerValidator) Validate(tokenString string) (jwt.Token[Claims], error) {
+	// 1. Read the issuer of the token, it is verified by the validator of the issuer
+	issuer, err := unverifiedIssuer(tokenString)
+	if err != nil {
+		return jwt.Token[Claims]{}, err
+	}
+
+	iss, exists := v.issuers[strings.TrimSuffix(issuer, "/")]
+	if !exists {
+		return jwt.Token[Claims]{}, ErrInvalidToken
+	}
+
+	// 2. Validate the token by the issuer
+	tk, err := iss.Validator.Validate(tokenString)
+	if err != nil {
+		return jwt.Token[Claims]{}, err
+	}
+
+	// 3. Map the issuer claims
+	if iss.MapClaims != nil {
+		if tk.Claims, err = iss.MapClaims(tk.Claims); err != nil {
+			return jwt.Token[Claims]{}, err
+		}
+	}
+
+	return tk, nil
+}
+
+// unverifiedIssuer returns the `iss` claim of the JWS token without verifying the signature,
+// the tokens without issuer are rejected as invalid rather than malformed
+func unverifiedIssuer(tokenString string) (string, error) {
+	parts := strings.Split(tokenString, ".")
+	if len(parts) != 3 {
+		return "", ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err := json.NewDecoder(bytes.NewReader(payload)).Decode(&claims); err != nil {
+		return "", ErrInvalidToken
+	}
+
+	if claims.Issuer == "" {
+		return "", ErrInvalidToken
+	}
+
+	return claims.Issuer, nil
+}
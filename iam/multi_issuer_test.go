@@ -0,0 +1,173 @@
+package iam
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/viebiz/lit/jwt"
+)
+
+func TestNewMultiIssuerValidator(t *testing.T) {
+	validator := NewMockValidator(t)
+
+	tcs := map[string]struct {
+		givenIssuers []TrustedIssuer
+		expErr       string
+	}{
+		"success": {
+			givenIssuers: []TrustedIssuer{
+				{Issuer: "https://internal.mukagen.com", Validator: validator},
+				{Issuer: "https://partner.example.com", Validator: validator},
+			},
+		},
+		"error - missing issuer": {
+			givenIssuers: []TrustedIssuer{{Validator: validator}},
+			expErr:       "invalid trusted issuer",
+		},
+		"error - missing validator": {
+			givenIssuers: []TrustedIssuer{{Issuer: "https://internal.mukagen.com"}},
+			expErr:       "invalid trusted issuer",
+		},
+		"error - duplicated issuer": {
+			givenIssuers: []TrustedIssuer{
+				{Issuer: "https://internal.mukagen.com", Validator: validator},
+				{Issuer: "https://internal.mukagen.com/", Validator: validator},
+			},
+			expErr: `invalid trusted issuer: duplicated issuer "https://internal.mukagen.com/"`,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+
+			// When
+			_, err := NewMultiIssuerValidator(tc.givenIssuers...)
+
+			// Then
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMultiIssuerValidator_Validate(t *testing.T) {
+	tokenForTest := func(payload string) string {
+		return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2ln"
+	}
+	internalToken := tokenForTest(`{"iss":"https://internal.mukagen.com/","sub":"juso-shi"}`)
+	partnerToken := tokenForTest(`{"iss":"https://partner.example.com","sub":"partner|juso-shi"}`)
+
+	type mockValidator struct {
+		expCall  bool
+		outToken jwt.Token[Claims]
+		outErr   error
+	}
+	tcs := map[string]struct {
+		givenToken       string
+		mockInternal     mockValidator
+		mockPartner      mockValidator
+		givenMapClaimErr error
+		expResult        jwt.Token[Claims]
+		expErr           error
+	}{
+		"success - routed to internal issuer": {
+			givenToken: internalToken,
+			mockInternal: mockValidator{
+				expCall:  true,
+				outToken: jwt.Token[Claims]{Claims: Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "juso-shi"}}},
+			},
+			expResult: jwt.Token[Claims]{Claims: Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "juso-shi"}}},
+		},
+		"success - routed to partner issuer and claims mapped": {
+			givenToken: partnerToken,
+			mockPartner: mockValidator{
+				expCall: true,
+				outToken: jwt.Token[Claims]{Claims: Claims{
+					RegisteredClaims: jwt.RegisteredClaims{Subject: "partner|juso-shi"},
+					ExtraClaims:      map[string]interface{}{"roles": []interface{}{"admin"}},
+				}},
+			},
+			expResult: jwt.Token[Claims]{Claims: Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "partner|juso-shi"},
+				ExtraClaims:      map[string]interface{}{"https://lightning.app/roles": []interface{}{"admin"}},
+			}},
+		},
+		"error - unknown issuer": {
+			givenToken: tokenForTest(`{"iss":"https://evil.com"}`),
+			expErr:     ErrInvalidToken,
+		},
+		"error - missing issuer": {
+			givenToken: tokenForTest(`{"sub":"juso-shi"}`),
+			expErr:     ErrInvalidToken,
+		},
+		"error - malformed payload": {
+			givenToken: "eyJhbGciOiJSUzI1NiJ9.not-json.c2ln",
+			expErr:     ErrInvalidToken,
+		},
+		"error - not a JWS": {
+			givenToken: "opaque-token",
+			expErr:     ErrInvalidToken,
+		},
+		"error - issuer validation failed": {
+			givenToken:   internalToken,
+			mockInternal: mockValidator{expCall: true, outErr: ErrTokenExpired},
+			expErr:       ErrTokenExpired,
+		},
+		"error - claims mapping failed": {
+			givenToken:       partnerToken,
+			mockPartner:      mockValidator{expCall: true},
+			givenMapClaimErr: ErrMissingRequiredClaim,
+			expErr:           ErrMissingRequiredClaim,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			internal := NewMockValidator(t)
+			if tc.mockInternal.expCall {
+				internal.EXPECT().Validate(tc.givenToken).Return(tc.mockInternal.outToken, tc.mockInternal.outErr)
+			}
+			partner := NewMockValidator(t)
+			if tc.mockPartner.expCall {
+				partner.EXPECT().Validate(tc.givenToken).Return(tc.mockPartner.outToken, tc.mockPartner.outErr)
+			}
+
+			v, err := NewMultiIssuerValidator(
+				TrustedIssuer{Issuer: "https://internal.mukagen.com", Validator: internal},
+				TrustedIssuer{Issuer: "https://partner.example.com", Validator: partner, MapClaims: func(claims Claims) (Claims, error) {
+					if tc.givenMapClaimErr != nil {
+						return Claims{}, tc.givenMapClaimErr
+					}
+
+					claims.ExtraClaims = map[string]interface{}{"https://lightning.app/roles": claims.ExtraClaims["roles"]}
+					return claims, nil
+				}},
+			)
+			require.NoError(t, err)
+
+			// When
+			rs, err := v.Validate(tc.givenToken)
+
+			// Then
+			if tc.expErr != nil {
+				require.True(t, errors.Is(err, tc.expErr))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, rs)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+)
+
+// ProviderMetadata represents the OpenID Provider metadata
+// Refer https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type ProviderMetadata struct {
+	Issuer                           string   `json:"issuer"`
+	AuthorizationEndpoint            string   `json:"authorization_endpoint,omitempty"`
+	TokenEndpoint                    string   `json:"token_endpoint,omitempty"`
+	UserinfoEndpoint                 string   `json:"userinfo_endpoint,omitempty"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	IntrospectionEndpoint            string   `json:"introspection_endpoint,omitempty"`
+	RevocationEndpoint               string   `json:"revocation_endpoint,omitempty"`
+	ScopesSupported                  []string `json:"scopes_supported,omitempty"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported,omitempty"`
+}
+
+// DiscoverProvider fetches the metadata of the issuer from its `/.well-known/openid-configuration` document.
+// The issuer of the document must be the given one, so a compromised document can't impersonate another issuer
+func DiscoverProvider(ctx context.Context, issuer string, client HTTPClient) (ProviderMetadata, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+oidcDiscoveryPath, nil)
+	if err != nil {
+		return ProviderMetadata{}, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return ProviderMetadata{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return ProviderMetadata{}, fmt.Errorf("got unexpected status code: %d", resp.StatusCode)
+	}
+
+	var metadata ProviderMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return ProviderMetadata{}, fmt.Errorf("could not decode provider metadata: %w", err)
+	}
+
+	if strings.TrimSuffix(metadata.Issuer, "/") != issuer {
+		return ProviderMetadata{}, fmt.Errorf("%w: issuer %q does not match %q", ErrInvalidProviderMetadata, metadata.Issuer, issuer)
+	}
+
+	if metadata.JWKSURI == "" {
+		return ProviderMetadata{}, fmt.Errorf("%w: %w", ErrInvalidProviderMetadata, ErrMissingJWKSURI)
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,197 @@
+package iam
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/viebiz/lit/ioutil"
+	"github.com/viebiz/lit/jwt"
+)
+
+func TestDiscoverProvider(t *testing.T) {
+	type args struct {
+		statusCode int
+		body       string
+		doErr      error
+	}
+	tcs := map[string]struct {
+		givenIssuer string
+		args        args
+		expResult   ProviderMetadata
+		expErr      string
+	}{
+		"success": {
+			givenIssuer: "https://mukagen.com/",
+			args: args{
+				statusCode: http.StatusOK,
+				body:       `{"issuer":"https://mukagen.com/","jwks_uri":"https://mukagen.com/jwks","id_token_signing_alg_values_supported":["RS256","ES256"]}`,
+			},
+			expResult: ProviderMetadata{
+				Issuer:                           "https://mukagen.com/",
+				JWKSURI:                          "https://mukagen.com/jwks",
+				IDTokenSigningAlgValuesSupported: []string{"RS256", "ES256"},
+			},
+		},
+		"error - issuer mismatch": {
+			givenIssuer: "https://mukagen.com",
+			args: args{
+				statusCode: http.StatusOK,
+				body:       `{"issuer":"https://evil.com","jwks_uri":"https://evil.com/jwks"}`,
+			},
+			expErr: `invalid provider metadata: issuer "https://evil.com" does not match "https://mukagen.com"`,
+		},
+		"error - missing jwks_uri": {
+			givenIssuer: "https://mukagen.com",
+			args: args{
+				statusCode: http.StatusOK,
+				body:       `{"issuer":"https://mukagen.com"}`,
+			},
+			expErr: "invalid provider metadata: missing JWKS URI",
+		},
+		"error - non-200 status": {
+			givenIssuer: "https://mukagen.com",
+			args:        args{statusCode: http.StatusNotFound},
+			expErr:      "got unexpected status code: 404",
+		},
+		"error - invalid JSON": {
+			givenIssuer: "https://mukagen.com",
+			args:        args{statusCode: http.StatusOK, body: `{`},
+			expErr:      "could not decode provider metadata: unexpected EOF",
+		},
+		"error - http client error": {
+			givenIssuer: "https://mukagen.com",
+			args:        args{doErr: errors.New("network fail")},
+			expErr:      "network fail",
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			mockClient := new(mockHTTPClient)
+			mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+				return req.URL.String() == "https://mukagen.com/.well-known/openid-configuration"
+			})).Return(&http.Response{
+				StatusCode: tc.args.statusCode,
+				Body:       io.NopCloser(strings.NewReader(tc.args.body)),
+			}, tc.args.doErr)
+
+			// When
+			rs, err := DiscoverProvider(context.Background(), tc.givenIssuer, mockClient)
+
+			// Then
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, rs)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewOIDCValidator(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	const audience = "https://limitless.mukagen.com"
+	staticTimeNow := time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC)
+
+	pkey := readRSAPrivateKey(t, "sample_rsa_private_key")
+	jwkSet := constructJWKSForTest(pkey.PublicKey, *readCertificate(t, "sample_rsa_certificate"))
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var issuer string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(ProviderMetadata{
+				Issuer:                           issuer,
+				JWKSURI:                          issuer + "/keys",
+				IDTokenSigningAlgValuesSupported: []string{"RS256"},
+			})
+		case "/keys":
+			_ = json.NewEncoder(w).Encode(jwkSet)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	issuer = srv.URL
+
+	signTokenForTest := func(method jwt.SigningMethod, key crypto.Signer) string {
+		tk := jwt.NewToken(method, Claims{RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   "juso-shi@clients",
+			Audience:  []string{audience},
+			IssuedAt:  pointerTo(staticTimeNow.Unix()),
+			ExpiresAt: pointerTo(staticTimeNow.Add(time.Hour).Unix()),
+			ClientID:  "juso-shi",
+			JTI:       "this-is-uuid",
+		}})
+		tk.Header.Typ = "at+jwt"
+		tk.Header.Kid = "json-web-key-01"
+
+		tokenString, err := tk.SignedString(key)
+		require.NoError(t, err)
+
+		return tokenString
+	}
+
+	tcs := map[string]struct {
+		givenToken string
+		expErr     error
+	}{
+		"success": {
+			givenToken: signTokenForTest(jwt.NewRS256(), pkey),
+		},
+		"success - algorithm not advertised for the ID tokens": {
+			givenToken: signTokenForTest(jwt.NewPS256(), pkey),
+		},
+		"error - algorithm does not match the key": {
+			givenToken: signTokenForTest(jwt.NewES256(), ecKey),
+			expErr:     jwt.ErrInvalidSignature,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			defer func(origin func() time.Time) { timeNowFunc = origin }(timeNowFunc)
+			timeNowFunc = func() time.Time { return staticTimeNow }
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			validator, err := NewOIDCValidator(ctx, issuer, audience, srv.Client())
+			require.NoError(t, err)
+
+			// When
+			tk, err := validator.Validate(tc.givenToken)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "juso-shi@clients", tk.Claims.RegisteredClaims.Subject)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package iam
+
+import (
+	"context"
+	"time"
+
+	"github.com/viebiz/lit/jwt"
+)
+
+const (
+	defaultRevocationKeyPrefix = "iam:revoked:"
+)
+
+// revocationValidator rejects the revoked tokens validated by the next validator
+type revocationValidator struct {
+	next  Validator
+	store RevocationStore
+}
+
+// Validate validates the token by the next validator then checks it's not revoked
+func (v revocationValidator) Validate(tokenString string) (jwt.Token[Claims], error) {
+	// 1. Validate token
+	tk, err := v.next.Validate(tokenString)
+	if err != nil {
+		return jwt.Token[Claims]{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// 2. Check the token is not revoked by its jti
+	rc := tk.Claims.RegisteredClaims
+	if rc.JTI != "" {
+		revoked, err := v.store.IsTokenRevoked(ctx, rc.JTI)
+		if err != nil {
+			return jwt.Token[Claims]{}, err
+		}
+
+		if revoked {
+			return jwt.Token[Claims]{}, ErrTokenRevoked
+		}
+	}
+
+	// 3. Check the tokens of the subject are not revoked, the tokens without `iat` can't prove they are issued after
+	if rc.Subject != "" {
+		before, err := v.store.SubjectRevokedBefore(ctx, rc.Subject)
+		if err != nil {
+			return jwt.Token[Claims]{}, err
+		}
+
+		if !before.IsZero() && (rc.IssuedAt == nil || *rc.IssuedAt <= before.Unix()) {
+			return jwt.Token[Claims]{}, ErrTokenRevoked
+		}
+	}
+
+	return tk, nil
+}
+
+// Revoker revokes the tokens in the RevocationStore, the entries live as long as the revoked tokens
+type Revoker struct {
+	store RevocationStore
+
+	// maxTokenLifetime is the longest lifetime of the issued tokens, the tokens of a revoked subject are expired after it
+	maxTokenLifetime time.Duration
+}
+
+// RevokeToken revokes the token of the jti until it expires, the max token lifetime is used when the expiry is unknown.
+// The entry never outlives the max token lifetime, so a far future exp can't keep it in the store
+func (r Revoker) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	if jti == "" {
+		return ErrMissingRequiredClaim
+	}
+
+	ttl := r.maxTokenLifetime
+	if !expiresAt.IsZero() {
+		ttl = expiresAt.Sub(timeNowFunc())
+
+		// The expired token is rejected anyway
+		if ttl <= 0 {
+			return nil
+		}
+
+		if r.maxTokenLifetime > 0 && ttl > r.maxTokenLifetime {
+			ttl = r.maxTokenLifetime
+		}
+	}
+
+	// The entry must expire, e.g. Redis keeps the key without TTL forever
+	if ttl <= 0 {
+		return ErrInvalidTokenLifetime
+	}
+
+	return r.store.RevokeToken(ctx, jti, ttl)
+}
+
+// RevokeSubject revokes all tokens of the subject issued until now, e.g. to log out the user everywhere
+func (r Revoker) RevokeSubject(ctx context.Context, sub string) error {
+	if sub == "" {
+		return ErrMissingRequiredClaim
+	}
+
+	if r.maxTokenLifetime <= 0 {
+		return ErrInvalidTokenLifetime
+	}
+
+	return r.store.RevokeSubject(ctx, sub, timeNowFunc(), r.maxTokenLifetime)
+}
@@ -0,0 +1,73 @@
+package iam
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryRevocationEntry struct {
+	before    time.Time
+	expiresAt time.Time
+}
+
+type memoryRevocationStore struct {
+	mu       sync.Mutex
+	tokens   map[string]time.Time
+	subjects map[string]memoryRevocationEntry
+}
+
+func (s *memoryRevocationStore) RevokeToken(_ context.Context, jti string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[jti] = timeNowFunc().Add(ttl)
+
+	return nil
+}
+
+func (s *memoryRevocationStore) IsTokenRevoked(_ context.Context, jti string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, exists := s.tokens[jti]
+	if !exists {
+		return false, nil
+	}
+
+	if timeNowFunc().After(expiresAt) {
+		delete(s.tokens, jti)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (s *memoryRevocationStore) RevokeSubject(_ context.Context, sub string, before time.Time, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subjects[sub] = memoryRevocationEntry{
+		before:    before,
+		expiresAt: timeNowFunc().Add(ttl),
+	}
+
+	return nil
+}
+
+func (s *memoryRevocationStore) SubjectRevokedBefore(_ context.Context, sub string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.subjects[sub]
+	if !exists {
+		return time.Time{}, nil
+	}
+
+	if timeNowFunc().After(entry.expiresAt) {
+		delete(s.subjects, sub)
+		return time.Time{}, nil
+	}
+
+	return entry.before, nil
+}
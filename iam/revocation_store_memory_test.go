@@ -0,0 +1,47 @@
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRevocationStore(t *testing.T) {
+	// Given
+	defer func(origin func() time.Time) { timeNowFunc = origin }(timeNowFunc)
+	now := time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC)
+	timeNowFunc = func() time.Time { return now }
+
+	ctx := context.Background()
+	store := NewMemoryRevocationStore()
+
+	// When
+	require.NoError(t, store.RevokeToken(ctx, "jti-1", time.Minute))
+	require.NoError(t, store.RevokeSubject(ctx, "sub-1", now, time.Hour))
+
+	// Then
+	revoked, err := store.IsTokenRevoked(ctx, "jti-1")
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revoked, err = store.IsTokenRevoked(ctx, "jti-2")
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	before, err := store.SubjectRevokedBefore(ctx, "sub-1")
+	require.NoError(t, err)
+	require.Equal(t, now, before)
+
+	// The entries expire by their TTL
+	now = now.Add(2 * time.Hour)
+
+	revoked, err = store.IsTokenRevoked(ctx, "jti-1")
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	before, err = store.SubjectRevokedBefore(ctx, "sub-1")
+	require.NoError(t, err)
+	require.True(t, before.IsZero())
+}
@@ -0,0 +1,50 @@
+package iam
+
+import (
+	"context"
+	"time"
+
+	"github.com/viebiz/lit/caching/redis"
+)
+
+const (
+	revokedTokenKeyPart   = "jti:"
+	revokedSubjectKeyPart = "sub:"
+)
+
+type redisRevocationStore struct {
+	client    redis.Client
+	keyPrefix string
+}
+
+func (s redisRevocationStore) RevokeToken(ctx context.Context, jti string, ttl time.Duration) error {
+	return s.client.SetInt(ctx, s.keyPrefix+revokedTokenKeyPart+jti, 1, ttl)
+}
+
+func (s redisRevocationStore) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	v, err := s.client.GetInt(ctx, s.keyPrefix+revokedTokenKeyPart+jti)
+	if err != nil {
+		return false, err
+	}
+
+	// Redis client returns zero if the key does not exist
+	return v != 0, nil
+}
+
+func (s redisRevocationStore) RevokeSubject(ctx context.Context, sub string, before time.Time, ttl time.Duration) error {
+	return s.client.SetInt(ctx, s.keyPrefix+revokedSubjectKeyPart+sub, before.Unix(), ttl)
+}
+
+func (s redisRevocationStore) SubjectRevokedBefore(ctx context.Context, sub string) (time.Time, error) {
+	v, err := s.client.GetInt(ctx, s.keyPrefix+revokedSubjectKeyPart+sub)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Redis client returns zero if the key does not exist
+	if v == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Unix(v, 0), nil
+}
@@ -0,0 +1,114 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit/caching/redis"
+)
+
+func TestRedisRevocationStore_IsTokenRevoked(t *testing.T) {
+	tcs := map[string]struct {
+		mockValue  int64
+		mockErr    error
+		expRevoked bool
+		expErr     error
+	}{
+		"revoked": {
+			mockValue:  1,
+			expRevoked: true,
+		},
+		"not revoked": {},
+		"error - redis error": {
+			mockErr: errors.New("simulated error"),
+			expErr:  errors.New("simulated error"),
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			ctx := context.Background()
+			mockClient := redis.NewMockClient(t)
+			mockClient.EXPECT().GetInt(ctx, "iam:revoked:jti:jti-1").Return(tc.mockValue, tc.mockErr)
+
+			// When
+			revoked, err := NewRedisRevocationStore(mockClient, "").IsTokenRevoked(ctx, "jti-1")
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expRevoked, revoked)
+		})
+	}
+}
+
+func TestRedisRevocationStore_SubjectRevokedBefore(t *testing.T) {
+	revokedAt := time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC)
+
+	tcs := map[string]struct {
+		mockValue int64
+		mockErr   error
+		expResult time.Time
+		expErr    error
+	}{
+		"revoked": {
+			mockValue: revokedAt.Unix(),
+			expResult: time.Unix(revokedAt.Unix(), 0),
+		},
+		"not revoked": {},
+		"error - redis error": {
+			mockErr: errors.New("simulated error"),
+			expErr:  errors.New("simulated error"),
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			ctx := context.Background()
+			mockClient := redis.NewMockClient(t)
+			mockClient.EXPECT().GetInt(ctx, "app:sub:sub-1").Return(tc.mockValue, tc.mockErr)
+
+			// When
+			before, err := NewRedisRevocationStore(mockClient, "app:").SubjectRevokedBefore(ctx, "sub-1")
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expResult, before)
+		})
+	}
+}
+
+func TestRedisRevocationStore_Revoke(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	revokedAt := time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC)
+	mockClient := redis.NewMockClient(t)
+	mockClient.EXPECT().SetInt(ctx, "iam:revoked:jti:jti-1", int64(1), time.Minute).Return(nil)
+	mockClient.EXPECT().SetInt(ctx, "iam:revoked:sub:sub-1", revokedAt.Unix(), time.Hour).Return(nil)
+	store := NewRedisRevocationStore(mockClient, "")
+
+	// When & Then
+	require.NoError(t, store.RevokeToken(ctx, "jti-1", time.Minute))
+	require.NoError(t, store.RevokeSubject(ctx, "sub-1", revokedAt, time.Hour))
+}
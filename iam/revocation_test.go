@@ -0,0 +1,208 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit/jwt"
+)
+
+func TestRevocationValidator_Validate(t *testing.T) {
+	revokedAt := time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC)
+	tokenForTest := func(jti string, iat *int64) jwt.Token[Claims] {
+		return jwt.Token[Claims]{Claims: Claims{RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  "juso-shi@clients",
+			JTI:      jti,
+			IssuedAt: iat,
+		}}}
+	}
+
+	type mockStore struct {
+		expTokenCall   bool
+		outRevoked     bool
+		outTokenErr    error
+		expSubjectCall bool
+		outBefore      time.Time
+		outSubjectErr  error
+	}
+	tcs := map[string]struct {
+		givenToken jwt.Token[Claims]
+		givenErr   error
+		mockStore  mockStore
+		expErr     error
+	}{
+		"success": {
+			givenToken: tokenForTest("this-is-uuid", pointerTo(revokedAt.Unix())),
+			mockStore:  mockStore{expTokenCall: true, expSubjectCall: true},
+		},
+		"success - issued after subject revoked": {
+			givenToken: tokenForTest("this-is-uuid", pointerTo(revokedAt.Add(time.Second).Unix())),
+			mockStore:  mockStore{expTokenCall: true, expSubjectCall: true, outBefore: revokedAt},
+		},
+		"success - without jti": {
+			givenToken: tokenForTest("", pointerTo(revokedAt.Unix())),
+			mockStore:  mockStore{expSubjectCall: true},
+		},
+		"error - validation failed": {
+			givenErr: ErrTokenExpired,
+			expErr:   ErrTokenExpired,
+		},
+		"error - token revoked": {
+			givenToken: tokenForTest("this-is-uuid", pointerTo(revokedAt.Unix())),
+			mockStore:  mockStore{expTokenCall: true, outRevoked: true},
+			expErr:     ErrTokenRevoked,
+		},
+		"error - issued before subject revoked": {
+			givenToken: tokenForTest("this-is-uuid", pointerTo(revokedAt.Add(-time.Minute).Unix())),
+			mockStore:  mockStore{expTokenCall: true, expSubjectCall: true, outBefore: revokedAt},
+			expErr:     ErrTokenRevoked,
+		},
+		"error - subject revoked and token without iat": {
+			givenToken: tokenForTest("this-is-uuid", nil),
+			mockStore:  mockStore{expTokenCall: true, expSubjectCall: true, outBefore: revokedAt},
+			expErr:     ErrTokenRevoked,
+		},
+		"error - token store error": {
+			givenToken: tokenForTest("this-is-uuid", pointerTo(revokedAt.Unix())),
+			mockStore:  mockStore{expTokenCall: true, outTokenErr: errors.New("simulated error")},
+			expErr:     errors.New("simulated error"),
+		},
+		"error - subject store error": {
+			givenToken: tokenForTest("this-is-uuid", pointerTo(revokedAt.Unix())),
+			mockStore:  mockStore{expTokenCall: true, expSubjectCall: true, outSubjectErr: errors.New("simulated error")},
+			expErr:     errors.New("simulated error"),
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			next := NewMockValidator(t)
+			next.EXPECT().Validate("access-token").Return(tc.givenToken, tc.givenErr)
+
+			store := NewMockRevocationStore(t)
+			if tc.mockStore.expTokenCall {
+				store.EXPECT().IsTokenRevoked(mock.Anything, "this-is-uuid").Return(tc.mockStore.outRevoked, tc.mockStore.outTokenErr)
+			}
+			if tc.mockStore.expSubjectCall {
+				store.EXPECT().SubjectRevokedBefore(mock.Anything, "juso-shi@clients").Return(tc.mockStore.outBefore, tc.mockStore.outSubjectErr)
+			}
+
+			// When
+			tk, err := NewRevocationValidator(next, store).Validate("access-token")
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.givenToken, tk)
+			}
+		})
+	}
+}
+
+func TestRevoker(t *testing.T) {
+	staticTimeNow := time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC)
+
+	type mockStore struct {
+		expCall bool
+		ttl     time.Duration
+	}
+	tcs := map[string]struct {
+		givenJTI         string
+		givenExpiresAt   time.Time
+		givenZeroRevoker bool
+		mockStore        mockStore
+		expErr           error
+	}{
+		"success - revoked until token expiry": {
+			givenJTI:       "this-is-uuid",
+			givenExpiresAt: staticTimeNow.Add(10 * time.Minute),
+			mockStore:      mockStore{expCall: true, ttl: 10 * time.Minute},
+		},
+		"success - revoked for max token lifetime": {
+			givenJTI:  "this-is-uuid",
+			mockStore: mockStore{expCall: true, ttl: time.Hour},
+		},
+		"success - far future expiry is clamped to max token lifetime": {
+			givenJTI:       "this-is-uuid",
+			givenExpiresAt: staticTimeNow.AddDate(10, 0, 0),
+			mockStore:      mockStore{expCall: true, ttl: time.Hour},
+		},
+		"success - expired token is skipped": {
+			givenJTI:       "this-is-uuid",
+			givenExpiresAt: staticTimeNow.Add(-time.Minute),
+		},
+		"success - zero revoker with token expiry": {
+			givenJTI:         "this-is-uuid",
+			givenExpiresAt:   staticTimeNow.Add(10 * time.Minute),
+			givenZeroRevoker: true,
+			mockStore:        mockStore{expCall: true, ttl: 10 * time.Minute},
+		},
+		"error - missing jti": {
+			expErr: ErrMissingRequiredClaim,
+		},
+		"error - zero revoker without token expiry": {
+			givenJTI:         "this-is-uuid",
+			givenZeroRevoker: true,
+			expErr:           ErrInvalidTokenLifetime,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			defer func(origin func() time.Time) { timeNowFunc = origin }(timeNowFunc)
+			timeNowFunc = func() time.Time { return staticTimeNow }
+
+			ctx := context.Background()
+			store := NewMockRevocationStore(t)
+			if tc.mockStore.expCall {
+				store.EXPECT().RevokeToken(ctx, tc.givenJTI, tc.mockStore.ttl).Return(nil)
+			}
+
+			revoker, err := NewRevoker(store, time.Hour)
+			require.NoError(t, err)
+			if tc.givenZeroRevoker {
+				revoker = Revoker{store: store}
+			}
+
+			// When
+			err = revoker.RevokeToken(ctx, tc.givenJTI, tc.givenExpiresAt)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRevoker_RevokeSubject(t *testing.T) {
+	// Given
+	defer func(origin func() time.Time) { timeNowFunc = origin }(timeNowFunc)
+	staticTimeNow := time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC)
+	timeNowFunc = func() time.Time { return staticTimeNow }
+
+	ctx := context.Background()
+	store := NewMockRevocationStore(t)
+	store.EXPECT().RevokeSubject(ctx, "juso-shi@clients", staticTimeNow, time.Hour).Return(nil)
+	revoker, err := NewRevoker(store, time.Hour)
+	require.NoError(t, err)
+
+	// When & Then
+	require.NoError(t, revoker.RevokeSubject(ctx, "juso-shi@clients"))
+	require.ErrorIs(t, revoker.RevokeSubject(ctx, ""), ErrMissingRequiredClaim)
+	require.ErrorIs(t, Revoker{store: store}.RevokeSubject(ctx, "juso-shi@clients"), ErrInvalidTokenLifetime)
+}
@@ -3,12 +3,7 @@ package iam
 import (
 	"context"
 	"crypto"
-	"crypto/x509"
-	"encoding/json"
-	"encoding/pem"
-	"fmt"
-	"net/http"
-	"slices"
+	"errors"
 	"strings"
 	"time"
 
@@ -18,211 +13,76 @@ import (
 const (
 	jwkKeyUseSig = "sig" // JWK property `use` determines the JWK is for signature verification
 
-	jwkAlgRSA = "RSA" //
-
 	timeout = 30 * time.Second
-)
 
-var (
-	rfc9068AllowTokenTypes = []string{
-		"at+jwt",
-		"application/at+jwt",
-	}
+	rfc9068TokenType = "at+jwt" // The "application/" prefixed form is accepted as well
 )
 
 // rfc9068Validator represents validator for validate oauth2 JWT
 // Refer https://datatracker.ietf.org/doc/rfc9068/
 type rfc9068Validator struct {
-	// jwksURI contains the URL to fetch JSON Web Key set
-	jwksURI string
-
 	// issuer contains the address token issuer
 	issuer string
 
 	// audience contains the address of current service
 	audience string
 
-	// httpClient is client to call external service
-	httpClient HTTPClient
-
-	// signingKeyMap stores crypto.PublicKey
-	// it will be initial at the first time run
-	signingKeyMap map[string]crypto.PublicKey
+	// keySet provides the signing keys of the issuer, refreshed on key rotation
+	keySet KeySet
 
 	tokenParser jwt.Parser[Claims]
 }
 
 // Validate validates given claims
 func (v *rfc9068Validator) Validate(tokenString string) (jwt.Token[Claims], error) {
-	// 1. Parse token from string
+	// 1. Parse token from string, the parser policy verifies the "typ" header and the claims
 	tk, err := v.tokenParser.Parse(tokenString, v.getKeyFunc)
 	if err != nil {
-		return jwt.Token[Claims]{}, err
-	}
-
-	// 2. Verify the "typ" header value
-	if typ := tk.Header["typ"]; !slices.Contains(rfc9068AllowTokenTypes, typ) {
-		return jwt.Token[Claims]{}, ErrInvalidToken
+		return jwt.Token[Claims]{}, toValidationError(err)
 	}
 
 	// TODO: Support encrypted token
 
-	// 3. Verify claims information
-	if err := v.validateClaims(tk.Claims); err != nil {
-		return jwt.Token[Claims]{}, err
-	}
-
 	return tk, nil
 }
 
-func (v *rfc9068Validator) validateClaims(c Claims) error {
-	now := timeNowFunc()
+// rfc9068ParserOptions returns the parser policy of RFC 9068 section 4
+func rfc9068ParserOptions(issuer, audience string) []jwt.ParserOptions {
+	issuer = strings.TrimSuffix(issuer, "/")
 
-	// Verify issuer claim
-	if c.RegisteredClaims.Issuer == "" {
-		return ErrInvalidToken
-	}
-
-	if strings.TrimRight(c.RegisteredClaims.Issuer, "/") != v.issuer {
-		return ErrInvalidToken
-	}
-
-	// Verify expires_at claim
-	if c.RegisteredClaims.ExpiresAt == nil {
-		return ErrInvalidToken
+	return []jwt.ParserOptions{
+		jwt.WithTokenTypes(rfc9068TokenType),
+		jwt.WithIssuer(issuer, issuer+"/"),
+		jwt.WithAudience(audience),
+		jwt.WithRequiredClaims("sub", "client_id", "iat", "jti"),
+		jwt.WithClock(func() time.Time { return timeNowFunc() }),
 	}
+}
 
-	if exp := time.Unix(*c.RegisteredClaims.ExpiresAt, 0); now.After(exp) {
+// toValidationError maps the claim errors of the parser policy to the errors of the package
+func toValidationError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired),
+		errors.Is(err, jwt.ErrTokenTooOld):
 		return ErrTokenExpired
-	}
-
-	// Verify audience claim
-	if len(c.RegisteredClaims.Audience) == 0 || c.RegisteredClaims.Audience[0] == "" {
-		return ErrInvalidToken
-	}
-
-	foundAud := false
-	for _, aud := range c.RegisteredClaims.Audience {
-		if aud == v.audience {
-			foundAud = true
-		}
-	}
-
-	if !foundAud {
-		return ErrInvalidToken
-	}
-
-	// Verify subject claim
-	if c.RegisteredClaims.Subject == "" {
-		return ErrInvalidToken
-	}
-
-	// Verify client_id claim
-	if c.RegisteredClaims.ClientID == "" {
-		return ErrInvalidToken
-	}
-
-	// Verify iat claim
-	if c.RegisteredClaims.IssuedAt == nil {
-		return ErrInvalidToken
-	}
-
-	// Verify jti claim
-	if c.RegisteredClaims.JTI == "" {
+	case errors.Is(err, jwt.ErrMissingRequiredClaim),
+		errors.Is(err, jwt.ErrInvalidIssuer),
+		errors.Is(err, jwt.ErrInvalidAudience),
+		errors.Is(err, jwt.ErrInvalidTokenType),
+		errors.Is(err, jwt.ErrTokenNotValidYet),
+		errors.Is(err, jwt.ErrTokenUsedBeforeIssued),
+		errors.Is(err, jwt.ErrSigningMethodNotAllowed):
 		return ErrInvalidToken
+	default:
+		return err
 	}
-
-	return nil
 }
 
 func (v *rfc9068Validator) getKeyFunc(keyID string) (crypto.PublicKey, error) {
-	key, exists := v.signingKeyMap[keyID]
-	if !exists {
+	key, err := v.keySet.Key(context.Background(), keyID)
+	if err != nil {
 		return nil, ErrInvalidToken
 	}
 
 	return key, nil
 }
-
-func (v *rfc9068Validator) downloadSigningKey(ctx context.Context) error {
-	keyset, err := v.fetchJWKS(ctx)
-	if err != nil {
-		return err
-	}
-
-	singingKeyMap, err := v.processJWKS(ctx, keyset)
-	if err != nil {
-		return err
-	}
-
-	v.signingKeyMap = singingKeyMap
-
-	return nil
-}
-
-func (v *rfc9068Validator) fetchJWKS(ctx context.Context) (JWKSet, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURI, nil)
-	if err != nil {
-		return JWKSet{}, err
-	}
-
-	resp, err := v.httpClient.Do(req)
-	if err != nil {
-		return JWKSet{}, err
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		return JWKSet{}, fmt.Errorf("got unexpected status code: %d", resp.StatusCode)
-	}
-
-	var jwks JWKSet
-	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
-		return JWKSet{}, fmt.Errorf("could not decode jwks: %w", err)
-	}
-
-	return jwks, nil
-}
-
-func (v *rfc9068Validator) processJWKS(ctx context.Context, jwks JWKSet) (map[string]crypto.PublicKey, error) {
-	signingKeyMap := map[string]crypto.PublicKey{}
-
-	for _, k := range jwks.Keys {
-		if k.Use != jwkKeyUseSig {
-			continue
-		}
-
-		if k.Kty != jwkAlgRSA {
-			continue
-		}
-
-		if k.KID == "" {
-			continue
-		}
-
-		if len(k.X5c) < 1 { // If no certificate chain then skip
-			continue
-		}
-
-		// pre-computing the signing key from the JWK and cert so that it does not need to be done again once cached
-		pemBlock, _ := pem.Decode([]byte("-----BEGIN CERTIFICATE-----\n" + k.X5c[0] + "\n-----END CERTIFICATE-----"))
-		if pemBlock == nil {
-			return nil, fmt.Errorf("could not parse certificate PEM")
-		}
-
-		cert, err := x509.ParseCertificate(pemBlock.Bytes)
-		if err != nil {
-			return nil, err
-		}
-
-		signingKeyMap[k.KID] = cert.PublicKey
-	}
-
-	if len(signingKeyMap) == 0 {
-		return nil, fmt.Errorf("no appropriate JWK found")
-	}
-
-	return signingKeyMap, nil
-}
@@ -1,7 +1,10 @@
 package iam
 
 import (
+	"context"
+	"crypto"
 	"net/http"
+	"time"
 
 	"github.com/viebiz/lit/jwt"
 )
@@ -19,6 +22,27 @@ type HTTPClient interface {
 // ExpressionFunction defines a custom function for Casbin models, compatible with govaluate.ExpressionFunction.
 type ExpressionFunction func(arguments ...interface{}) (interface{}, error)
 
+// KeySet provides the keys verifying the token signatures by their `kid`, e.g. JWKSCache
+type KeySet interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+// RevocationStore persists the revoked tokens by their `jti` and the revocations of all tokens of a subject.
+// The entries expire by the given TTL, so the store is bounded by the tokens that are not expired yet
+type RevocationStore interface {
+	// RevokeToken revokes the token of the jti for the ttl, usually the remaining lifetime of the token
+	RevokeToken(ctx context.Context, jti string, ttl time.Duration) error
+
+	// IsTokenRevoked returns true if the token of the jti is revoked
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+
+	// RevokeSubject revokes the tokens of the subject issued before the given time for the ttl, usually the max token lifetime
+	RevokeSubject(ctx context.Context, sub string, before time.Time, ttl time.Duration) error
+
+	// SubjectRevokedBefore returns the time the tokens of the subject issued before are revoked, zero time if none
+	SubjectRevokedBefore(ctx context.Context, sub string) (time.Time, error)
+}
+
 type Validator interface {
 	Validate(tokenString string) (jwt.Token[Claims], error)
 }
@@ -1,7 +1,6 @@
 package iam
 
 import (
-	"fmt"
 	"maps"
 	"slices"
 	"strings"
@@ -42,43 +41,5 @@ func (p UserProfile) GetProfile() map[string]any {
 }
 
 func extractRolesFromClaims(claims Claims) ([]string, error) {
-	rolesClaim, exists := claims.ExtraClaims[roleClaimKey]
-	if !exists {
-		return nil, ErrMissingRequiredClaim
-	}
-
-	switch v := rolesClaim.(type) {
-	case string:
-		return strings.Split(v, ","), nil
-	case []string:
-		return v, nil
-	case []interface{}:
-		rs := make([]string, len(v))
-		for idx, item := range v {
-			role, ok := item.(string)
-			if !ok {
-				role = fmt.Sprintf("%v", item)
-			}
-
-			rs[idx] = role
-		}
-
-		return rs, nil
-	default:
-		return nil, ErrInvalidToken
-	}
-}
-
-func extractUserProfileFromClaims(claims Claims) map[string]any {
-	pf, exists := claims.ExtraClaims[profileClaimKey]
-	if !exists {
-		return nil
-	}
-
-	pfMap, ok := pf.(map[string]any)
-	if !ok {
-		return nil
-	}
-
-	return pfMap
+	return extractClaimValues(claims, []string{roleClaimKey}, nil, splitCommaValues)
 }
@@ -49,7 +49,67 @@ func NewRS512() RSA {
 	}
 }
 
-// NewParser creates a new Parser with the default signing methods and validator.
+// NewES256 creates a new ES256 signing method struct, it uses P-256 keys
+func NewES256() ECDSA {
+	return ECDSA{
+		Name:      SigningMethodNameES256,
+		Hash:      crypto.SHA256,
+		KeySize:   32,
+		CurveBits: 256,
+	}
+}
+
+// NewES384 creates a new ES384 signing method struct, it uses P-384 keys
+func NewES384() ECDSA {
+	return ECDSA{
+		Name:      SigningMethodNameES384,
+		Hash:      crypto.SHA384,
+		KeySize:   48,
+		CurveBits: 384,
+	}
+}
+
+// NewES512 creates a new ES512 signing method struct, it uses P-521 keys
+func NewES512() ECDSA {
+	return ECDSA{
+		Name:      SigningMethodNameES512,
+		Hash:      crypto.SHA512,
+		KeySize:   66,
+		CurveBits: 521,
+	}
+}
+
+// NewPS256 creates a new PS256 signing method struct
+func NewPS256() RSAPSS {
+	return RSAPSS{
+		Name: SigningMethodNamePS256,
+		Hash: crypto.SHA256,
+	}
+}
+
+// NewPS384 creates a new PS384 signing method struct
+func NewPS384() RSAPSS {
+	return RSAPSS{
+		Name: SigningMethodNamePS384,
+		Hash: crypto.SHA384,
+	}
+}
+
+// NewPS512 creates a new PS512 signing method struct
+func NewPS512() RSAPSS {
+	return RSAPSS{
+		Name: SigningMethodNamePS512,
+		Hash: crypto.SHA512,
+	}
+}
+
+// NewEdDSA creates a new EdDSA signing method struct, it uses Ed25519 keys
+func NewEdDSA() EdDSA {
+	return EdDSA{}
+}
+
+// NewParser creates a new Parser with the default signing methods and validation policy, that requires the `exp` claim.
+// The policy is tuned by the options, e.g. WithIssuer, WithAudience, WithLeeway.
 func NewParser[T Claims](opts ...ParserOptions) Parser[T] {
 	p := NewDefaultParser[T]()
 	for _, opt := range opts {
@@ -68,6 +128,13 @@ func NewDefaultParser[T Claims]() Parser[T] {
 			SigningMethodNameHS256: NewHS256(),
 			SigningMethodNameHS384: NewHS384(),
 			SigningMethodNameHS512: NewHS512(),
+			SigningMethodNameES256: NewES256(),
+			SigningMethodNameES384: NewES384(),
+			SigningMethodNameES512: NewES512(),
+			SigningMethodNamePS256: NewPS256(),
+			SigningMethodNamePS384: NewPS384(),
+			SigningMethodNamePS512: NewPS512(),
+			SigningMethodNameEdDSA: NewEdDSA(),
 		},
 	}
 }
@@ -75,9 +142,9 @@ func NewDefaultParser[T Claims]() Parser[T] {
 // NewToken creates a new Token with the specified signing method and claims
 func NewToken[T Claims](method SigningMethod, claims T) Token[T] {
 	tk := Token[T]{
-		Header: map[string]string{
-			"typ": tokenHeaderType,
-			"alg": method.Alg(),
+		Header: Header{
+			Typ: tokenHeaderType,
+			Alg: method.Alg(),
 		},
 		Claims:        claims,
 		signingMethod: method,
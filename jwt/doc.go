@@ -1,6 +1,7 @@
 // Package jwt provided method for signing and verify JWT
 //
-// Supported signing methods: RSA, HMAC, ECDSA
+// Supported signing methods: HMAC (HS256/384/512), RSA (RS256/384/512), RSA-PSS (PS256/384/512), ECDSA (ES256/384/512)
+// and EdDSA (Ed25519)
 //
 // Usage example:
 //
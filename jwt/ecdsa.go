@@ -0,0 +1,80 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+)
+
+const (
+	SigningMethodNameES256 string = "ES256"
+	SigningMethodNameES384 string = "ES384"
+	SigningMethodNameES512 string = "ES512"
+)
+
+// ECDSA implements the ECDSA family of signing methods, the signature is the R and S values
+// concatenated in KeySize bytes each as RFC 7518 section 3.4
+type ECDSA struct {
+	Name      string
+	Hash      crypto.Hash
+	KeySize   int // Size in bytes of R and S
+	CurveBits int // Bit size of the curve, the key must be on this curve
+}
+
+// Sign implements token signing for the SigningMethod, that take Signer (*ecdsa.PrivateKey) for sign a token
+func (sm ECDSA) Sign(signingString []byte, key Signer) ([]byte, error) {
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || privateKey.Curve == nil || privateKey.Curve.Params().BitSize != sm.CurveBits {
+		return nil, ErrInvalidKeyType
+	}
+
+	if !sm.Hash.Available() {
+		return nil, ErrHashUnavailable
+	}
+
+	hash := sm.Hash.New()
+	hash.Write(signingString)
+
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hash.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	sig := make([]byte, 2*sm.KeySize)
+	r.FillBytes(sig[:sm.KeySize])
+	s.FillBytes(sig[sm.KeySize:])
+
+	return sig, nil
+}
+
+// Verify verifies the signingString with signature by provided VerifyKey, that can consider as *ecdsa.PublicKey
+func (sm ECDSA) Verify(signingString []byte, sig []byte, key VerifyKey) error {
+	publicKey, ok := key.(*ecdsa.PublicKey)
+	if !ok || publicKey.Curve == nil || publicKey.Curve.Params().BitSize != sm.CurveBits {
+		return ErrInvalidKeyType
+	}
+
+	if len(sig) != 2*sm.KeySize {
+		return ErrInvalidSignature
+	}
+
+	if !sm.Hash.Available() {
+		return ErrHashUnavailable
+	}
+
+	hash := sm.Hash.New()
+	hash.Write(signingString)
+
+	r := new(big.Int).SetBytes(sig[:sm.KeySize])
+	s := new(big.Int).SetBytes(sig[sm.KeySize:])
+	if !ecdsa.Verify(publicKey, hash.Sum(nil), r, s) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (sm ECDSA) Alg() string {
+	return sm.Name
+}
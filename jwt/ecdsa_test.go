@@ -0,0 +1,165 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/viebiz/lit/ioutil"
+)
+
+func TestSigningMethodECDSA_SignAndVerify(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	p256Key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	p384Key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p384_private_key")
+	p521Key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p521_private_key")
+
+	tcs := map[string]struct {
+		method     ECDSA
+		signingKey Signer
+		verifyKey  VerifyKey
+		expSigLen  int
+	}{
+		"success - ES256": {
+			method:     NewES256(),
+			signingKey: p256Key,
+			verifyKey:  p256Key.Public(),
+			expSigLen:  64,
+		},
+		"success - ES384": {
+			method:     NewES384(),
+			signingKey: p384Key,
+			verifyKey:  p384Key.Public(),
+			expSigLen:  96,
+		},
+		"success - ES512": {
+			method:     NewES512(),
+			signingKey: p521Key,
+			verifyKey:  p521Key.Public(),
+			expSigLen:  132,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			signingString := []byte("developers-prefer-dark-mode-because-light-attracts-bugs")
+
+			// When
+			sig, err := tc.method.Sign(signingString, tc.signingKey)
+			require.NoError(t, err)
+
+			// Then
+			require.Len(t, sig, tc.expSigLen)
+			require.NoError(t, tc.method.Verify(signingString, sig, tc.verifyKey))
+		})
+	}
+}
+
+func TestSigningMethodECDSA_Sign(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	p384Key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p384_private_key")
+	rsaKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+
+	tcs := map[string]struct {
+		key    Signer
+		expErr error
+	}{
+		"error - key type not supported": {
+			key:    rsaKey,
+			expErr: ErrInvalidKeyType,
+		},
+		"error - curve not match": {
+			key:    p384Key,
+			expErr: ErrInvalidKeyType,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			method := NewES256()
+
+			// When
+			_, err := method.Sign([]byte("developers-prefer-dark-mode-because-light-attracts-bugs"), tc.key)
+
+			// Then
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestSigningMethodECDSA_Verify(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	p256Key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	p384Key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p384_private_key")
+	rsaKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+
+	signingString := []byte("developers-prefer-dark-mode-because-light-attracts-bugs")
+	sig, err := NewES256().Sign(signingString, p256Key)
+	require.NoError(t, err)
+
+	tamperedSig := append([]byte{}, sig...)
+	tamperedSig[10] ^= 0xff
+
+	tcs := map[string]struct {
+		signingString []byte
+		sig           []byte
+		key           VerifyKey
+		expErr        error
+	}{
+		"success": {
+			signingString: signingString,
+			sig:           sig,
+			key:           p256Key.Public(),
+		},
+		"error - invalid key type": {
+			signingString: signingString,
+			sig:           sig,
+			key:           rsaKey.Public(),
+			expErr:        ErrInvalidKeyType,
+		},
+		"error - curve not match": {
+			signingString: signingString,
+			sig:           sig,
+			key:           p384Key.Public(),
+			expErr:        ErrInvalidKeyType,
+		},
+		"error - signature length not match": {
+			signingString: signingString,
+			sig:           sig[:63],
+			key:           p256Key.Public(),
+			expErr:        ErrInvalidSignature,
+		},
+		"error - tampered signature": {
+			signingString: signingString,
+			sig:           tamperedSig,
+			key:           p256Key.Public(),
+			expErr:        ErrInvalidSignature,
+		},
+		"error - tampered signing string": {
+			signingString: []byte("developers-prefer-light-mode"),
+			sig:           sig,
+			key:           p256Key.Public(),
+			expErr:        ErrInvalidSignature,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			method := NewES256()
+
+			// When
+			err := method.Verify(tc.signingString, tc.sig, tc.key)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
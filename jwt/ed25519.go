@@ -0,0 +1,61 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+)
+
+const (
+	SigningMethodNameEdDSA string = "EdDSA"
+)
+
+// EdDSA implements the EdDSA signing method with Ed25519 keys as RFC 8037
+type EdDSA struct{}
+
+// Sign implements token signing for the SigningMethod, that take Signer (ed25519.PrivateKey) for sign a token
+func (sm EdDSA) Sign(signingString []byte, key Signer) ([]byte, error) {
+	var privateKey ed25519.PrivateKey
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		privateKey = k
+	case *ed25519.PrivateKey:
+		privateKey = *k
+	default:
+		return nil, ErrInvalidKeyType
+	}
+
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, ErrInvalidKeyType
+	}
+
+	// Ed25519 signs the message itself, crypto.Hash(0) tells the message is not hashed
+	return privateKey.Sign(rand.Reader, signingString, crypto.Hash(0))
+}
+
+// Verify verifies the signingString with signature by provided VerifyKey, that can consider as ed25519.PublicKey
+func (sm EdDSA) Verify(signingString []byte, sig []byte, key VerifyKey) error {
+	var publicKey ed25519.PublicKey
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		publicKey = k
+	case *ed25519.PublicKey:
+		publicKey = *k
+	default:
+		return ErrInvalidKeyType
+	}
+
+	if len(publicKey) != ed25519.PublicKeySize {
+		return ErrInvalidKeyType
+	}
+
+	if !ed25519.Verify(publicKey, signingString, sig) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (sm EdDSA) Alg() string {
+	return SigningMethodNameEdDSA
+}
@@ -0,0 +1,106 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/viebiz/lit/ioutil"
+)
+
+func TestSigningMethodEdDSA_Sign(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	key := readKeyForTest[ed25519.PrivateKey](t, "sample_ed25519_private_key")
+	rsaKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+
+	tcs := map[string]struct {
+		key    Signer
+		expErr error
+	}{
+		"success": {
+			key: key,
+		},
+		"success - pointer key": {
+			key: &key,
+		},
+		"error - key type not supported": {
+			key:    rsaKey,
+			expErr: ErrInvalidKeyType,
+		},
+		"error - key size not match": {
+			key:    ed25519.PrivateKey(key[:32]),
+			expErr: ErrInvalidKeyType,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			method := NewEdDSA()
+			signingString := []byte("developers-prefer-dark-mode-because-light-attracts-bugs")
+
+			// When
+			sig, err := method.Sign(signingString, tc.key)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+				// Ed25519 signatures are deterministic
+				require.Equal(t, ed25519.Sign(key, signingString), sig)
+			}
+		})
+	}
+}
+
+func TestSigningMethodEdDSA_Verify(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	key := readKeyForTest[ed25519.PrivateKey](t, "sample_ed25519_private_key")
+	rsaKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+
+	signingString := []byte("developers-prefer-dark-mode-because-light-attracts-bugs")
+	sig := ed25519.Sign(key, signingString)
+
+	tamperedSig := append([]byte{}, sig...)
+	tamperedSig[0] ^= 0xff
+
+	tcs := map[string]struct {
+		sig    []byte
+		key    VerifyKey
+		expErr error
+	}{
+		"success": {
+			sig: sig,
+			key: key.Public(),
+		},
+		"error - invalid key type": {
+			sig:    sig,
+			key:    rsaKey.Public(),
+			expErr: ErrInvalidKeyType,
+		},
+		"error - tampered signature": {
+			sig:    tamperedSig,
+			key:    key.Public(),
+			expErr: ErrInvalidSignature,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			method := NewEdDSA()
+
+			// When
+			err := method.Verify(signingString, tc.sig, tc.key)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 var (
 	ErrInvalidKeyType = errors.New("invalid key type")
 
+	ErrInvalidKey = errors.New("invalid key")
+
 	ErrHashUnavailable = errors.New("unavailable hash function")
 
 	ErrTokenMalformed = errors.New("malformed token")
@@ -24,4 +26,20 @@ var (
 	ErrTokenUsedBeforeIssued = errors.New("token is used before issued")
 
 	ErrTokenNotValidYet = errors.New("token is not valid yet")
+
+	ErrTokenTooOld = errors.New("token is too old")
+
+	ErrInvalidIssuer = errors.New("invalid token issuer")
+
+	ErrInvalidAudience = errors.New("invalid token audience")
+
+	ErrInvalidTokenType = errors.New("invalid token type")
+
+	ErrSigningMethodNotAllowed = errors.New("signing method not allowed")
+
+	ErrUnsupportedCriticalHeader = errors.New("unsupported critical header")
+
+	ErrEncryptionNotSupported = errors.New("encryption algorithm not supported")
+
+	ErrDecryptionFailed = errors.New("failed to decrypt token")
 )
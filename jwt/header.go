@@ -0,0 +1,176 @@
+package jwt
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// Header represents the JOSE header of a JWS or a JWE, the members not registered by the specs are kept in Extensions
+// Refer https://datatracker.ietf.org/doc/html/rfc7515#section-4 and https://datatracker.ietf.org/doc/html/rfc7516#section-4
+type Header struct {
+	Alg     string   // Algorithm, e.g. RS256 for a JWS, RSA-OAEP for a JWE
+	Typ     string   // Type, e.g. JWT, at+jwt
+	Cty     string   // Content type, JWT for the nested tokens
+	Kid     string   // Key ID
+	Crit    []string // Extensions that must be understood and processed
+	JKU     string   // JWK Set URL
+	JWK     *JWK     // JSON Web Key
+	X5U     string   // X.509 URL
+	X5c     []string // X.509 certificate chain
+	X5t     string   // X.509 certificate SHA-1 thumbprint
+	X5tS256 string   // X.509 certificate SHA-256 thumbprint
+	Enc     string   // Content encryption algorithm of a JWE, e.g. A256GCM
+	Zip     string   // Compression algorithm of a JWE
+	EPK     *JWK     // Ephemeral public key of ECDH-ES
+	APU     string   // Agreement PartyUInfo of ECDH-ES
+	APV     string   // Agreement PartyVInfo of ECDH-ES
+
+	// Extensions contains the raw JSON of the members not listed above
+	Extensions map[string]json.RawMessage
+}
+
+// registeredHeaderNames are the header members defined by RFC 7515, RFC 7516 and RFC 7518, they can't be listed in `crit`
+var registeredHeaderNames = []string{
+	"alg", "typ", "cty", "kid", "crit", "jku", "jwk", "x5u", "x5c", "x5t", "x5t#S256",
+	"enc", "zip", "epk", "apu", "apv", "iv", "tag", "p2s", "p2c",
+}
+
+// Extension decodes the extension member into v, it returns false when the header does not have the member
+func (h Header) Extension(name string, v any) (bool, error) {
+	raw, ok := h.Extensions[name]
+	if !ok {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(raw, v)
+}
+
+// SetExtension sets the extension member to the JSON encoding of v
+func (h *Header) SetExtension(name string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if h.Extensions == nil {
+		h.Extensions = map[string]json.RawMessage{}
+	}
+	h.Extensions[name] = raw
+
+	return nil
+}
+
+// MarshalJSON encodes the header with its members sorted by name
+func (h Header) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(h.Extensions)+4)
+	for name, raw := range h.Extensions {
+		members[name] = raw
+	}
+
+	setString := func(name, value string) {
+		if value != "" {
+			members[name] = value
+		}
+	}
+	setString("alg", h.Alg)
+	setString("typ", h.Typ)
+	setString("cty", h.Cty)
+	setString("kid", h.Kid)
+	setString("jku", h.JKU)
+	setString("x5u", h.X5U)
+	setString("x5t", h.X5t)
+	setString("x5t#S256", h.X5tS256)
+	setString("enc", h.Enc)
+	setString("zip", h.Zip)
+	setString("apu", h.APU)
+	setString("apv", h.APV)
+
+	if h.Crit != nil {
+		members["crit"] = h.Crit
+	}
+	if h.JWK != nil {
+		members["jwk"] = h.JWK
+	}
+	if h.X5c != nil {
+		members["x5c"] = h.X5c
+	}
+	if h.EPK != nil {
+		members["epk"] = h.EPK
+	}
+
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON decodes the header, a registered member of an unexpected type fails the decoding
+func (h *Header) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	var decoded Header
+	fields := map[string]any{
+		"alg":      &decoded.Alg,
+		"typ":      &decoded.Typ,
+		"cty":      &decoded.Cty,
+		"kid":      &decoded.Kid,
+		"crit":     &decoded.Crit,
+		"jku":      &decoded.JKU,
+		"jwk":      &decoded.JWK,
+		"x5u":      &decoded.X5U,
+		"x5c":      &decoded.X5c,
+		"x5t":      &decoded.X5t,
+		"x5t#S256": &decoded.X5tS256,
+		"enc":      &decoded.Enc,
+		"zip":      &decoded.Zip,
+		"epk":      &decoded.EPK,
+		"apu":      &decoded.APU,
+		"apv":      &decoded.APV,
+	}
+
+	for name, raw := range members {
+		field, ok := fields[name]
+		if !ok {
+			if decoded.Extensions == nil {
+				decoded.Extensions = map[string]json.RawMessage{}
+			}
+			decoded.Extensions[name] = raw
+			continue
+		}
+
+		if err := json.Unmarshal(raw, field); err != nil {
+			return ErrTokenMalformed
+		}
+	}
+
+	*h = decoded
+	return nil
+}
+
+// validateCritical checks the `crit` header as RFC 7515 section 4.1.11, the listed extensions must be present
+// in the header and understood by the caller
+func (h Header) validateCritical(understood []string) error {
+	if h.Crit == nil {
+		return nil
+	}
+
+	if len(h.Crit) == 0 {
+		return ErrInvalidToken
+	}
+
+	for _, name := range h.Crit {
+		if slices.Contains(registeredHeaderNames, name) {
+			return ErrInvalidToken
+		}
+
+		if _, ok := h.Extensions[name]; !ok {
+			return ErrInvalidToken
+		}
+
+		if !slices.Contains(understood, name) {
+			return ErrUnsupportedCriticalHeader
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/viebiz/lit/ioutil"
+)
+
+func TestHeader_UnmarshalJSON(t *testing.T) {
+	tcs := map[string]struct {
+		given     string
+		expResult Header
+		expErr    error
+	}{
+		"success": {
+			given: `{"alg":"ES256","typ":"JWT","kid":"key-01","crit":["exp"],"exp":1721782800,"x5c":["MIIB"],"jwk":{"kty":"EC","crv":"P-256","x":"eA","y":"eQ"}}`,
+			expResult: Header{
+				Alg:  "ES256",
+				Typ:  "JWT",
+				Kid:  "key-01",
+				Crit: []string{"exp"},
+				X5c:  []string{"MIIB"},
+				JWK:  &JWK{Kty: "EC", Crv: "P-256", X: "eA", Y: "eQ"},
+				Extensions: map[string]json.RawMessage{
+					"exp": json.RawMessage("1721782800"),
+				},
+			},
+		},
+		"error - registered member type not match": {
+			given:  `{"alg":"ES256","crit":"exp"}`,
+			expErr: ErrTokenMalformed,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			var header Header
+
+			// When
+			err := json.Unmarshal([]byte(tc.given), &header)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, header)
+			}
+		})
+	}
+}
+
+func TestHeader_MarshalJSON(t *testing.T) {
+	// Given
+	header := Header{Alg: "RS256", Typ: "JWT", X5c: []string{"MIIB"}}
+	require.NoError(t, header.SetExtension("b64", false))
+
+	// When
+	b, err := json.Marshal(header)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, `{"alg":"RS256","b64":false,"typ":"JWT","x5c":["MIIB"]}`, string(b))
+
+	var b64 bool
+	found, err := header.Extension("b64", &b64)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.False(t, b64)
+}
+
+func TestParser_Parse_CriticalHeaders(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	keyFunc := func(string) (crypto.PublicKey, error) {
+		return key.Public(), nil
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tcs := map[string]struct {
+		givenCrit       []string
+		givenExtensions map[string]any
+		givenOpts       []ParserOptions
+		expErr          error
+	}{
+		"success": {
+			givenCrit:       []string{"urn:example:tenant"},
+			givenExtensions: map[string]any{"urn:example:tenant": "mukagen"},
+			givenOpts:       []ParserOptions{WithCriticalHeaders("urn:example:tenant")},
+		},
+		"success - extension not critical": {
+			givenExtensions: map[string]any{"urn:example:tenant": "mukagen"},
+		},
+		"error - extension not understood": {
+			givenCrit:       []string{"urn:example:tenant"},
+			givenExtensions: map[string]any{"urn:example:tenant": "mukagen"},
+			expErr:          ErrUnsupportedCriticalHeader,
+		},
+		"error - extension not present": {
+			givenCrit: []string{"urn:example:tenant"},
+			givenOpts: []ParserOptions{WithCriticalHeaders("urn:example:tenant")},
+			expErr:    ErrInvalidToken,
+		},
+		"error - registered header listed": {
+			givenCrit: []string{"alg"},
+			expErr:    ErrInvalidToken,
+		},
+		"error - empty list": {
+			givenCrit: []string{},
+			expErr:    ErrInvalidToken,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			tk := NewToken(NewES256(), RegisteredClaims{ExpiresAt: &exp})
+			tk.Header.Crit = tc.givenCrit
+			for name, v := range tc.givenExtensions {
+				require.NoError(t, tk.Header.SetExtension(name, v))
+			}
+
+			tokenString, err := tk.SignedString(key)
+			require.NoError(t, err)
+
+			// When
+			parsed, err := NewParser[RegisteredClaims](tc.givenOpts...).Parse(tokenString, keyFunc)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tk.Header, parsed.Header)
+			}
+		})
+	}
+}
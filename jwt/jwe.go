@@ -0,0 +1,315 @@
+package jwt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"hash"
+	"strings"
+)
+
+const (
+	KeyAlgorithmRSAOAEP    string = "RSA-OAEP"
+	KeyAlgorithmRSAOAEP256 string = "RSA-OAEP-256"
+	KeyAlgorithmECDHES     string = "ECDH-ES"
+	KeyAlgorithmDirect     string = "dir"
+
+	EncryptionA128GCM string = "A128GCM"
+	EncryptionA192GCM string = "A192GCM"
+	EncryptionA256GCM string = "A256GCM"
+
+	gcmIVSize  = 12
+	gcmTagSize = 16
+)
+
+// Encrypt encrypts the plaintext into a JWE compact serialization as RFC 7516. The header Alg selects the key
+// management algorithm and Enc the content encryption, the other members, e.g. Kid, Cty, are kept as-is.
+//
+// The key is the recipient key:
+//   - RSA-OAEP, RSA-OAEP-256: *rsa.PublicKey
+//   - ECDH-ES: *ecdsa.PublicKey on P-256, P-384 or P-521
+//   - dir: []byte of the content encryption key size
+//
+// For a nested JWT, encrypt the signed token with Cty set to "JWT"
+func Encrypt(plaintext []byte, header Header, key any) (string, error) {
+	if header.Zip != "" {
+		return "", ErrEncryptionNotSupported
+	}
+
+	cekSize, err := contentKeySize(header.Enc)
+	if err != nil {
+		return "", err
+	}
+
+	var cek, encryptedKey []byte
+	switch header.Alg {
+	case KeyAlgorithmRSAOAEP, KeyAlgorithmRSAOAEP256:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return "", ErrInvalidKeyType
+		}
+
+		cek = make([]byte, cekSize)
+		if _, err := rand.Read(cek); err != nil {
+			return "", err
+		}
+
+		if encryptedKey, err = rsa.EncryptOAEP(oaepHash(header.Alg), rand.Reader, pub, cek, nil); err != nil {
+			return "", err
+		}
+	case KeyAlgorithmECDHES:
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return "", ErrInvalidKeyType
+		}
+
+		recipient, err := pub.ECDH()
+		if err != nil {
+			return "", ErrInvalidKeyType
+		}
+
+		ephemeral, err := ecdsa.GenerateKey(pub.Curve, rand.Reader)
+		if err != nil {
+			return "", err
+		}
+		epk := newECPublicJWK(&ephemeral.PublicKey)
+		header.EPK = &epk
+
+		if cek, err = deriveECDHESKey(ephemeral, recipient, header, cekSize); err != nil {
+			return "", err
+		}
+	case KeyAlgorithmDirect:
+		secret, ok := key.([]byte)
+		if !ok || len(secret) != cekSize {
+			return "", ErrInvalidKeyType
+		}
+
+		cek = secret
+	default:
+		return "", ErrEncryptionNotSupported
+	}
+
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	protected := encodeSegment(headerBytes)
+
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return "", err
+	}
+
+	iv := make([]byte, gcmIVSize)
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	// The protected header in its encoded form is the additional authenticated data
+	sealed := gcm.Seal(nil, iv, plaintext, protected)
+	ciphertext, tag := sealed[:len(sealed)-gcmTagSize], sealed[len(sealed)-gcmTagSize:]
+
+	return strings.Join([]string{
+		string(protected),
+		string(encodeSegment(encryptedKey)),
+		string(encodeSegment(iv)),
+		string(encodeSegment(ciphertext)),
+		string(encodeSegment(tag)),
+	}, "."), nil
+}
+
+// Decrypt decrypts the JWE compact serialization by the recipient private key, it returns the protected header
+// and the plaintext. The key is *rsa.PrivateKey for RSA-OAEP and RSA-OAEP-256, *ecdsa.PrivateKey for ECDH-ES
+// and the []byte content encryption key for dir. Any decryption failure is reported as ErrDecryptionFailed
+func Decrypt(tokenString string, key any) (Header, []byte, error) {
+	parts := strings.Split(tokenString, ".")
+	if len(parts) != 5 {
+		return Header{}, nil, ErrTokenMalformed
+	}
+
+	segments := make([][]byte, len(parts))
+	for idx, part := range parts {
+		decoded, err := decodeSegment([]byte(part))
+		if err != nil {
+			return Header{}, nil, ErrTokenMalformed
+		}
+		segments[idx] = decoded
+	}
+	encryptedKey, iv, ciphertext, tag := segments[1], segments[2], segments[3], segments[4]
+
+	var header Header
+	if err := json.Unmarshal(segments[0], &header); err != nil {
+		return Header{}, nil, ErrTokenMalformed
+	}
+
+	if err := header.validateCritical(nil); err != nil {
+		return Header{}, nil, err
+	}
+
+	if header.Zip != "" {
+		return Header{}, nil, ErrEncryptionNotSupported
+	}
+
+	cekSize, err := contentKeySize(header.Enc)
+	if err != nil {
+		return Header{}, nil, err
+	}
+
+	var cek []byte
+	switch header.Alg {
+	case KeyAlgorithmRSAOAEP, KeyAlgorithmRSAOAEP256:
+		priv, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return Header{}, nil, ErrInvalidKeyType
+		}
+
+		// A random key is used when the decryption fails, so the failure can't be told apart from a wrong tag
+		// and the RSA decryption can't be used as an oracle, RFC 7516 section 11.5
+		cek = make([]byte, cekSize)
+		if _, err := rand.Read(cek); err != nil {
+			return Header{}, nil, err
+		}
+		if decrypted, err := rsa.DecryptOAEP(oaepHash(header.Alg), nil, priv, encryptedKey, nil); err == nil {
+			subtle.ConstantTimeCopy(subtle.ConstantTimeEq(int32(len(decrypted)), int32(cekSize)), cek, padOrTruncate(decrypted, cekSize))
+		}
+	case KeyAlgorithmECDHES:
+		priv, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return Header{}, nil, ErrInvalidKeyType
+		}
+
+		if len(encryptedKey) != 0 || header.EPK == nil {
+			return Header{}, nil, ErrDecryptionFailed
+		}
+
+		epk, err := header.EPK.ecdsaPublicKey()
+		if err != nil || epk.Curve != priv.Curve {
+			return Header{}, nil, ErrDecryptionFailed
+		}
+
+		sender, err := epk.ECDH()
+		if err != nil {
+			return Header{}, nil, ErrDecryptionFailed
+		}
+
+		if cek, err = deriveECDHESKey(priv, sender, header, cekSize); err != nil {
+			return Header{}, nil, ErrDecryptionFailed
+		}
+	case KeyAlgorithmDirect:
+		secret, ok := key.([]byte)
+		if !ok || len(secret) != cekSize {
+			return Header{}, nil, ErrInvalidKeyType
+		}
+
+		if len(encryptedKey) != 0 {
+			return Header{}, nil, ErrDecryptionFailed
+		}
+
+		cek = secret
+	default:
+		return Header{}, nil, ErrEncryptionNotSupported
+	}
+
+	if len(iv) != gcmIVSize || len(tag) != gcmTagSize {
+		return Header{}, nil, ErrDecryptionFailed
+	}
+
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return Header{}, nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, iv, append(ciphertext, tag...), []byte(parts[0]))
+	if err != nil {
+		return Header{}, nil, ErrDecryptionFailed
+	}
+
+	return header, plaintext, nil
+}
+
+func contentKeySize(enc string) (int, error) {
+	switch enc {
+	case EncryptionA128GCM:
+		return 16, nil
+	case EncryptionA192GCM:
+		return 24, nil
+	case EncryptionA256GCM:
+		return 32, nil
+	default:
+		return 0, ErrEncryptionNotSupported
+	}
+}
+
+func oaepHash(alg string) hash.Hash {
+	if alg == KeyAlgorithmRSAOAEP256 {
+		return sha256.New()
+	}
+
+	return sha1.New() // RSA-OAEP is defined with SHA-1, RFC 7518 section 4.3
+}
+
+func newGCM(cek []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// deriveECDHESKey derives the content encryption key from the shared secret by the Concat KDF,
+// RFC 7518 section 4.6.2
+func deriveECDHESKey(priv *ecdsa.PrivateKey, pub *ecdh.PublicKey, header Header, keySize int) ([]byte, error) {
+	local, err := priv.ECDH()
+	if err != nil {
+		return nil, ErrInvalidKeyType
+	}
+
+	z, err := local.ECDH(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	apu, err := decodeSegment([]byte(header.APU))
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+	apv, err := decodeSegment([]byte(header.APV))
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	// In the direct key agreement mode, the AlgorithmID is the content encryption algorithm
+	var otherInfo []byte
+	for _, field := range [][]byte{[]byte(header.Enc), apu, apv} {
+		otherInfo = binary.BigEndian.AppendUint32(otherInfo, uint32(len(field)))
+		otherInfo = append(otherInfo, field...)
+	}
+	otherInfo = binary.BigEndian.AppendUint32(otherInfo, uint32(keySize*8))
+
+	var key []byte
+	for counter := uint32(1); len(key) < keySize; counter++ {
+		h := sha256.New()
+		h.Write(binary.BigEndian.AppendUint32(nil, counter))
+		h.Write(z)
+		h.Write(otherInfo)
+		key = h.Sum(key)
+	}
+
+	return key[:keySize], nil
+}
+
+func padOrTruncate(b []byte, size int) []byte {
+	out := make([]byte, size)
+	copy(out, b)
+
+	return out
+}
@@ -0,0 +1,383 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/viebiz/lit/ioutil"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	rsaKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+	p256Key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	p521Key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p521_private_key")
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	tcs := map[string]struct {
+		givenHeader   Header
+		encryptionKey any
+		decryptionKey any
+	}{
+		"RSA-OAEP with A256GCM": {
+			givenHeader:   Header{Alg: KeyAlgorithmRSAOAEP, Enc: EncryptionA256GCM},
+			encryptionKey: rsaKey.Public(),
+			decryptionKey: rsaKey,
+		},
+		"RSA-OAEP-256 with A128GCM": {
+			givenHeader:   Header{Alg: KeyAlgorithmRSAOAEP256, Enc: EncryptionA128GCM},
+			encryptionKey: rsaKey.Public(),
+			decryptionKey: rsaKey,
+		},
+		"ECDH-ES P-256 with A256GCM": {
+			givenHeader:   Header{Alg: KeyAlgorithmECDHES, Enc: EncryptionA256GCM, APU: "QWxpY2U", APV: "Qm9i"},
+			encryptionKey: p256Key.Public(),
+			decryptionKey: p256Key,
+		},
+		"ECDH-ES P-521 with A192GCM": {
+			givenHeader:   Header{Alg: KeyAlgorithmECDHES, Enc: EncryptionA192GCM},
+			encryptionKey: p521Key.Public(),
+			decryptionKey: p521Key,
+		},
+		"dir with A256GCM": {
+			givenHeader:   Header{Alg: KeyAlgorithmDirect, Enc: EncryptionA256GCM, Kid: "secret-01", Cty: "JWT"},
+			encryptionKey: secret,
+			decryptionKey: secret,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			plaintext := []byte("developers-prefer-dark-mode-because-light-attracts-bugs")
+
+			// When
+			tokenString, err := Encrypt(plaintext, tc.givenHeader, tc.encryptionKey)
+			require.NoError(t, err)
+
+			header, decrypted, err := Decrypt(tokenString, tc.decryptionKey)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, plaintext, decrypted)
+			require.Equal(t, tc.givenHeader.Alg, header.Alg)
+			require.Equal(t, tc.givenHeader.Enc, header.Enc)
+			require.Equal(t, tc.givenHeader.Kid, header.Kid)
+			require.Equal(t, tc.givenHeader.Cty, header.Cty)
+		})
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	rsaKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+	edKey := readKeyForTest[ed25519.PrivateKey](t, "sample_ed25519_private_key")
+
+	tcs := map[string]struct {
+		givenHeader Header
+		givenKey    any
+		expErr      error
+	}{
+		"error - enc not supported": {
+			givenHeader: Header{Alg: KeyAlgorithmRSAOAEP, Enc: "A256CBC-HS512"},
+			givenKey:    rsaKey.Public(),
+			expErr:      ErrEncryptionNotSupported,
+		},
+		"error - alg not supported": {
+			givenHeader: Header{Alg: "A256KW", Enc: EncryptionA256GCM},
+			givenKey:    rsaKey.Public(),
+			expErr:      ErrEncryptionNotSupported,
+		},
+		"error - compression not supported": {
+			givenHeader: Header{Alg: KeyAlgorithmRSAOAEP, Enc: EncryptionA256GCM, Zip: "DEF"},
+			givenKey:    rsaKey.Public(),
+			expErr:      ErrEncryptionNotSupported,
+		},
+		"error - RSA-OAEP with Ed25519 key": {
+			givenHeader: Header{Alg: KeyAlgorithmRSAOAEP, Enc: EncryptionA256GCM},
+			givenKey:    edKey.Public(),
+			expErr:      ErrInvalidKeyType,
+		},
+		"error - ECDH-ES with RSA key": {
+			givenHeader: Header{Alg: KeyAlgorithmECDHES, Enc: EncryptionA256GCM},
+			givenKey:    rsaKey.Public(),
+			expErr:      ErrInvalidKeyType,
+		},
+		"error - dir key size not match": {
+			givenHeader: Header{Alg: KeyAlgorithmDirect, Enc: EncryptionA256GCM},
+			givenKey:    []byte("0123456789abcdef"),
+			expErr:      ErrInvalidKeyType,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+
+			// When
+			_, err := Encrypt([]byte("plaintext"), tc.givenHeader, tc.givenKey)
+
+			// Then
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	rsaKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+	p256Key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	p384Key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p384_private_key")
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	encrypt := func(header Header, key any) string {
+		tokenString, err := Encrypt([]byte("plaintext"), header, key)
+		require.NoError(t, err)
+
+		return tokenString
+	}
+	rsaToken := encrypt(Header{Alg: KeyAlgorithmRSAOAEP, Enc: EncryptionA256GCM}, rsaKey.Public())
+	ecToken := encrypt(Header{Alg: KeyAlgorithmECDHES, Enc: EncryptionA256GCM}, p256Key.Public())
+	dirToken := encrypt(Header{Alg: KeyAlgorithmDirect, Enc: EncryptionA256GCM}, secret)
+
+	tamper := func(tokenString string, idx int) string {
+		parts := strings.Split(tokenString, ".")
+		b, err := base64.RawURLEncoding.DecodeString(parts[idx])
+		require.NoError(t, err)
+		b[0] ^= 0xff
+		parts[idx] = base64.RawURLEncoding.EncodeToString(b)
+
+		return strings.Join(parts, ".")
+	}
+	critHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"dir","enc":"A256GCM","crit":["urn:example:tenant"],"urn:example:tenant":"mukagen"}`))
+
+	tcs := map[string]struct {
+		givenToken string
+		givenKey   any
+		expErr     error
+	}{
+		"error - malformed": {
+			givenToken: "a.b.c",
+			givenKey:   secret,
+			expErr:     ErrTokenMalformed,
+		},
+		"error - RSA key type not match": {
+			givenToken: rsaToken,
+			givenKey:   p256Key,
+			expErr:     ErrInvalidKeyType,
+		},
+		"error - RSA encrypted key tampered": {
+			givenToken: tamper(rsaToken, 1),
+			givenKey:   rsaKey,
+			expErr:     ErrDecryptionFailed,
+		},
+		"error - ciphertext tampered": {
+			givenToken: tamper(rsaToken, 3),
+			givenKey:   rsaKey,
+			expErr:     ErrDecryptionFailed,
+		},
+		"error - tag tampered": {
+			givenToken: tamper(dirToken, 4),
+			givenKey:   secret,
+			expErr:     ErrDecryptionFailed,
+		},
+		"error - ECDH-ES curve not match": {
+			givenToken: ecToken,
+			givenKey:   p384Key,
+			expErr:     ErrDecryptionFailed,
+		},
+		"error - dir wrong key": {
+			givenToken: dirToken,
+			givenKey:   []byte("fedcba9876543210fedcba9876543210"),
+			expErr:     ErrDecryptionFailed,
+		},
+		"error - critical header not understood": {
+			givenToken: critHeader + "..AAAAAAAAAAAAAAAA.AA.AAAAAAAAAAAAAAAAAAAAAA",
+			givenKey:   secret,
+			expErr:     ErrUnsupportedCriticalHeader,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+
+			// When
+			_, _, err := Decrypt(tc.givenToken, tc.givenKey)
+
+			// Then
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestDeriveECDHESKey(t *testing.T) {
+	// Given the example of RFC 7518 appendix C
+	alice, err := JWK{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   "gI0GAILBdu7T53akrFmMyGcsF3n5dO7MmwNBHKW5SV0",
+		Y:   "SLW_xSffzlPWrHEVI30DHM_4egVwt3NQqeUD7nMFpps",
+		D:   "0_NxaRPUMQoAJt50Gz8YiTr8gRTwyEaCumd-MToTmIo",
+	}.PrivateKey()
+	require.NoError(t, err)
+
+	bob, err := JWK{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   "weNJy2HscCSM6AEDTDg04biOvhFhyyWvOHQfeF_PxMQ",
+		Y:   "e8lnCO-AlStT-NJVX-crhB7QRYhiix03illJOVAOyck",
+		D:   "VEmDZpDXXK8p8N0Cndsxs924q6nS1RXFASRl6BfUqdw",
+	}.PrivateKey()
+	require.NoError(t, err)
+
+	bobPublic, err := bob.(*ecdsa.PrivateKey).PublicKey.ECDH()
+	require.NoError(t, err)
+
+	// When
+	key, err := deriveECDHESKey(alice.(*ecdsa.PrivateKey), bobPublic, Header{Enc: EncryptionA128GCM, APU: "QWxpY2U", APV: "Qm9i"}, 16)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "VqqN6vgjbSBcIijNcacQGg", base64.RawURLEncoding.EncodeToString(key))
+}
+
+func TestParser_Parse_Nested(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	signingKey := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	encryptionKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+	keyFunc := func(string) (crypto.PublicKey, error) {
+		return signingKey.Public(), nil
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+	claims := RegisteredClaims{Subject: "juso-shi", ExpiresAt: &exp}
+	signed, err := NewToken(NewES256(), claims).SignedString(signingKey)
+	require.NoError(t, err)
+
+	encrypt := func(cty string) string {
+		tokenString, err := Encrypt([]byte(signed), Header{Alg: KeyAlgorithmRSAOAEP256, Enc: EncryptionA256GCM, Cty: cty}, encryptionKey.Public())
+		require.NoError(t, err)
+
+		return tokenString
+	}
+
+	tcs := map[string]struct {
+		givenToken string
+		givenOpts  []ParserOptions
+		expErr     error
+	}{
+		"success": {
+			givenToken: encrypt("JWT"),
+			givenOpts:  []ParserOptions{WithDecryptionKey(encryptionKey)},
+		},
+		"success - signed token": {
+			givenToken: signed,
+			givenOpts:  []ParserOptions{WithDecryptionKey(encryptionKey)},
+		},
+		"error - not nested JWT": {
+			givenToken: encrypt(""),
+			givenOpts:  []ParserOptions{WithDecryptionKey(encryptionKey)},
+			expErr:     ErrInvalidToken,
+		},
+		"error - no decryption key": {
+			givenToken: encrypt("JWT"),
+			expErr:     ErrTokenMalformed,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			parser := NewParser[RegisteredClaims](tc.givenOpts...)
+
+			// When
+			tk, err := parser.Parse(tc.givenToken, keyFunc)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, claims, tk.Claims)
+			}
+		})
+	}
+}
+
+func TestParser_ParseDetached(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	key := readKeyForTest[ed25519.PrivateKey](t, "sample_ed25519_private_key")
+	keyFunc := func(string) (crypto.PublicKey, error) {
+		return key.Public(), nil
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+	claims := RegisteredClaims{Subject: "juso-shi", ExpiresAt: &exp}
+	detached, payload, err := NewToken(NewEdDSA(), claims).SignedDetachedString(key)
+	require.NoError(t, err)
+
+	signed, err := NewToken(NewEdDSA(), claims).SignedString(key)
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		givenToken   string
+		givenPayload []byte
+		expErr       error
+	}{
+		"success": {
+			givenToken:   detached,
+			givenPayload: payload,
+		},
+		"error - payload tampered": {
+			givenToken:   detached,
+			givenPayload: []byte(strings.Replace(string(payload), "juso-shi", "mallory", 1)),
+			expErr:       ErrInvalidSignature,
+		},
+		"error - payload not detached": {
+			givenToken:   signed,
+			givenPayload: payload,
+			expErr:       ErrTokenMalformed,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			parser := NewParser[RegisteredClaims]()
+
+			// When
+			tk, err := parser.ParseDetached(tc.givenToken, tc.givenPayload, keyFunc)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, claims, tk.Claims)
+			}
+		})
+	}
+}
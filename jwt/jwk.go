@@ -0,0 +1,307 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+)
+
+// JWK represents a JSON Web Key of the RSA, EC (P-256, P-384, P-521) and OKP (Ed25519) key types
+// Refer https://datatracker.ietf.org/doc/html/rfc7517 and https://datatracker.ietf.org/doc/html/rfc8037
+type JWK struct {
+	KID string   `json:"kid,omitempty"`
+	Kty string   `json:"kty"`
+	Use string   `json:"use,omitempty"`
+	Alg string   `json:"alg,omitempty"`
+	Crv string   `json:"crv,omitempty"`
+	N   string   `json:"n,omitempty"`
+	E   string   `json:"e,omitempty"`
+	X   string   `json:"x,omitempty"`
+	Y   string   `json:"y,omitempty"`
+	D   string   `json:"d,omitempty"`
+	P   string   `json:"p,omitempty"`
+	Q   string   `json:"q,omitempty"`
+	DP  string   `json:"dp,omitempty"`
+	DQ  string   `json:"dq,omitempty"`
+	QI  string   `json:"qi,omitempty"`
+	X5c []string `json:"x5c,omitempty"`
+	X5t string   `json:"x5t,omitempty"`
+}
+
+// ParsePublicKeyFromJWK parses the JSON encoded JWK into *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey
+func ParsePublicKeyFromJWK(b []byte) (crypto.PublicKey, error) {
+	var k JWK
+	if err := json.Unmarshal(b, &k); err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	return k.PublicKey()
+}
+
+// ParsePrivateKeyFromJWK parses the JSON encoded JWK into *rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey
+func ParsePrivateKeyFromJWK(b []byte) (Signer, error) {
+	var k JWK
+	if err := json.Unmarshal(b, &k); err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	return k.PrivateKey()
+}
+
+// PublicKey returns the public key of the JWK, the x5c certificate is used when the key parameters are absent
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		if k.N == "" && len(k.X5c) > 0 {
+			return k.certificatePublicKey()
+		}
+		return k.rsaPublicKey()
+	case "EC":
+		if k.X == "" && len(k.X5c) > 0 {
+			return k.certificatePublicKey()
+		}
+		return k.ecdsaPublicKey()
+	case "OKP":
+		return k.ed25519PublicKey()
+	default:
+		return nil, ErrInvalidKeyType
+	}
+}
+
+// PrivateKey returns the private key of the JWK, it fails with ErrInvalidKey when the JWK holds only the public key
+func (k JWK) PrivateKey() (Signer, error) {
+	if k.D == "" {
+		return nil, ErrInvalidKey
+	}
+
+	switch k.Kty {
+	case "RSA":
+		return k.rsaPrivateKey()
+	case "EC":
+		return k.ecdsaPrivateKey()
+	case "OKP":
+		return k.ed25519PrivateKey()
+	default:
+		return nil, ErrInvalidKeyType
+	}
+}
+
+func (k JWK) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeBigInt(k.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, ErrInvalidKey
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (k JWK) rsaPrivateKey() (*rsa.PrivateKey, error) {
+	pub, err := k.rsaPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	d, err := decodeBigInt(k.D)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decodeBigInt(k.P)
+	if err != nil {
+		return nil, err
+	}
+	q, err := decodeBigInt(k.Q)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &rsa.PrivateKey{PublicKey: *pub, D: d, Primes: []*big.Int{p, q}}
+	if err := key.Validate(); err != nil {
+		return nil, ErrInvalidKey
+	}
+	key.Precompute()
+
+	return key, nil
+}
+
+func (k JWK) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	curve, size, err := k.curve()
+	if err != nil {
+		return nil, err
+	}
+
+	x, err := decodeFixed(k.X, size)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeFixed(k.Y, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// The ecdh package rejects the points not on the curve
+	point := append(append([]byte{4}, x...), y...)
+	if _, err := ecdhCurve(curve).NewPublicKey(point); err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}, nil
+}
+
+func (k JWK) ecdsaPrivateKey() (*ecdsa.PrivateKey, error) {
+	pub, err := k.ecdsaPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	_, size, _ := k.curve()
+
+	d, err := decodeFixed(k.D, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// The private scalar must derive the given public point
+	priv, err := ecdhCurve(pub.Curve).NewPrivateKey(d)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	expected := make([]byte, 1+2*size)
+	expected[0] = 4
+	pub.X.FillBytes(expected[1 : 1+size])
+	pub.Y.FillBytes(expected[1+size:])
+	if string(priv.PublicKey().Bytes()) != string(expected) {
+		return nil, ErrInvalidKey
+	}
+
+	return &ecdsa.PrivateKey{PublicKey: *pub, D: new(big.Int).SetBytes(d)}, nil
+}
+
+func (k JWK) ed25519PublicKey() (ed25519.PublicKey, error) {
+	if k.Crv != "Ed25519" {
+		return nil, ErrInvalidKeyType
+	}
+
+	x, err := decodeFixed(k.X, ed25519.PublicKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	return ed25519.PublicKey(x), nil
+}
+
+func (k JWK) ed25519PrivateKey() (ed25519.PrivateKey, error) {
+	pub, err := k.ed25519PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := decodeFixed(k.D, ed25519.SeedSize)
+	if err != nil {
+		return nil, err
+	}
+
+	key := ed25519.NewKeyFromSeed(seed)
+	if !pub.Equal(key.Public()) {
+		return nil, ErrInvalidKey
+	}
+
+	return key, nil
+}
+
+func (k JWK) certificatePublicKey() (crypto.PublicKey, error) {
+	// The x5c values are standard base64 DER, not base64url
+	der, err := base64.StdEncoding.DecodeString(k.X5c[0])
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if k.Kty != "RSA" {
+			return nil, ErrInvalidKeyType
+		}
+		return pub, nil
+	case *ecdsa.PublicKey:
+		if k.Kty != "EC" {
+			return nil, ErrInvalidKeyType
+		}
+		return pub, nil
+	default:
+		return nil, ErrInvalidKeyType
+	}
+}
+
+// newECPublicJWK returns the JWK of the EC public key, e.g. the ephemeral key of ECDH-ES
+func newECPublicJWK(pub *ecdsa.PublicKey) JWK {
+	size := (pub.Curve.Params().BitSize + 7) / 8
+
+	return JWK{
+		Kty: "EC",
+		Crv: pub.Curve.Params().Name,
+		X:   base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size))),
+		Y:   base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size))),
+	}
+}
+
+func (k JWK) curve() (elliptic.Curve, int, error) {
+	switch k.Crv {
+	case "P-256":
+		return elliptic.P256(), 32, nil
+	case "P-384":
+		return elliptic.P384(), 48, nil
+	case "P-521":
+		return elliptic.P521(), 66, nil
+	default:
+		return nil, 0, ErrInvalidKeyType
+	}
+}
+
+func ecdhCurve(curve elliptic.Curve) ecdh.Curve {
+	switch curve {
+	case elliptic.P256():
+		return ecdh.P256()
+	case elliptic.P384():
+		return ecdh.P384()
+	default:
+		return ecdh.P521()
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, ErrInvalidKey
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+func decodeFixed(s string, size int) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) != size {
+		return nil, ErrInvalidKey
+	}
+
+	return b, nil
+}
@@ -0,0 +1,193 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/viebiz/lit/ioutil"
+)
+
+func TestParsePublicKeyFromJWK(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	rsaKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+	ecKey := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p521_private_key")
+	edKey := readKeyForTest[ed25519.PrivateKey](t, "sample_ed25519_private_key")
+
+	rsaJWK := jwkForTest(rsaKey, false)
+	ecJWK := jwkForTest(ecKey, false)
+	edJWK := jwkForTest(edKey, false)
+
+	offCurveJWK := ecJWK
+	offCurveJWK.Y = ecJWK.X
+
+	tcs := map[string]struct {
+		given     JWK
+		expResult any
+		expErr    error
+	}{
+		"success - RSA": {
+			given:     rsaJWK,
+			expResult: rsaKey.Public(),
+		},
+		"success - EC": {
+			given:     ecJWK,
+			expResult: ecKey.Public(),
+		},
+		"success - OKP": {
+			given:     edJWK,
+			expResult: edKey.Public(),
+		},
+		"success - x5c": {
+			given: JWK{
+				Kty: "EC",
+				X5c: []string{base64.StdEncoding.EncodeToString(selfSignedCertificateForTest(t, ecKey))},
+			},
+			expResult: ecKey.Public(),
+		},
+		"error - kty not supported": {
+			given:  JWK{Kty: "oct"},
+			expErr: ErrInvalidKeyType,
+		},
+		"error - crv not supported": {
+			given:  JWK{Kty: "EC", Crv: "secp256k1"},
+			expErr: ErrInvalidKeyType,
+		},
+		"error - point not on curve": {
+			given:  offCurveJWK,
+			expErr: ErrInvalidKey,
+		},
+		"error - coordinate length not match": {
+			given:  JWK{Kty: "EC", Crv: "P-256", X: ecJWK.X, Y: ecJWK.Y},
+			expErr: ErrInvalidKey,
+		},
+		"error - malformed modulus": {
+			given:  JWK{Kty: "RSA", N: "!!", E: "AQAB"},
+			expErr: ErrInvalidKey,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			b, err := json.Marshal(tc.given)
+			require.NoError(t, err)
+
+			// When
+			key, err := ParsePublicKeyFromJWK(b)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, key)
+			}
+		})
+	}
+}
+
+func TestParsePrivateKeyFromJWK(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	rsaKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+	ecKey := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	edKey := readKeyForTest[ed25519.PrivateKey](t, "sample_ed25519_private_key")
+
+	mismatchedJWK := jwkForTest(ecKey, true)
+	mismatchedJWK.D = base64.RawURLEncoding.EncodeToString(new(big.Int).Add(ecKey.D, big.NewInt(1)).FillBytes(make([]byte, 32)))
+
+	tcs := map[string]struct {
+		given     JWK
+		expResult Signer
+		expErr    error
+	}{
+		"success - RSA": {
+			given:     jwkForTest(rsaKey, true),
+			expResult: rsaKey,
+		},
+		"success - EC": {
+			given:     jwkForTest(ecKey, true),
+			expResult: ecKey,
+		},
+		"success - OKP": {
+			given:     jwkForTest(edKey, true),
+			expResult: edKey,
+		},
+		"error - public key only": {
+			given:  jwkForTest(ecKey, false),
+			expErr: ErrInvalidKey,
+		},
+		"error - private key not match public key": {
+			given:  mismatchedJWK,
+			expErr: ErrInvalidKey,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			b, err := json.Marshal(tc.given)
+			require.NoError(t, err)
+
+			// When
+			key, err := ParsePrivateKeyFromJWK(b)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+				require.True(t, tc.expResult.(interface{ Equal(crypto.PrivateKey) bool }).Equal(key))
+			}
+		})
+	}
+}
+
+func TestParsePublicKeyFromJWK_Malformed(t *testing.T) {
+	// Given
+
+	// When
+	_, err := ParsePublicKeyFromJWK([]byte("{"))
+
+	// Then
+	require.Equal(t, ErrInvalidKey, err)
+}
+
+func jwkForTest(key any, withPrivate bool) JWK {
+	enc := base64.RawURLEncoding.EncodeToString
+	fixed := func(v *big.Int, size int) string {
+		return enc(v.FillBytes(make([]byte, size)))
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		jwk := JWK{Kty: "RSA", N: enc(k.N.Bytes()), E: enc(big.NewInt(int64(k.E)).Bytes())}
+		if withPrivate {
+			jwk.D = enc(k.D.Bytes())
+			jwk.P = enc(k.Primes[0].Bytes())
+			jwk.Q = enc(k.Primes[1].Bytes())
+		}
+		return jwk
+	case *ecdsa.PrivateKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		jwk := JWK{Kty: "EC", Crv: k.Curve.Params().Name, X: fixed(k.X, size), Y: fixed(k.Y, size)}
+		if withPrivate {
+			jwk.D = fixed(k.D, size)
+		}
+		return jwk
+	case ed25519.PrivateKey:
+		jwk := JWK{Kty: "OKP", Crv: "Ed25519", X: enc(k.Public().(ed25519.PublicKey))}
+		if withPrivate {
+			jwk.D = enc(k.Seed())
+		}
+		return jwk
+	default:
+		panic("unknown key type")
+	}
+}
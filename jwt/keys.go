@@ -0,0 +1,77 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+)
+
+// ParsePrivateKeyFromPEM parses the PEM encoded private key, the PKCS #8 ("PRIVATE KEY"), PKCS #1 ("RSA PRIVATE KEY")
+// and SEC 1 ("EC PRIVATE KEY") forms are supported. The result is *rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey
+func ParsePrivateKeyFromPEM(b []byte) (Signer, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, ErrInvalidKey
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+		return k.(Signer), nil
+	default:
+		return nil, ErrInvalidKeyType
+	}
+}
+
+// ParsePublicKeyFromPEM parses the PEM encoded public key, the PKIX ("PUBLIC KEY"), PKCS #1 ("RSA PUBLIC KEY")
+// and certificate ("CERTIFICATE") forms are supported. The result is *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey
+func ParsePublicKeyFromPEM(b []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, ErrInvalidKey
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+			key = cert.PublicKey
+		}
+	default:
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, ErrInvalidKeyType
+	}
+}
@@ -0,0 +1,141 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/viebiz/lit/ioutil"
+)
+
+func TestParsePrivateKeyFromPEM(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	rsaKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+	ecKey := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	edKey := readKeyForTest[ed25519.PrivateKey](t, "sample_ed25519_private_key")
+
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		given     []byte
+		expResult Signer
+		expErr    error
+	}{
+		"success - PKCS #8 RSA": {
+			given:     readFileForTest(t, "sample_rsa_private_key"),
+			expResult: rsaKey,
+		},
+		"success - PKCS #8 EC": {
+			given:     readFileForTest(t, "sample_ec_p256_private_key"),
+			expResult: ecKey,
+		},
+		"success - PKCS #8 Ed25519": {
+			given:     readFileForTest(t, "sample_ed25519_private_key"),
+			expResult: edKey,
+		},
+		"success - PKCS #1 RSA": {
+			given:     pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			expResult: rsaKey,
+		},
+		"success - SEC 1 EC": {
+			given:     pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+			expResult: ecKey,
+		},
+		"error - not PEM": {
+			given:  []byte("not a pem"),
+			expErr: ErrInvalidKey,
+		},
+		"error - malformed key": {
+			given:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("malformed")}),
+			expErr: ErrInvalidKey,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+
+			// When
+			key, err := ParsePrivateKeyFromPEM(tc.given)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, key)
+			}
+		})
+	}
+}
+
+func TestParsePublicKeyFromPEM(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	rsaKey := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+	ecKey := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	edKey := readKeyForTest[ed25519.PrivateKey](t, "sample_ed25519_private_key")
+
+	pkixPEM := func(key any) []byte {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		require.NoError(t, err)
+
+		return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	}
+
+	tcs := map[string]struct {
+		given     []byte
+		expResult any
+		expErr    error
+	}{
+		"success - PKIX RSA": {
+			given:     pkixPEM(rsaKey.Public()),
+			expResult: rsaKey.Public(),
+		},
+		"success - PKIX EC": {
+			given:     pkixPEM(ecKey.Public()),
+			expResult: ecKey.Public(),
+		},
+		"success - PKIX Ed25519": {
+			given:     pkixPEM(edKey.Public()),
+			expResult: edKey.Public(),
+		},
+		"success - PKCS #1 RSA": {
+			given:     pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)}),
+			expResult: rsaKey.Public(),
+		},
+		"success - certificate": {
+			given:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: selfSignedCertificateForTest(t, ecKey)}),
+			expResult: ecKey.Public(),
+		},
+		"error - not PEM": {
+			given:  []byte("not a pem"),
+			expErr: ErrInvalidKey,
+		},
+		"error - malformed key": {
+			given:  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("malformed")}),
+			expErr: ErrInvalidKey,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+
+			// When
+			key, err := ParsePublicKeyFromPEM(tc.given)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expResult, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package jwt
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockregisteredClaimsGetter is an autogenerated mock type for the registeredClaimsGetter type
+type MockregisteredClaimsGetter struct {
+	mock.Mock
+}
+
+type MockregisteredClaimsGetter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockregisteredClaimsGetter) EXPECT() *MockregisteredClaimsGetter_Expecter {
+	return &MockregisteredClaimsGetter_Expecter{mock: &_m.Mock}
+}
+
+// GetRegisteredClaims provides a mock function with no fields
+func (_m *MockregisteredClaimsGetter) GetRegisteredClaims() RegisteredClaims {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegisteredClaims")
+	}
+
+	var r0 RegisteredClaims
+	if rf, ok := ret.Get(0).(func() RegisteredClaims); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(RegisteredClaims)
+	}
+
+	return r0
+}
+
+// MockregisteredClaimsGetter_GetRegisteredClaims_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRegisteredClaims'
+type MockregisteredClaimsGetter_GetRegisteredClaims_Call struct {
+	*mock.Call
+}
+
+// GetRegisteredClaims is a helper method to define mock.On call
+func (_e *MockregisteredClaimsGetter_Expecter) GetRegisteredClaims() *MockregisteredClaimsGetter_GetRegisteredClaims_Call {
+	return &MockregisteredClaimsGetter_GetRegisteredClaims_Call{Call: _e.mock.On("GetRegisteredClaims")}
+}
+
+func (_c *MockregisteredClaimsGetter_GetRegisteredClaims_Call) Run(run func()) *MockregisteredClaimsGetter_GetRegisteredClaims_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockregisteredClaimsGetter_GetRegisteredClaims_Call) Return(_a0 RegisteredClaims) *MockregisteredClaimsGetter_GetRegisteredClaims_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockregisteredClaimsGetter_GetRegisteredClaims_Call) RunAndReturn(run func() RegisteredClaims) *MockregisteredClaimsGetter_GetRegisteredClaims_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockregisteredClaimsGetter creates a new instance of MockregisteredClaimsGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockregisteredClaimsGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockregisteredClaimsGetter {
+	mock := &MockregisteredClaimsGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -12,34 +12,57 @@ import (
 // T represents a specific Claims type to ensure precise handling of predefined claims.
 type Parser[T Claims] struct {
 	signingMethods map[string]SigningMethod // Supported signing method
+	policy         validationPolicy         // Checks on top of the signature verification
+	decryptionKey  any                      // Key to decrypt the nested tokens, see WithDecryptionKey
 }
 
 // Parse decodes the provided JWT string into a Token, verifies its signature using the provided public key.
 // The public key can be determined dynamically based on the `kid` (Key ID) in the token header.
+//
+// An encrypted token (JWE) is decrypted first when the parser has a decryption key, it must be a nested JWT
+// whose content type (cty) is "JWT", the signed token inside is then parsed as usual
 func (p Parser[T]) Parse(tokenString string, getKeyFunc func(string) (crypto.PublicKey, error)) (Token[T], error) {
+	// 0. Decrypt the nested token
+	if p.decryptionKey != nil && strings.Count(tokenString, ".") == 4 {
+		header, plaintext, err := Decrypt(tokenString, p.decryptionKey)
+		if err != nil {
+			return Token[T]{}, err
+		}
+
+		if !strings.EqualFold(header.Cty, tokenHeaderType) {
+			return Token[T]{}, ErrInvalidToken
+		}
+
+		tokenString = string(plaintext)
+	}
+
 	// 1. Parse JWT string to Token
-	token, signingString, err := p.parseToken(tokenString)
+	token, signingString, payload, err := p.parseToken(tokenString)
 	if err != nil {
 		return Token[T]{}, err
 	}
 
 	// 2. Lookup signing method
 	// 2.1. Get signing algorithm in token header
-	alg, exists := token.Header["alg"]
-	if !exists {
+	if token.Header.Alg == "" {
 		return Token[T]{}, ErrInvalidToken
 	}
 
 	// 2.2. get signing method by name
-	signingMethod, ok := p.getSigningMethod(alg)
+	signingMethod, ok := p.getSigningMethod(token.Header.Alg)
 	if !ok {
 		return Token[T]{}, ErrSigningMethodNotSupported
 	}
 
+	// 2.3. Check the header against the policy, e.g. the allowed algorithms, token types and critical extensions
+	if err := p.policy.validateHeader(token.Header); err != nil {
+		return Token[T]{}, err
+	}
+
 	// 3. Verify signature by signing method
 	// 3.1. Get Public/Private key for verify token,
 	// Optionally, use the `kid` (Key ID) to determine which key to use for verification.
-	key, err := getKeyFunc(token.Header["kid"])
+	key, err := getKeyFunc(token.Header.Kid)
 	if err != nil {
 		return Token[T]{}, err
 	}
@@ -49,57 +72,76 @@ func (p Parser[T]) Parse(tokenString string, getKeyFunc func(string) (crypto.Pub
 		return Token[T]{}, ErrInvalidSignature
 	}
 
-	// 3.3. Validate token claims
-	if err := token.Claims.Valid(); err != nil {
+	// 3.3. Validate token claims by the policy, the claims not carrying the registered claims validate themselves.
+	// The Valid of the registered claims is skipped, the policy replaces its checks
+	if err := p.policy.validateClaims(token.Claims, payload); err != nil {
 		return Token[T]{}, err
 	}
 
+	if _, ok := any(token.Claims).(registeredClaimsGetter); !ok {
+		if err := token.Claims.Valid(); err != nil {
+			return Token[T]{}, err
+		}
+	}
+
 	return token, nil
 }
 
-func (p Parser[T]) parseToken(tokenString string) (Token[T], []byte, error) {
+// ParseDetached parses the JWS with detached payload as RFC 7515 appendix F, e.g. created by Token.SignedDetachedString.
+// The payload is the JSON claims transported separately, the token and the payload are verified as by Parse
+func (p Parser[T]) ParseDetached(tokenString string, payload []byte, getKeyFunc func(string) (crypto.PublicKey, error)) (Token[T], error) {
+	parts := strings.Split(tokenString, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return Token[T]{}, ErrTokenMalformed
+	}
+
+	return p.Parse(fmt.Sprintf("%s.%s.%s", parts[0], encodeSegment(payload), parts[2]), getKeyFunc)
+}
+
+func (p Parser[T]) parseToken(tokenString string) (Token[T], []byte, []byte, error) {
 	parts := strings.Split(tokenString, ".")
 	if len(parts) != 3 {
-		return Token[T]{}, nil, ErrTokenMalformed
+		return Token[T]{}, nil, nil, ErrTokenMalformed
 	}
 
 	// 1.1. Decode header segment
 	headerBytes, err := decodeSegment([]byte(parts[0]))
 	if err != nil {
-		return Token[T]{}, nil, err
+		return Token[T]{}, nil, nil, err
 	}
 
-	// 1.2. Decode JSON header to map
-	var headerMap map[string]string
-	if err := json.Unmarshal(headerBytes, &headerMap); err != nil {
-		return Token[T]{}, nil, err
+	// 1.2. Decode JSON header
+	var header Header
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return Token[T]{}, nil, nil, err
 	}
 
 	// 2.1. Decode payload segment
 	payloadBytes, err := decodeSegment([]byte(parts[1]))
 	if err != nil {
-		return Token[T]{}, nil, err
+		return Token[T]{}, nil, nil, err
 	}
 
 	// 2.2. Decode JSON Claims
 	var claims T
 	if err := json.Unmarshal(payloadBytes, &claims); err != nil {
-		return Token[T]{}, nil, err
+		return Token[T]{}, nil, nil, err
 	}
+	fillNilRegisteredClaims(&claims)
 
 	// 3.3. Decode signature
 	sigBytes, err := decodeSegment([]byte(parts[2]))
 	if err != nil {
-		return Token[T]{}, nil, err
+		return Token[T]{}, nil, nil, err
 	}
 
 	signingString := fmt.Sprintf("%s.%s", parts[0], parts[1])
 
 	return Token[T]{
-		Header:    headerMap,
+		Header:    header,
 		Claims:    claims,
 		Signature: sigBytes,
-	}, []byte(signingString), nil
+	}, []byte(signingString), payloadBytes, nil
 }
 
 func (p Parser[T]) getSigningMethod(alg string) (SigningMethod, bool) {
@@ -1,5 +1,9 @@
 package jwt
 
+import (
+	"time"
+)
+
 type ParserOptions func(*Parser[Claims])
 
 // WithSigningMethod add more signing method to parser
@@ -15,3 +19,93 @@ func WithSigningMethods(signingMethods map[string]SigningMethod) ParserOptions {
 		p.signingMethods = signingMethods
 	}
 }
+
+// WithIssuer accepts only the tokens issued by one of the issuers, the `iss` claim must match exactly
+func WithIssuer(issuers ...string) ParserOptions {
+	return func(p *Parser[Claims]) {
+		p.policy.issuers = append(p.policy.issuers, issuers...)
+	}
+}
+
+// WithAudience accepts only the tokens intended for one of the audiences
+func WithAudience(audiences ...string) ParserOptions {
+	return func(p *Parser[Claims]) {
+		p.policy.audiences = append(p.policy.audiences, audiences...)
+	}
+}
+
+// WithLeeway tolerates the clock skew between the issuer and this service when checking the `exp`, `nbf` and `iat` claims
+func WithLeeway(leeway time.Duration) ParserOptions {
+	return func(p *Parser[Claims]) {
+		p.policy.leeway = leeway
+	}
+}
+
+// WithRequiredClaims rejects the tokens missing one of the claims or having it empty, e.g. "sub", "jti"
+func WithRequiredClaims(names ...string) ParserOptions {
+	return func(p *Parser[Claims]) {
+		p.policy.requiredClaims = append(p.policy.requiredClaims, names...)
+	}
+}
+
+// WithExpirationOptional accepts the tokens without the `exp` claim, it is required by default
+func WithExpirationOptional() ParserOptions {
+	return func(p *Parser[Claims]) {
+		p.policy.expOptional = true
+	}
+}
+
+// WithMaxTokenAge rejects the tokens issued longer than the given duration ago, the `iat` claim becomes required
+func WithMaxTokenAge(age time.Duration) ParserOptions {
+	return func(p *Parser[Claims]) {
+		p.policy.maxAge = age
+	}
+}
+
+// WithAllowedAlgorithms accepts only the given `alg` values, even when more signing methods are supported
+func WithAllowedAlgorithms(algs ...string) ParserOptions {
+	return func(p *Parser[Claims]) {
+		p.policy.algs = append(p.policy.algs, algs...)
+	}
+}
+
+// WithKeyAlgorithms pins the `alg` values accepted for the key with the given `kid`, e.g. to prevent
+// an RSA key from being used with another algorithm than the one it was issued for
+func WithKeyAlgorithms(kid string, algs ...string) ParserOptions {
+	return func(p *Parser[Claims]) {
+		if p.policy.keyAlgs == nil {
+			p.policy.keyAlgs = map[string][]string{}
+		}
+		p.policy.keyAlgs[kid] = append(p.policy.keyAlgs[kid], algs...)
+	}
+}
+
+// WithTokenTypes accepts only the tokens having one of the `typ` header values, e.g. "at+jwt".
+// The values are compared case-insensitively and the "application/" prefix is optional
+func WithTokenTypes(types ...string) ParserOptions {
+	return func(p *Parser[Claims]) {
+		p.policy.types = append(p.policy.types, types...)
+	}
+}
+
+// WithClock sets the clock used to check the time based claims, it defaults to the current UTC time
+func WithClock(now func() time.Time) ParserOptions {
+	return func(p *Parser[Claims]) {
+		p.policy.clock = now
+	}
+}
+
+// WithCriticalHeaders declares the header extensions the caller processes, the tokens listing other extensions
+// in the `crit` header are rejected with ErrUnsupportedCriticalHeader
+func WithCriticalHeaders(names ...string) ParserOptions {
+	return func(p *Parser[Claims]) {
+		p.policy.critical = append(p.policy.critical, names...)
+	}
+}
+
+// WithDecryptionKey decrypts the encrypted tokens (nested JWT) before verifying them, see Decrypt for the key types
+func WithDecryptionKey(key any) ParserOptions {
+	return func(p *Parser[Claims]) {
+		p.decryptionKey = key
+	}
+}
@@ -27,6 +27,9 @@ type RegisteredClaims struct {
 // Valid validates time based claims "exp, iat, nbf"
 // if any of the above claims are not in the token, it will still
 // be considered a valid claim.
+//
+// Parser does not call it, the claims carrying the registered claims are validated by the Parser policy
+// instead, e.g. with the leeway, the clock, the expected issuer and audience
 func (c RegisteredClaims) Valid() error {
 	now := timeNowFunc()
 
@@ -61,3 +64,8 @@ func (c RegisteredClaims) Valid() error {
 
 	return nil
 }
+
+// GetRegisteredClaims returns the registered claims for the Parser policy
+func (c RegisteredClaims) GetRegisteredClaims() RegisteredClaims {
+	return c
+}
@@ -0,0 +1,66 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+const (
+	SigningMethodNamePS256 string = "PS256"
+	SigningMethodNamePS384 string = "PS384"
+	SigningMethodNamePS512 string = "PS512"
+)
+
+// RSAPSS implements the RSASSA-PSS family of signing methods, the salt length equals the hash size as RFC 7518 section 3.5
+type RSAPSS struct {
+	Name string
+	Hash crypto.Hash
+}
+
+// Sign implements token signing for the SigningMethod, that take Signer (*rsa.PrivateKey) for sign a token
+func (sm RSAPSS) Sign(signingString []byte, key Signer) ([]byte, error) {
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidKeyType
+	}
+
+	if !sm.Hash.Available() {
+		return nil, ErrHashUnavailable
+	}
+
+	hash := sm.Hash.New()
+	hash.Write(signingString)
+
+	return rsa.SignPSS(rand.Reader, privateKey, sm.Hash, hash.Sum(nil), &rsa.PSSOptions{
+		SaltLength: rsa.PSSSaltLengthEqualsHash,
+	})
+}
+
+// Verify verifies the signingString with signature by provided VerifyKey, that can consider as *rsa.PublicKey.
+// The salt length is detected, so the tokens signed by the issuers using a different salt length are accepted
+func (sm RSAPSS) Verify(signingString []byte, sig []byte, key VerifyKey) error {
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return ErrInvalidKeyType
+	}
+
+	if !sm.Hash.Available() {
+		return ErrHashUnavailable
+	}
+
+	hash := sm.Hash.New()
+	hash.Write(signingString)
+
+	if err := rsa.VerifyPSS(publicKey, sm.Hash, hash.Sum(nil), sig, &rsa.PSSOptions{
+		SaltLength: rsa.PSSSaltLengthAuto,
+	}); err != nil {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (sm RSAPSS) Alg() string {
+	return sm.Name
+}
@@ -0,0 +1,112 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/viebiz/lit/ioutil"
+)
+
+func TestSigningMethodRSAPSS_SignAndVerify(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	key := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+
+	tcs := map[string]struct {
+		method RSAPSS
+	}{
+		"success - PS256": {method: NewPS256()},
+		"success - PS384": {method: NewPS384()},
+		"success - PS512": {method: NewPS512()},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			signingString := []byte("developers-prefer-dark-mode-because-light-attracts-bugs")
+
+			// When
+			sig, err := tc.method.Sign(signingString, key)
+			require.NoError(t, err)
+
+			// Then
+			require.NoError(t, tc.method.Verify(signingString, sig, key.Public()))
+		})
+	}
+}
+
+func TestSigningMethodRSAPSS_Sign(t *testing.T) {
+	// Given
+	method := NewPS256()
+
+	// When
+	_, err := method.Sign([]byte("developers-prefer-dark-mode-because-light-attracts-bugs"), &ecdsa.PrivateKey{})
+
+	// Then
+	require.Equal(t, ErrInvalidKeyType, err)
+}
+
+func TestSigningMethodRSAPSS_Verify(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	key := readKeyForTest[*rsa.PrivateKey](t, "sample_rsa_private_key")
+
+	signingString := []byte("developers-prefer-dark-mode-because-light-attracts-bugs")
+	sig, err := NewPS256().Sign(signingString, key)
+	require.NoError(t, err)
+
+	// Some issuers sign with the maximum salt length
+	digest := crypto.SHA256.New()
+	digest.Write(signingString)
+	maxSaltSig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest.Sum(nil), &rsa.PSSOptions{
+		SaltLength: rsa.PSSSaltLengthAuto,
+	})
+	require.NoError(t, err)
+
+	pkcs1Sig, err := NewRS256().Sign(signingString, key)
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		sig    []byte
+		key    VerifyKey
+		expErr error
+	}{
+		"success": {
+			sig: sig,
+			key: key.Public(),
+		},
+		"success - maximum salt length": {
+			sig: maxSaltSig,
+			key: key.Public(),
+		},
+		"error - invalid key type": {
+			sig:    sig,
+			key:    &ecdsa.PublicKey{},
+			expErr: ErrInvalidKeyType,
+		},
+		"error - PKCS #1 v1.5 signature": {
+			sig:    pkcs1Sig,
+			key:    key.Public(),
+			expErr: ErrInvalidSignature,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			method := NewPS256()
+
+			// When
+			err := method.Verify(signingString, tc.sig, tc.key)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
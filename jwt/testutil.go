@@ -2,9 +2,13 @@ package jwt
 
 import (
 	"crypto"
+	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/viebiz/lit/ioutil"
@@ -38,3 +42,24 @@ func readKeyForTest[T crypto.Signer](t require.TestingT, path string) T {
 
 	return key
 }
+
+func readFileForTest(t require.TestingT, path string) []byte {
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	return b
+}
+
+func selfSignedCertificateForTest(t require.TestingT, key crypto.Signer) []byte {
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "lit"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+
+	return der
+}
@@ -14,7 +14,7 @@ const (
 
 // Token represents a JWT Token
 type Token[T Claims] struct {
-	Header    map[string]string
+	Header    Header
 	Claims    T
 	Signature []byte
 
@@ -39,15 +39,27 @@ func (tk Token[T]) SignedString(key crypto.Signer) (string, error) {
 	return fmt.Sprintf("%s.%s", msg, encodeSegment(sig)), nil
 }
 
-func (tk Token[T]) signingString() ([]byte, error) {
-	// Marshal the header map to JSON format
-	headerBytes, err := json.Marshal(tk.Header)
+// SignedDetachedString creates a JWS with detached payload as RFC 7515 appendix F, the payload segment is left empty
+// and the payload (the JSON claims) is returned to be transported separately, see Parser.ParseDetached
+func (tk Token[T]) SignedDetachedString(key crypto.Signer) (string, []byte, error) {
+	headerBytes, payloadBytes, err := tk.marshal()
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 
-	// Marshal the payload (claims) to JSON format
-	payloadBytes, err := json.Marshal(tk.Claims)
+	headerBase64 := encodeSegment(headerBytes)
+	msg := bytes.Join([][]byte{headerBase64, encodeSegment(payloadBytes)}, []byte("."))
+
+	sig, err := tk.signingMethod.Sign(msg, key)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("%s..%s", headerBase64, encodeSegment(sig)), payloadBytes, nil
+}
+
+func (tk Token[T]) signingString() ([]byte, error) {
+	headerBytes, payloadBytes, err := tk.marshal()
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +70,22 @@ func (tk Token[T]) signingString() ([]byte, error) {
 	return bytes.Join([][]byte{headerBase64, payloadBase64}, []byte(".")), nil
 }
 
+func (tk Token[T]) marshal() ([]byte, []byte, error) {
+	// Marshal the header to JSON format
+	headerBytes, err := json.Marshal(tk.Header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Marshal the payload (claims) to JSON format
+	payloadBytes, err := json.Marshal(tk.Claims)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return headerBytes, payloadBytes, nil
+}
+
 func encodeSegment(b []byte) []byte {
 	buf := make([]byte, base64.RawURLEncoding.EncodedLen(len(b)))
 	base64.RawURLEncoding.Encode(buf, b)
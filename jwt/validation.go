@@ -0,0 +1,183 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// registeredClaimsGetter is implemented by the Claims carrying the registered claims, e.g. RegisteredClaims
+// and the claims embedding it. The time, issuer and audience checks of the Parser policy apply to them
+type registeredClaimsGetter interface {
+	GetRegisteredClaims() RegisteredClaims
+}
+
+var registeredClaimsPtrType = reflect.TypeOf((*RegisteredClaims)(nil))
+
+// validationPolicy is the set of checks Parser runs on top of the signature verification
+type validationPolicy struct {
+	clock          func() time.Time
+	leeway         time.Duration
+	issuers        []string
+	audiences      []string
+	requiredClaims []string
+	expOptional    bool
+	maxAge         time.Duration
+	algs           []string
+	keyAlgs        map[string][]string
+	types          []string
+	critical       []string
+}
+
+func (p validationPolicy) now() time.Time {
+	if p.clock != nil {
+		return p.clock()
+	}
+
+	return timeNowFunc()
+}
+
+// validateHeader checks the alg, typ and crit headers, it runs before the key lookup
+func (p validationPolicy) validateHeader(header Header) error {
+	if len(p.algs) > 0 && !slices.Contains(p.algs, header.Alg) {
+		return ErrSigningMethodNotAllowed
+	}
+
+	if algs, ok := p.keyAlgs[header.Kid]; ok && !slices.Contains(algs, header.Alg) {
+		return ErrSigningMethodNotAllowed
+	}
+
+	if len(p.types) > 0 && !slices.ContainsFunc(p.types, func(typ string) bool {
+		return sameTokenType(typ, header.Typ)
+	}) {
+		return ErrInvalidTokenType
+	}
+
+	return header.validateCritical(p.critical)
+}
+
+// validateClaims checks the required claims in the payload and the registered claims, the claims not carrying
+// the registered claims are rejected when the policy checks them, so the checks never pass silently
+func (p validationPolicy) validateClaims(claims Claims, payload []byte) error {
+	if len(p.requiredClaims) > 0 {
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(payload, &raw); err != nil {
+			return ErrTokenMalformed
+		}
+
+		for _, name := range p.requiredClaims {
+			if v, ok := raw[name]; !ok || isEmptyClaim(v) {
+				return ErrMissingRequiredClaim
+			}
+		}
+	}
+
+	getter, ok := claims.(registeredClaimsGetter)
+	if !ok {
+		if p.checksRegisteredClaims() {
+			return ErrInvalidToken
+		}
+
+		return nil
+	}
+
+	return p.validateRegisteredClaims(getter.GetRegisteredClaims())
+}
+
+// checksRegisteredClaims reports whether the policy has any check on the registered claims, `exp` is checked unless it's optional
+func (p validationPolicy) checksRegisteredClaims() bool {
+	return !p.expOptional || len(p.issuers) > 0 || len(p.audiences) > 0 || p.maxAge > 0
+}
+
+// fillNilRegisteredClaims sets the nil *RegisteredClaims embedded in the claims to empty claims, e.g. the token
+// has no registered claims at all, so the promoted Valid and GetRegisteredClaims don't panic on the nil pointer
+func fillNilRegisteredClaims(ptr any) {
+	v := reflect.ValueOf(ptr)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	field, ok := v.Type().FieldByName("RegisteredClaims")
+	if !ok || !field.Anonymous || field.Type != registeredClaimsPtrType {
+		return
+	}
+
+	// The error means an outer embedded pointer is nil, the claims don't carry the registered claims then
+	rc, err := v.FieldByIndexErr(field.Index)
+	if err != nil || !rc.IsNil() || !rc.CanSet() {
+		return
+	}
+
+	rc.Set(reflect.ValueOf(&RegisteredClaims{}))
+}
+
+func (p validationPolicy) validateRegisteredClaims(c RegisteredClaims) error {
+	now := p.now()
+
+	// 1. Verify the `exp` claim, it's required unless the policy says otherwise
+	if c.ExpiresAt == nil {
+		if !p.expOptional {
+			return ErrMissingRequiredClaim
+		}
+	} else if now.After(time.Unix(*c.ExpiresAt, 0).Add(p.leeway)) {
+		return ErrTokenExpired
+	}
+
+	// 2. Verify the `iat` claim
+	if c.IssuedAt != nil {
+		issuedAt := time.Unix(*c.IssuedAt, 0)
+		if now.Add(p.leeway).Before(issuedAt) {
+			return ErrTokenUsedBeforeIssued
+		}
+
+		if p.maxAge > 0 && now.Sub(issuedAt) > p.maxAge+p.leeway {
+			return ErrTokenTooOld
+		}
+	} else if p.maxAge > 0 {
+		return ErrMissingRequiredClaim
+	}
+
+	// 3. Verify the `nbf` claim
+	if c.NotBefore != nil && now.Add(p.leeway).Before(time.Unix(*c.NotBefore, 0)) {
+		return ErrTokenNotValidYet
+	}
+
+	// 4. Verify the `iss` claim
+	if len(p.issuers) > 0 && !slices.Contains(p.issuers, c.Issuer) {
+		return ErrInvalidIssuer
+	}
+
+	// 5. Verify the `aud` claim, one of the audiences is enough
+	if len(p.audiences) > 0 && !slices.ContainsFunc(c.Audience, func(aud string) bool {
+		return slices.Contains(p.audiences, aud)
+	}) {
+		return ErrInvalidAudience
+	}
+
+	return nil
+}
+
+// sameTokenType compares the typ values case-insensitively, the "application/" prefix is optional as RFC 7515 section 4.1.9
+func sameTokenType(expected, actual string) bool {
+	normalize := func(typ string) string {
+		typ = strings.ToLower(typ)
+		return strings.TrimPrefix(typ, "application/")
+	}
+
+	return actual != "" && normalize(expected) == normalize(actual)
+}
+
+func isEmptyClaim(v json.RawMessage) bool {
+	v = bytes.TrimSpace(v)
+	return len(v) == 0 || bytes.Equal(v, []byte("null")) || bytes.Equal(v, []byte(`""`))
+}
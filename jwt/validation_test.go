@@ -0,0 +1,350 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/viebiz/lit/ioutil"
+)
+
+func TestParser_Parse_ValidationPolicy(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	keyFunc := func(string) (crypto.PublicKey, error) {
+		return key.Public(), nil
+	}
+
+	now := time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC)
+	unix := func(d time.Duration) *int64 {
+		v := now.Add(d).Unix()
+		return &v
+	}
+
+	type givenToken struct {
+		typ    string
+		kid    string
+		claims RegisteredClaims
+	}
+	validToken := givenToken{
+		claims: RegisteredClaims{
+			Issuer:    "https://mukagen.com",
+			Subject:   "juso-shi@clients",
+			Audience:  ClaimStrings{"https://limitless.mukagen.com"},
+			IssuedAt:  unix(-time.Minute),
+			ExpiresAt: unix(time.Hour),
+		},
+	}
+
+	tcs := map[string]struct {
+		givenToken givenToken
+		givenOpts  []ParserOptions
+		expErr     error
+	}{
+		"success - default policy": {
+			givenToken: validToken,
+		},
+		"success - full policy": {
+			givenToken: givenToken{
+				typ:    "application/AT+JWT",
+				kid:    "key-01",
+				claims: validToken.claims,
+			},
+			givenOpts: []ParserOptions{
+				WithIssuer("https://other.com", "https://mukagen.com"),
+				WithAudience("https://limitless.mukagen.com"),
+				WithRequiredClaims("sub", "iat"),
+				WithMaxTokenAge(time.Hour),
+				WithAllowedAlgorithms(SigningMethodNameES256, SigningMethodNameRS256),
+				WithKeyAlgorithms("key-01", SigningMethodNameES256),
+				WithTokenTypes("at+jwt"),
+			},
+		},
+		"success - expired within leeway": {
+			givenToken: givenToken{claims: RegisteredClaims{ExpiresAt: unix(-30 * time.Second)}},
+			givenOpts:  []ParserOptions{WithLeeway(time.Minute)},
+		},
+		"success - not valid yet within leeway": {
+			givenToken: givenToken{claims: RegisteredClaims{ExpiresAt: unix(time.Hour), NotBefore: unix(30 * time.Second)}},
+			givenOpts:  []ParserOptions{WithLeeway(time.Minute)},
+		},
+		"success - expiration optional": {
+			givenToken: givenToken{claims: RegisteredClaims{Subject: "juso-shi@clients"}},
+			givenOpts:  []ParserOptions{WithExpirationOptional()},
+		},
+		"success - clock": {
+			givenToken: givenToken{claims: RegisteredClaims{ExpiresAt: unix(-time.Hour)}},
+			givenOpts: []ParserOptions{WithClock(func() time.Time {
+				return now.Add(-2 * time.Hour)
+			})},
+		},
+		"error - missing exp": {
+			givenToken: givenToken{claims: RegisteredClaims{Subject: "juso-shi@clients"}},
+			expErr:     ErrMissingRequiredClaim,
+		},
+		"error - expired": {
+			givenToken: givenToken{claims: RegisteredClaims{ExpiresAt: unix(-2 * time.Minute)}},
+			givenOpts:  []ParserOptions{WithLeeway(time.Minute)},
+			expErr:     ErrTokenExpired,
+		},
+		"error - not valid yet": {
+			givenToken: givenToken{claims: RegisteredClaims{ExpiresAt: unix(time.Hour), NotBefore: unix(time.Minute)}},
+			expErr:     ErrTokenNotValidYet,
+		},
+		"error - used before issued": {
+			givenToken: givenToken{claims: RegisteredClaims{ExpiresAt: unix(time.Hour), IssuedAt: unix(time.Minute)}},
+			expErr:     ErrTokenUsedBeforeIssued,
+		},
+		"error - too old": {
+			givenToken: validToken,
+			givenOpts:  []ParserOptions{WithMaxTokenAge(30 * time.Second)},
+			expErr:     ErrTokenTooOld,
+		},
+		"error - max token age without iat": {
+			givenToken: givenToken{claims: RegisteredClaims{ExpiresAt: unix(time.Hour)}},
+			givenOpts:  []ParserOptions{WithMaxTokenAge(time.Hour)},
+			expErr:     ErrMissingRequiredClaim,
+		},
+		"error - missing required claim": {
+			givenToken: validToken,
+			givenOpts:  []ParserOptions{WithRequiredClaims("jti")},
+			expErr:     ErrMissingRequiredClaim,
+		},
+		"error - issuer not match": {
+			givenToken: validToken,
+			givenOpts:  []ParserOptions{WithIssuer("https://mukagen.com/")},
+			expErr:     ErrInvalidIssuer,
+		},
+		"error - audience not match": {
+			givenToken: validToken,
+			givenOpts:  []ParserOptions{WithAudience("https://other.mukagen.com")},
+			expErr:     ErrInvalidAudience,
+		},
+		"error - algorithm not allowed": {
+			givenToken: validToken,
+			givenOpts:  []ParserOptions{WithAllowedAlgorithms(SigningMethodNameRS256)},
+			expErr:     ErrSigningMethodNotAllowed,
+		},
+		"error - algorithm not allowed for key": {
+			givenToken: givenToken{kid: "key-01", claims: validToken.claims},
+			givenOpts:  []ParserOptions{WithKeyAlgorithms("key-01", SigningMethodNamePS256)},
+			expErr:     ErrSigningMethodNotAllowed,
+		},
+		"error - token type not match": {
+			givenToken: validToken,
+			givenOpts:  []ParserOptions{WithTokenTypes("at+jwt")},
+			expErr:     ErrInvalidTokenType,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			tk := NewToken(NewES256(), tc.givenToken.claims)
+			if tc.givenToken.typ != "" {
+				tk.Header.Typ = tc.givenToken.typ
+			}
+			tk.Header.Kid = tc.givenToken.kid
+			tokenString, err := tk.SignedString(key)
+			require.NoError(t, err)
+
+			opts := append([]ParserOptions{WithClock(func() time.Time { return now })}, tc.givenOpts...)
+			parser := NewParser[RegisteredClaims](opts...)
+
+			// When
+			_, err = parser.Parse(tokenString, keyFunc)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_NilRegisteredClaims(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	keyFunc := func(string) (crypto.PublicKey, error) {
+		return key.Public(), nil
+	}
+
+	type profileClaims struct {
+		*RegisteredClaims
+		Name string `json:"name"`
+	}
+
+	tcs := map[string]struct {
+		givenOpts []ParserOptions
+		expErr    error
+	}{
+		"success - expiration optional": {
+			givenOpts: []ParserOptions{WithExpirationOptional()},
+		},
+		"error - missing exp": {
+			expErr: ErrMissingRequiredClaim,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			tokenString, err := NewToken(NewES256(), profileClaims{Name: "juso-shi"}).SignedString(key)
+			require.NoError(t, err)
+
+			parser := NewParser[profileClaims](tc.givenOpts...)
+
+			// When
+			tk, err := parser.Parse(tokenString, keyFunc)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "juso-shi", tk.Claims.Name)
+			require.Equal(t, &RegisteredClaims{}, tk.Claims.RegisteredClaims)
+		})
+	}
+}
+
+// profileClaimsForTest doesn't carry the registered claims, it's validated by its own Valid
+type profileClaimsForTest struct {
+	Name string `json:"name"`
+}
+
+func (c profileClaimsForTest) Valid() error {
+	if c.Name == "" {
+		return ErrMissingRequiredClaim
+	}
+
+	return nil
+}
+
+func TestParser_Parse_ClaimsWithoutRegisteredClaims(t *testing.T) {
+	ioutil.SetResourceDir("testdata")
+	key := readKeyForTest[*ecdsa.PrivateKey](t, "sample_ec_p256_private_key")
+	keyFunc := func(string) (crypto.PublicKey, error) {
+		return key.Public(), nil
+	}
+
+	tcs := map[string]struct {
+		givenClaims profileClaimsForTest
+		givenOpts   []ParserOptions
+		expErr      error
+	}{
+		"success - no registered claims checks": {
+			givenClaims: profileClaimsForTest{Name: "juso-shi"},
+			givenOpts:   []ParserOptions{WithExpirationOptional()},
+		},
+		"error - claims invalid": {
+			givenOpts: []ParserOptions{WithExpirationOptional()},
+			expErr:    ErrMissingRequiredClaim,
+		},
+		"error - exp required by default": {
+			givenClaims: profileClaimsForTest{Name: "juso-shi"},
+			expErr:      ErrInvalidToken,
+		},
+		"error - issuer configured": {
+			givenClaims: profileClaimsForTest{Name: "juso-shi"},
+			givenOpts:   []ParserOptions{WithExpirationOptional(), WithIssuer("https://mukagen.com")},
+			expErr:      ErrInvalidToken,
+		},
+		"error - audience configured": {
+			givenClaims: profileClaimsForTest{Name: "juso-shi"},
+			givenOpts:   []ParserOptions{WithExpirationOptional(), WithAudience("https://limitless.mukagen.com")},
+			expErr:      ErrInvalidToken,
+		},
+		"error - max age configured": {
+			givenClaims: profileClaimsForTest{Name: "juso-shi"},
+			givenOpts:   []ParserOptions{WithExpirationOptional(), WithMaxTokenAge(time.Hour)},
+			expErr:      ErrInvalidToken,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			tokenString, err := NewToken(NewES256(), tc.givenClaims).SignedString(key)
+			require.NoError(t, err)
+
+			parser := NewParser[profileClaimsForTest](tc.givenOpts...)
+
+			// When
+			tk, err := parser.Parse(tokenString, keyFunc)
+
+			// Then
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.givenClaims, tk.Claims)
+		})
+	}
+}
+
+func TestRegisteredClaims_Valid(t *testing.T) {
+	now := time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC)
+	unix := func(d time.Duration) *int64 {
+		v := now.Add(d).Unix()
+		return &v
+	}
+
+	tcs := map[string]struct {
+		givenClaims RegisteredClaims
+		expErr      error
+	}{
+		"success": {
+			givenClaims: RegisteredClaims{IssuedAt: unix(-time.Minute), NotBefore: unix(-time.Minute), ExpiresAt: unix(time.Hour)},
+		},
+		"error - missing exp": {
+			givenClaims: RegisteredClaims{IssuedAt: unix(-time.Minute)},
+			expErr:      ErrMissingRequiredClaim,
+		},
+		"error - expired": {
+			givenClaims: RegisteredClaims{ExpiresAt: unix(-time.Second)},
+			expErr:      ErrTokenExpired,
+		},
+		"error - used before issued": {
+			givenClaims: RegisteredClaims{IssuedAt: unix(time.Minute), ExpiresAt: unix(time.Hour)},
+			expErr:      ErrTokenUsedBeforeIssued,
+		},
+		"error - not valid yet": {
+			givenClaims: RegisteredClaims{NotBefore: unix(time.Minute), ExpiresAt: unix(time.Hour)},
+			expErr:      ErrTokenNotValidYet,
+		},
+	}
+
+	defer func(origin func() time.Time) { timeNowFunc = origin }(timeNowFunc)
+	timeNowFunc = func() time.Time { return now }
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			claims := tc.givenClaims
+
+			// When
+			err := claims.Valid()
+
+			// Then
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
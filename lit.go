@@ -46,6 +46,25 @@ type Router interface {
 	//	}
 	Route(prefix string, middleware ...HandlerFunc) Router
 
+	// Versioned creates a versioned route group, the API version is resolved from the path, Accept header or a custom header.
+	// The request is routed to the handler of the requested version, or the latest older version that has a handler
+	//
+	// Usage:
+	//
+	//	func main() {
+	//		api := r.Versioned(lit.VersioningConfig{
+	//			Versions: []lit.APIVersion{
+	//				{Name: "v1", DeprecatedAt: deprecatedAt, Sunset: sunset, Link: "https://example.com/changelog#v2"},
+	//				{Name: "v2"},
+	//			},
+	//			Header: "API-Version",
+	//		})
+	//		api.Get("v1", "/orders/:id", getOrderV1)
+	//		api.Get("v2", "/orders/:id", getOrderV2)
+	//		api.Get("v1", "/orders", listOrders) // Also serves v2
+	//	}
+	Versioned(cfg VersioningConfig, middleware ...HandlerFunc) VersionedRoute
+
 	Routes() RoutesInfo
 
 	// Handler returns http standard handler
@@ -107,6 +126,27 @@ type Route interface {
 	StaticFS(relativePath string, fs http.FileSystem) Route
 }
 
+// VersionedRoute registers handlers for a specific API version
+type VersionedRoute interface {
+	// Handle registers a new request handle for the given version
+	Handle(version string, method string, relativePath string, handler HandlerFunc) VersionedRoute
+
+	// Get registers a GET request handler for the given version
+	Get(version string, relativePath string, handler HandlerFunc) VersionedRoute
+
+	// Post registers a POST request handler for the given version
+	Post(version string, relativePath string, handler HandlerFunc) VersionedRoute
+
+	// Delete registers a DELETE request handler for the given version
+	Delete(version string, relativePath string, handler HandlerFunc) VersionedRoute
+
+	// Patch registers a PATCH request handler for the given version
+	Patch(version string, relativePath string, handler HandlerFunc) VersionedRoute
+
+	// Put registers a PUT request handler for the given version
+	Put(version string, relativePath string, handler HandlerFunc) VersionedRoute
+}
+
 // ResponseWriter copy from gin.ResponseWriter
 type ResponseWriter interface {
 	http.ResponseWriter
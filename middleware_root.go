@@ -5,21 +5,38 @@ import (
 	"fmt"
 	"net/http"
 	"runtime/debug"
+	"time"
 
 	"github.com/viebiz/lit/monitoring"
 	"github.com/viebiz/lit/monitoring/instrumenthttp"
 )
 
 const (
+	// SkipLoggingResponseBodyKey is the Context key to exclude the response body from the request log
 	SkipLoggingResponseBodyKey = "skip_logging_response_body"
 )
 
 // rootMiddleware is a middleware function that handles tracing for incoming requests
 // and recovers from any panics that may occur during request handling
-func rootMiddleware(rootCtx context.Context) HandlerFunc {
+func rootMiddleware(rootCtx context.Context, cfg accessLogConfig) HandlerFunc {
 	return func(c Context) error {
+		startTime := time.Now()
+
 		// Start tracing for the incoming request
 		ctx, reqMeta, endInstrumentation := instrumenthttp.StartIncomingRequest(monitoring.FromContext(rootCtx), c.Request(), c.FullPath())
+
+		// Update context, set instrument context and capture the response body
+		c.SetRequestContext(ctx)
+		w := newCaptureWriter(ctx, c.Writer(), cfg.responseBodyLimit, reqMeta.Method, reqMeta.Path)
+		defer w.release()
+		c.SetWriter(w)
+
+		// Count the request body bytes read by the handlers
+		body := &countingBody{ReadCloser: c.Request().Body}
+		if body.ReadCloser != nil {
+			c.Request().Body = body
+		}
+
 		// Recovery logic when got panic
 		defer func() {
 			// Recover from any panic that may have occurred during request handling
@@ -40,65 +57,17 @@ func rootMiddleware(rootCtx context.Context) HandlerFunc {
 
 				// End the instrumentation, marking the request with a 500 status code and the error.
 				endInstrumentation(http.StatusInternalServerError, err)
+				logIncomingRequest(c, cfg, reqMeta, w, body, startTime)
 			}
 		}()
 
-		// Update context, set instrument context and update response writer
-		c.SetRequestContext(ctx)
-
-		c.SetWriter(wrapWriter(ctx, c.Writer(), c.Get, reqMeta.Method, reqMeta.Path))
-
 		// Continue handle request
 		c.Next()
 
 		// End instrumentation and log
 		endInstrumentation(c.Writer().Status(), nil)
-		logIncomingRequest(c, reqMeta, "http.incoming_request")
+		logIncomingRequest(c, cfg, reqMeta, w, body, startTime)
 
 		return nil
 	}
 }
-
-type responseRecorder struct {
-	ResponseWriter
-
-	ctx          context.Context
-	keyExtractor func(key string) (any, bool)
-	method, path string
-}
-
-func wrapWriter(ctx context.Context, w ResponseWriter, keyExtractor func(key string) (any, bool), method, path string) ResponseWriter {
-	return &responseRecorder{ResponseWriter: w, ctx: ctx, keyExtractor: keyExtractor, method: method, path: path}
-}
-
-func (w *responseRecorder) Write(resp []byte) (n int, err error) {
-	defer func() {
-		if err != nil {
-			monitoring.FromContext(w.ctx).Error(err, "[incoming_request] Failed to write response",
-				monitoring.StringField("http.request.method", w.method),
-				monitoring.StringField("url.path", w.path))
-		} else {
-			logFields := []monitoring.Field{
-				monitoring.StringField("http.request.method", w.method),
-				monitoring.StringField("url.path", w.path),
-			}
-			if _, exists := w.keyExtractor(SkipLoggingResponseBodyKey); !exists {
-				logFields = append(logFields, monitoring.JSONField("http.response.body", resp))
-			}
-
-			monitoring.FromContext(w.ctx).Info("[incoming_request] Wrote response", logFields...)
-		}
-	}()
-
-	return w.ResponseWriter.Write(resp)
-}
-
-func logIncomingRequest(ctx Context, reqMeta instrumenthttp.RequestMetadata, msg string) {
-	monitoring.FromContext(ctx.Request().Context()).Info(msg,
-		monitoring.StringField("http.request.method", reqMeta.Method),
-		monitoring.StringField("url.path", reqMeta.Path),
-		monitoring.StringField("url.query", reqMeta.Query),
-		monitoring.JSONField("http.request.body", reqMeta.BodyToLog),
-		monitoring.IntField("http.response.status_code", ctx.Writer().Status()),
-		monitoring.IntField("http.response.body.size", ctx.Writer().Size()))
-}
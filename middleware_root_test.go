@@ -47,8 +47,7 @@ func TestRootMiddleware(t *testing.T) {
 			expStatus: http.StatusOK,
 			expBody:   "{\"message\":\"pong\"}\n",
 			expLogs: []map[string]interface{}{
-				{"level": "INFO", "ts": "2025-02-23T18:18:48.186+0700", "msg": "[incoming_request] Wrote response", "http.request.method": "GET", "url.path": "/ping", "http.response.body": map[string]any{"message": "pong"}, "server.name": "lightning", "environment": "dev", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
-				{"level": "INFO", "ts": "2025-02-23T18:23:26.434+0700", "msg": "http.incoming_request", "http.request.method": "GET", "url.path": "/ping", "url.query": "", "http.response.body.size": float64(19), "http.response.status_code": float64(200), "server.name": "lightning", "environment": "dev", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
+				{"level": "INFO", "ts": "2025-02-23T18:23:26.434+0700", "msg": "http.incoming_request", "http.request.method": "GET", "url.path": "/ping", "url.query": "", "http.response.body": map[string]any{"message": "pong"}, "http.response.body.size": float64(19), "http.response.status_code": float64(200), "server.name": "lightning", "environment": "dev", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
 			},
 		},
 		"success - POST method": {
@@ -70,8 +69,7 @@ func TestRootMiddleware(t *testing.T) {
 			expStatus: http.StatusOK,
 			expBody:   "{\"message\":\"Hello lightning\"}\n",
 			expLogs: []map[string]interface{}{
-				{"level": "INFO", "ts": "2025-02-23T18:18:48.186+0700", "msg": "[incoming_request] Wrote response", "http.request.method": "POST", "url.path": "/ping", "http.response.body": map[string]any{"message": "Hello lightning"}, "server.name": "lightning", "environment": "dev", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
-				{"level": "INFO", "ts": "2025-02-23T18:23:26.434+0700", "msg": "http.incoming_request", "http.request.method": "POST", "url.path": "/ping", "url.query": "", "http.request.body": map[string]any{"message": "Hello lightning"}, "http.response.body.size": float64(30), "http.response.status_code": float64(200), "server.name": "lightning", "environment": "dev", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
+				{"level": "INFO", "ts": "2025-02-23T18:23:26.434+0700", "msg": "http.incoming_request", "http.request.method": "POST", "url.path": "/ping", "url.query": "", "http.response.body": map[string]any{"message": "Hello lightning"}, "http.request.body": map[string]any{"message": "Hello lightning"}, "http.response.body.size": float64(30), "http.response.status_code": float64(200), "server.name": "lightning", "environment": "dev", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
 			},
 		},
 		"error - Expected error": {
@@ -86,8 +84,7 @@ func TestRootMiddleware(t *testing.T) {
 			expStatus: http.StatusBadRequest,
 			expBody:   "{\"error\":\"validation_error\",\"error_description\":\"Invalid request\"}\n",
 			expLogs: []map[string]interface{}{
-				{"level": "INFO", "ts": "2025-02-23T18:18:48.186+0700", "msg": "[incoming_request] Wrote response", "http.request.method": "PATCH", "url.path": "/ping", "http.response.body": map[string]any{"error": "validation_error", "error_description": "Invalid request"}, "server.name": "lightning", "environment": "dev", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
-				{"level": "INFO", "ts": "2025-02-23T18:23:26.434+0700", "msg": "http.incoming_request", "http.request.method": "PATCH", "url.path": "/ping", "url.query": "", "http.request.body": map[string]any{"message": "pong"}, "http.response.body.size": float64(67), "http.response.status_code": float64(400), "server.name": "lightning", "environment": "dev", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
+				{"level": "WARN", "ts": "2025-02-23T18:23:26.434+0700", "msg": "http.incoming_request", "http.request.method": "PATCH", "url.path": "/ping", "url.query": "", "http.response.body": map[string]any{"error": "validation_error", "error_description": "Invalid request"}, "http.request.body": map[string]any{"message": "pong"}, "http.response.body.size": float64(67), "http.response.status_code": float64(400), "server.name": "lightning", "environment": "dev", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
 			},
 		},
 		"error - PANIC request": {
@@ -103,7 +100,7 @@ func TestRootMiddleware(t *testing.T) {
 			expBody:   "{\"error\":\"Internal Server Error\",\"error_description\":\"Internal Server Error\"}\n",
 			expLogs: []map[string]interface{}{
 				{"environment": "dev", "level": "ERROR", "msg": "Caught a panic", "error.kind": "*errors.errorString", "error.message": "simulated panic", "server.name": "lightning", "ts": "2025-02-23T18:43:12.5460700", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
-				{"level": "INFO", "ts": "2025-02-23T18:18:48.186+0700", "msg": "[incoming_request] Wrote response", "http.request.method": "PATCH", "url.path": "/ping", "http.response.body": map[string]any{"error": "Internal Server Error", "error_description": "Internal Server Error"}, "server.name": "lightning", "environment": "dev", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
+				{"level": "ERROR", "ts": "2025-02-23T18:23:26.434+0700", "msg": "http.incoming_request", "http.request.method": "PATCH", "url.path": "/ping", "url.query": "", "http.request.body": map[string]any{"message": "pong"}, "http.response.body": map[string]any{"error": "Internal Server Error", "error_description": "Internal Server Error"}, "http.response.body.size": float64(78), "http.response.status_code": float64(500), "server.name": "lightning", "environment": "dev", "version": "1.0.0", "trace_id": "00000000000000000000000000000001", "span_id": "0000000000000001"},
 			},
 		},
 	}
@@ -121,7 +118,7 @@ func TestRootMiddleware(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			r, ctx, handleRequest := NewRouterForTest(w)
-			r.Use(rootMiddleware(monitorCtx))
+			r.Use(rootMiddleware(monitorCtx, defaultAccessLogConfig()))
 			r.Handle(tc.hdl.Method, tc.hdl.Path, tc.hdl.Func)
 
 			if slices.Contains([]string{http.MethodPost, http.MethodPut, http.MethodPatch}, tc.givenReq.Method) {
@@ -156,6 +153,122 @@ func TestRootMiddleware(t *testing.T) {
 	}
 }
 
+func TestRootMiddleware_ResponseBodyCapture(t *testing.T) {
+	tp := mocktracer.Start()
+	defer tp.Stop()
+
+	tcs := map[string]struct {
+		givenLimit  int
+		givenHandle HandlerFunc
+		expFields   map[string]interface{}
+		expNoFields []string
+	}{
+		"streaming - combined in one record": {
+			givenLimit: 1024,
+			givenHandle: func(c Context) error {
+				c.Header("Content-Type", "text/plain; charset=utf-8")
+				c.Writer().WriteString("chunk-1;")
+				c.Writer().Flush()
+				c.Writer().Write([]byte("chunk-2"))
+				return nil
+			},
+			expFields: map[string]interface{}{
+				"http.response.body":      "chunk-1;chunk-2",
+				"http.response.body.size": float64(15),
+			},
+			expNoFields: []string{"http.response.body.truncated"},
+		},
+		"truncated": {
+			givenLimit: 10,
+			givenHandle: func(c Context) error {
+				return c.JSON(http.StatusOK, map[string]string{"message": "a long message"})
+			},
+			expFields: map[string]interface{}{
+				"http.response.body":           `{"message"...[truncated]`,
+				"http.response.body.truncated": true,
+				"http.response.body.size":      float64(29),
+			},
+		},
+		"binary content type": {
+			givenLimit: 1024,
+			givenHandle: func(c Context) error {
+				c.Header("Content-Type", "image/png")
+				_, err := c.Writer().Write([]byte("\x89PNG\r\n\x1a\n"))
+				return err
+			},
+			expFields: map[string]interface{}{
+				"http.response.body.size": float64(8),
+			},
+			expNoFields: []string{"http.response.body"},
+		},
+		"skip logging response body": {
+			givenLimit: 1024,
+			givenHandle: func(c Context) error {
+				c.Set(SkipLoggingResponseBodyKey, true)
+				return c.JSON(http.StatusOK, map[string]string{"token": "secret"})
+			},
+			expFields: map[string]interface{}{
+				"http.response.body.size": float64(19),
+			},
+			expNoFields: []string{"http.response.body"},
+		},
+		"disabled": {
+			givenLimit: 0,
+			givenHandle: func(c Context) error {
+				return c.String(http.StatusOK, "pong")
+			},
+			expNoFields: []string{"http.response.body"},
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			logBuffer := bytes.NewBuffer(nil)
+			m, err := monitoring.New(monitoring.Config{ServerName: "lightning", Environment: "dev", Version: "1.0.0", Writer: logBuffer})
+			require.NoError(t, err)
+			monitorCtx := monitoring.SetInContext(context.Background(), m)
+
+			w := httptest.NewRecorder()
+			r, ctx, handleRequest := NewRouterForTest(w)
+			cfg := defaultAccessLogConfig()
+			cfg.responseBodyLimit = tc.givenLimit
+			r.Use(rootMiddleware(monitorCtx, cfg))
+			r.Get("/ping", tc.givenHandle)
+			ctx.SetRequest(httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+			// When
+			handleRequest()
+
+			// Then
+			parsedLogs, err := parseLog(logBuffer.Bytes(), 2) // Skip 2 init log
+			require.NoError(t, err)
+			require.Len(t, parsedLogs, 1)
+			require.Equal(t, "http.incoming_request", parsedLogs[0]["msg"])
+			for k, v := range tc.expFields {
+				require.Equal(t, v, parsedLogs[0][k], k)
+			}
+			for _, k := range tc.expNoFields {
+				require.NotContains(t, parsedLogs[0], k)
+			}
+		})
+	}
+}
+
+func TestWithResponseBodyLogLimit(t *testing.T) {
+	r := NewRouter(context.Background(), WithResponseBodyLogLimit(128))
+	require.Equal(t, 128, r.(*router).accessLog.responseBodyLimit)
+
+	r = NewRouter(context.Background(), WithResponseBodyLogLimit(-1))
+	require.Equal(t, 0, r.(*router).accessLog.responseBodyLimit)
+
+	r = NewRouter(context.Background())
+	require.Equal(t, defaultResponseBodyLogLimit, r.(*router).accessLog.responseBodyLimit)
+}
+
 func parseLog(b []byte, skip int) ([]map[string]interface{}, error) {
 	var result []map[string]interface{}
 	for idx, s := range strings.Split(string(b), "\n") {
@@ -0,0 +1,156 @@
+package lit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/viebiz/lit/monitoring"
+)
+
+const (
+	truncatedMarker = "...[truncated]"
+)
+
+var bodyBufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// captureWriter accumulates the response body up to the limit, so it's logged once in the request log
+// instead of on every Write call
+type captureWriter struct {
+	ResponseWriter
+
+	ctx          context.Context
+	method, path string
+	limit        int
+
+	checked   bool // Whether the content type has been checked
+	capturing bool
+	truncated bool
+	body      *bytes.Buffer
+}
+
+func newCaptureWriter(ctx context.Context, w ResponseWriter, limit int, method, path string) *captureWriter {
+	return &captureWriter{
+		ResponseWriter: w,
+		ctx:            ctx,
+		method:         method,
+		path:           path,
+		limit:          limit,
+	}
+}
+
+func (w *captureWriter) Write(resp []byte) (int, error) {
+	w.capture(resp)
+
+	n, err := w.ResponseWriter.Write(resp)
+	if err != nil {
+		w.logWriteErr(err)
+	}
+
+	return n, err
+}
+
+func (w *captureWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+
+	n, err := w.ResponseWriter.WriteString(s)
+	if err != nil {
+		w.logWriteErr(err)
+	}
+
+	return n, err
+}
+
+func (w *captureWriter) capture(p []byte) {
+	if !w.checked {
+		w.checked = true
+		w.capturing = w.limit > 0 && isTextualContent(w.Header().Get("Content-Type"), p)
+		if w.capturing {
+			w.body = bodyBufferPool.Get().(*bytes.Buffer)
+		}
+	}
+
+	if !w.capturing || w.truncated {
+		return
+	}
+
+	if remaining := w.limit - w.body.Len(); len(p) > remaining {
+		p = p[:remaining]
+		w.truncated = true
+	}
+	w.body.Write(p)
+}
+
+// bodyLogFields appends the log fields of the captured response body.
+// A complete JSON body is logged as JSON, otherwise it's logged as a string with the truncation marker
+func (w *captureWriter) bodyLogFields(fields []monitoring.Field, key string) []monitoring.Field {
+	if w.body == nil || w.body.Len() == 0 {
+		return fields
+	}
+
+	body := w.body.Bytes()
+	if !w.truncated && json.Valid(body) {
+		return append(fields, monitoring.JSONField(key, bytes.Clone(body)))
+	}
+
+	if !w.truncated {
+		return append(fields, monitoring.StringField(key, string(body)))
+	}
+
+	return append(fields,
+		monitoring.StringField(key, string(body)+truncatedMarker),
+		monitoring.BoolField(key+".truncated", true),
+	)
+}
+
+// release returns the body buffer to the pool, the writer must not be used after
+func (w *captureWriter) release() {
+	if w.body == nil {
+		return
+	}
+
+	w.body.Reset()
+	bodyBufferPool.Put(w.body)
+	w.body = nil
+	w.capturing = false
+}
+
+func (w *captureWriter) logWriteErr(err error) {
+	monitoring.FromContext(w.ctx).Error(err, "[incoming_request] Failed to write response",
+		monitoring.StringField("http.request.method", w.method),
+		monitoring.StringField("url.path", w.path))
+}
+
+// isTextualContent reports whether the response body is human-readable, binary content is not logged
+func isTextualContent(contentType string, firstChunk []byte) bool {
+	if contentType == "" {
+		contentType = http.DetectContentType(firstChunk)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript", "application/x-www-form-urlencoded", "application/problem+json":
+		return true
+	}
+
+	return false
+}
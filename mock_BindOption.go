@@ -0,0 +1,65 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package lit
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockBindOption is an autogenerated mock type for the BindOption type
+type MockBindOption struct {
+	mock.Mock
+}
+
+type MockBindOption_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBindOption) EXPECT() *MockBindOption_Expecter {
+	return &MockBindOption_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: _a0
+func (_m *MockBindOption) Execute(_a0 *bindConfig) {
+	_m.Called(_a0)
+}
+
+// MockBindOption_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockBindOption_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - _a0 *bindConfig
+func (_e *MockBindOption_Expecter) Execute(_a0 interface{}) *MockBindOption_Execute_Call {
+	return &MockBindOption_Execute_Call{Call: _e.mock.On("Execute", _a0)}
+}
+
+func (_c *MockBindOption_Execute_Call) Run(run func(_a0 *bindConfig)) *MockBindOption_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*bindConfig))
+	})
+	return _c
+}
+
+func (_c *MockBindOption_Execute_Call) Return() *MockBindOption_Execute_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockBindOption_Execute_Call) RunAndReturn(run func(*bindConfig)) *MockBindOption_Execute_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockBindOption creates a new instance of MockBindOption. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBindOption(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBindOption {
+	mock := &MockBindOption{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
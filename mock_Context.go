@@ -11,6 +11,8 @@ import (
 	multipart "mime/multipart"
 
 	time "time"
+
+	uuid "github.com/google/uuid"
 )
 
 // MockContext is an autogenerated mock type for the Context type
@@ -58,17 +60,24 @@ func (_c *MockContext_Abort_Call) RunAndReturn(run func()) *MockContext_Abort_Ca
 	return _c
 }
 
-// Bind provides a mock function with given fields: obj
-func (_m *MockContext) Bind(obj interface{}) error {
-	ret := _m.Called(obj)
+// Bind provides a mock function with given fields: obj, opts
+func (_m *MockContext) Bind(obj interface{}, opts ...BindOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, obj)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Bind")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
-		r0 = rf(obj)
+	if rf, ok := ret.Get(0).(func(interface{}, ...BindOption) error); ok {
+		r0 = rf(obj, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -83,13 +92,21 @@ type MockContext_Bind_Call struct {
 
 // Bind is a helper method to define mock.On call
 //   - obj interface{}
-func (_e *MockContext_Expecter) Bind(obj interface{}) *MockContext_Bind_Call {
-	return &MockContext_Bind_Call{Call: _e.mock.On("Bind", obj)}
+//   - opts ...BindOption
+func (_e *MockContext_Expecter) Bind(obj interface{}, opts ...interface{}) *MockContext_Bind_Call {
+	return &MockContext_Bind_Call{Call: _e.mock.On("Bind",
+		append([]interface{}{obj}, opts...)...)}
 }
 
-func (_c *MockContext_Bind_Call) Run(run func(obj interface{})) *MockContext_Bind_Call {
+func (_c *MockContext_Bind_Call) Run(run func(obj interface{}, opts ...BindOption)) *MockContext_Bind_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(interface{}))
+		variadicArgs := make([]BindOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(BindOption)
+			}
+		}
+		run(args[0].(interface{}), variadicArgs...)
 	})
 	return _c
 }
@@ -99,7 +116,108 @@ func (_c *MockContext_Bind_Call) Return(_a0 error) *MockContext_Bind_Call {
 	return _c
 }
 
-func (_c *MockContext_Bind_Call) RunAndReturn(run func(interface{}) error) *MockContext_Bind_Call {
+func (_c *MockContext_Bind_Call) RunAndReturn(run func(interface{}, ...BindOption) error) *MockContext_Bind_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClientIP provides a mock function with no fields
+func (_m *MockContext) ClientIP() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClientIP")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockContext_ClientIP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClientIP'
+type MockContext_ClientIP_Call struct {
+	*mock.Call
+}
+
+// ClientIP is a helper method to define mock.On call
+func (_e *MockContext_Expecter) ClientIP() *MockContext_ClientIP_Call {
+	return &MockContext_ClientIP_Call{Call: _e.mock.On("ClientIP")}
+}
+
+func (_c *MockContext_ClientIP_Call) Run(run func()) *MockContext_ClientIP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockContext_ClientIP_Call) Return(_a0 string) *MockContext_ClientIP_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockContext_ClientIP_Call) RunAndReturn(run func() string) *MockContext_ClientIP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Cookie provides a mock function with given fields: name
+func (_m *MockContext) Cookie(name string) (string, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cookie")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockContext_Cookie_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cookie'
+type MockContext_Cookie_Call struct {
+	*mock.Call
+}
+
+// Cookie is a helper method to define mock.On call
+//   - name string
+func (_e *MockContext_Expecter) Cookie(name interface{}) *MockContext_Cookie_Call {
+	return &MockContext_Cookie_Call{Call: _e.mock.On("Cookie", name)}
+}
+
+func (_c *MockContext_Cookie_Call) Run(run func(name string)) *MockContext_Cookie_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockContext_Cookie_Call) Return(_a0 string, _a1 error) *MockContext_Cookie_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockContext_Cookie_Call) RunAndReturn(run func(string) (string, error)) *MockContext_Cookie_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -445,6 +563,52 @@ func (_c *MockContext_Get_Call) RunAndReturn(run func(string) (any, bool)) *Mock
 	return _c
 }
 
+// GetHeader provides a mock function with given fields: key
+func (_m *MockContext) GetHeader(key string) string {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHeader")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockContext_GetHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHeader'
+type MockContext_GetHeader_Call struct {
+	*mock.Call
+}
+
+// GetHeader is a helper method to define mock.On call
+//   - key string
+func (_e *MockContext_Expecter) GetHeader(key interface{}) *MockContext_GetHeader_Call {
+	return &MockContext_GetHeader_Call{Call: _e.mock.On("GetHeader", key)}
+}
+
+func (_c *MockContext_GetHeader_Call) Run(run func(key string)) *MockContext_GetHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockContext_GetHeader_Call) Return(_a0 string) *MockContext_GetHeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockContext_GetHeader_Call) RunAndReturn(run func(string) string) *MockContext_GetHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Header provides a mock function with given fields: key, value
 func (_m *MockContext) Header(key string, value string) {
 	_m.Called(key, value)
@@ -707,6 +871,120 @@ func (_c *MockContext_Param_Call) RunAndReturn(run func(string) string) *MockCon
 	return _c
 }
 
+// ParamInt provides a mock function with given fields: key
+func (_m *MockContext) ParamInt(key string) (int, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParamInt")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockContext_ParamInt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParamInt'
+type MockContext_ParamInt_Call struct {
+	*mock.Call
+}
+
+// ParamInt is a helper method to define mock.On call
+//   - key string
+func (_e *MockContext_Expecter) ParamInt(key interface{}) *MockContext_ParamInt_Call {
+	return &MockContext_ParamInt_Call{Call: _e.mock.On("ParamInt", key)}
+}
+
+func (_c *MockContext_ParamInt_Call) Run(run func(key string)) *MockContext_ParamInt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockContext_ParamInt_Call) Return(_a0 int, _a1 error) *MockContext_ParamInt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockContext_ParamInt_Call) RunAndReturn(run func(string) (int, error)) *MockContext_ParamInt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ParamUUID provides a mock function with given fields: key
+func (_m *MockContext) ParamUUID(key string) (uuid.UUID, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParamUUID")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (uuid.UUID, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) uuid.UUID); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockContext_ParamUUID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParamUUID'
+type MockContext_ParamUUID_Call struct {
+	*mock.Call
+}
+
+// ParamUUID is a helper method to define mock.On call
+//   - key string
+func (_e *MockContext_Expecter) ParamUUID(key interface{}) *MockContext_ParamUUID_Call {
+	return &MockContext_ParamUUID_Call{Call: _e.mock.On("ParamUUID", key)}
+}
+
+func (_c *MockContext_ParamUUID_Call) Run(run func(key string)) *MockContext_ParamUUID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockContext_ParamUUID_Call) Return(_a0 uuid.UUID, _a1 error) *MockContext_ParamUUID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockContext_ParamUUID_Call) RunAndReturn(run func(string) (uuid.UUID, error)) *MockContext_ParamUUID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ParamWithCallback provides a mock function with given fields: key, callback
 func (_m *MockContext) ParamWithCallback(key string, callback func() string) string {
 	ret := _m.Called(key, callback)
@@ -847,6 +1125,329 @@ func (_c *MockContext_Query_Call) RunAndReturn(run func(string) string) *MockCon
 	return _c
 }
 
+// QueryArray provides a mock function with given fields: key
+func (_m *MockContext) QueryArray(key string) []string {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryArray")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// MockContext_QueryArray_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryArray'
+type MockContext_QueryArray_Call struct {
+	*mock.Call
+}
+
+// QueryArray is a helper method to define mock.On call
+//   - key string
+func (_e *MockContext_Expecter) QueryArray(key interface{}) *MockContext_QueryArray_Call {
+	return &MockContext_QueryArray_Call{Call: _e.mock.On("QueryArray", key)}
+}
+
+func (_c *MockContext_QueryArray_Call) Run(run func(key string)) *MockContext_QueryArray_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockContext_QueryArray_Call) Return(_a0 []string) *MockContext_QueryArray_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockContext_QueryArray_Call) RunAndReturn(run func(string) []string) *MockContext_QueryArray_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryBool provides a mock function with given fields: key
+func (_m *MockContext) QueryBool(key string) (bool, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryBool")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockContext_QueryBool_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryBool'
+type MockContext_QueryBool_Call struct {
+	*mock.Call
+}
+
+// QueryBool is a helper method to define mock.On call
+//   - key string
+func (_e *MockContext_Expecter) QueryBool(key interface{}) *MockContext_QueryBool_Call {
+	return &MockContext_QueryBool_Call{Call: _e.mock.On("QueryBool", key)}
+}
+
+func (_c *MockContext_QueryBool_Call) Run(run func(key string)) *MockContext_QueryBool_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockContext_QueryBool_Call) Return(_a0 bool, _a1 error) *MockContext_QueryBool_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockContext_QueryBool_Call) RunAndReturn(run func(string) (bool, error)) *MockContext_QueryBool_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryInt provides a mock function with given fields: key
+func (_m *MockContext) QueryInt(key string) (int, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryInt")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockContext_QueryInt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryInt'
+type MockContext_QueryInt_Call struct {
+	*mock.Call
+}
+
+// QueryInt is a helper method to define mock.On call
+//   - key string
+func (_e *MockContext_Expecter) QueryInt(key interface{}) *MockContext_QueryInt_Call {
+	return &MockContext_QueryInt_Call{Call: _e.mock.On("QueryInt", key)}
+}
+
+func (_c *MockContext_QueryInt_Call) Run(run func(key string)) *MockContext_QueryInt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockContext_QueryInt_Call) Return(_a0 int, _a1 error) *MockContext_QueryInt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockContext_QueryInt_Call) RunAndReturn(run func(string) (int, error)) *MockContext_QueryInt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryMap provides a mock function with given fields: key
+func (_m *MockContext) QueryMap(key string) map[string]string {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryMap")
+	}
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string) map[string]string); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
+// MockContext_QueryMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryMap'
+type MockContext_QueryMap_Call struct {
+	*mock.Call
+}
+
+// QueryMap is a helper method to define mock.On call
+//   - key string
+func (_e *MockContext_Expecter) QueryMap(key interface{}) *MockContext_QueryMap_Call {
+	return &MockContext_QueryMap_Call{Call: _e.mock.On("QueryMap", key)}
+}
+
+func (_c *MockContext_QueryMap_Call) Run(run func(key string)) *MockContext_QueryMap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockContext_QueryMap_Call) Return(_a0 map[string]string) *MockContext_QueryMap_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockContext_QueryMap_Call) RunAndReturn(run func(string) map[string]string) *MockContext_QueryMap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryTime provides a mock function with given fields: key, layout
+func (_m *MockContext) QueryTime(key string, layout string) (time.Time, error) {
+	ret := _m.Called(key, layout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryTime")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (time.Time, error)); ok {
+		return rf(key, layout)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) time.Time); ok {
+		r0 = rf(key, layout)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, layout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockContext_QueryTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryTime'
+type MockContext_QueryTime_Call struct {
+	*mock.Call
+}
+
+// QueryTime is a helper method to define mock.On call
+//   - key string
+//   - layout string
+func (_e *MockContext_Expecter) QueryTime(key interface{}, layout interface{}) *MockContext_QueryTime_Call {
+	return &MockContext_QueryTime_Call{Call: _e.mock.On("QueryTime", key, layout)}
+}
+
+func (_c *MockContext_QueryTime_Call) Run(run func(key string, layout string)) *MockContext_QueryTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockContext_QueryTime_Call) Return(_a0 time.Time, _a1 error) *MockContext_QueryTime_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockContext_QueryTime_Call) RunAndReturn(run func(string, string) (time.Time, error)) *MockContext_QueryTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryUUID provides a mock function with given fields: key
+func (_m *MockContext) QueryUUID(key string) (uuid.UUID, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryUUID")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (uuid.UUID, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) uuid.UUID); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockContext_QueryUUID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryUUID'
+type MockContext_QueryUUID_Call struct {
+	*mock.Call
+}
+
+// QueryUUID is a helper method to define mock.On call
+//   - key string
+func (_e *MockContext_Expecter) QueryUUID(key interface{}) *MockContext_QueryUUID_Call {
+	return &MockContext_QueryUUID_Call{Call: _e.mock.On("QueryUUID", key)}
+}
+
+func (_c *MockContext_QueryUUID_Call) Run(run func(key string)) *MockContext_QueryUUID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockContext_QueryUUID_Call) Return(_a0 uuid.UUID, _a1 error) *MockContext_QueryUUID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockContext_QueryUUID_Call) RunAndReturn(run func(string) (uuid.UUID, error)) *MockContext_QueryUUID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // QueryWithCallback provides a mock function with given fields: key, callback
 func (_m *MockContext) QueryWithCallback(key string, callback func() string) string {
 	ret := _m.Called(key, callback)
@@ -941,6 +1542,53 @@ func (_c *MockContext_QueryWithDefault_Call) RunAndReturn(run func(string, strin
 	return _c
 }
 
+// Redirect provides a mock function with given fields: code, location
+func (_m *MockContext) Redirect(code int, location string) error {
+	ret := _m.Called(code, location)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Redirect")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(code, location)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockContext_Redirect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Redirect'
+type MockContext_Redirect_Call struct {
+	*mock.Call
+}
+
+// Redirect is a helper method to define mock.On call
+//   - code int
+//   - location string
+func (_e *MockContext_Expecter) Redirect(code interface{}, location interface{}) *MockContext_Redirect_Call {
+	return &MockContext_Redirect_Call{Call: _e.mock.On("Redirect", code, location)}
+}
+
+func (_c *MockContext_Redirect_Call) Run(run func(code int, location string)) *MockContext_Redirect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockContext_Redirect_Call) Return(_a0 error) *MockContext_Redirect_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockContext_Redirect_Call) RunAndReturn(run func(int, string) error) *MockContext_Redirect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Request provides a mock function with no fields
 func (_m *MockContext) Request() *http.Request {
 	ret := _m.Called()
@@ -1022,6 +1670,39 @@ func (_c *MockContext_Set_Call) RunAndReturn(run func(string, any)) *MockContext
 	return _c
 }
 
+// SetCookie provides a mock function with given fields: cookie
+func (_m *MockContext) SetCookie(cookie *http.Cookie) {
+	_m.Called(cookie)
+}
+
+// MockContext_SetCookie_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCookie'
+type MockContext_SetCookie_Call struct {
+	*mock.Call
+}
+
+// SetCookie is a helper method to define mock.On call
+//   - cookie *http.Cookie
+func (_e *MockContext_Expecter) SetCookie(cookie interface{}) *MockContext_SetCookie_Call {
+	return &MockContext_SetCookie_Call{Call: _e.mock.On("SetCookie", cookie)}
+}
+
+func (_c *MockContext_SetCookie_Call) Run(run func(cookie *http.Cookie)) *MockContext_SetCookie_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Cookie))
+	})
+	return _c
+}
+
+func (_c *MockContext_SetCookie_Call) Return() *MockContext_SetCookie_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockContext_SetCookie_Call) RunAndReturn(run func(*http.Cookie)) *MockContext_SetCookie_Call {
+	_c.Run(run)
+	return _c
+}
+
 // SetRequest provides a mock function with given fields: _a0
 func (_m *MockContext) SetRequest(_a0 *http.Request) {
 	_m.Called(_a0)
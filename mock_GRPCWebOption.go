@@ -0,0 +1,65 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package lit
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockGRPCWebOption is an autogenerated mock type for the GRPCWebOption type
+type MockGRPCWebOption struct {
+	mock.Mock
+}
+
+type MockGRPCWebOption_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGRPCWebOption) EXPECT() *MockGRPCWebOption_Expecter {
+	return &MockGRPCWebOption_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: cfg
+func (_m *MockGRPCWebOption) Execute(cfg *grpcWebConfig) {
+	_m.Called(cfg)
+}
+
+// MockGRPCWebOption_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockGRPCWebOption_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - cfg *grpcWebConfig
+func (_e *MockGRPCWebOption_Expecter) Execute(cfg interface{}) *MockGRPCWebOption_Execute_Call {
+	return &MockGRPCWebOption_Execute_Call{Call: _e.mock.On("Execute", cfg)}
+}
+
+func (_c *MockGRPCWebOption_Execute_Call) Run(run func(cfg *grpcWebConfig)) *MockGRPCWebOption_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*grpcWebConfig))
+	})
+	return _c
+}
+
+func (_c *MockGRPCWebOption_Execute_Call) Return() *MockGRPCWebOption_Execute_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockGRPCWebOption_Execute_Call) RunAndReturn(run func(*grpcWebConfig)) *MockGRPCWebOption_Execute_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockGRPCWebOption creates a new instance of MockGRPCWebOption. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGRPCWebOption(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGRPCWebOption {
+	mock := &MockGRPCWebOption{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -965,6 +965,69 @@ func (_c *MockRouter_Use_Call) RunAndReturn(run func(...HandlerFunc)) *MockRoute
 	return _c
 }
 
+// Versioned provides a mock function with given fields: cfg, middleware
+func (_m *MockRouter) Versioned(cfg VersioningConfig, middleware ...HandlerFunc) VersionedRoute {
+	_va := make([]interface{}, len(middleware))
+	for _i := range middleware {
+		_va[_i] = middleware[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, cfg)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Versioned")
+	}
+
+	var r0 VersionedRoute
+	if rf, ok := ret.Get(0).(func(VersioningConfig, ...HandlerFunc) VersionedRoute); ok {
+		r0 = rf(cfg, middleware...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(VersionedRoute)
+		}
+	}
+
+	return r0
+}
+
+// MockRouter_Versioned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Versioned'
+type MockRouter_Versioned_Call struct {
+	*mock.Call
+}
+
+// Versioned is a helper method to define mock.On call
+//   - cfg VersioningConfig
+//   - middleware ...HandlerFunc
+func (_e *MockRouter_Expecter) Versioned(cfg interface{}, middleware ...interface{}) *MockRouter_Versioned_Call {
+	return &MockRouter_Versioned_Call{Call: _e.mock.On("Versioned",
+		append([]interface{}{cfg}, middleware...)...)}
+}
+
+func (_c *MockRouter_Versioned_Call) Run(run func(cfg VersioningConfig, middleware ...HandlerFunc)) *MockRouter_Versioned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]HandlerFunc, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(HandlerFunc)
+			}
+		}
+		run(args[0].(VersioningConfig), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRouter_Versioned_Call) Return(_a0 VersionedRoute) *MockRouter_Versioned_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRouter_Versioned_Call) RunAndReturn(run func(VersioningConfig, ...HandlerFunc) VersionedRoute) *MockRouter_Versioned_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRouter creates a new instance of MockRouter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRouter(t interface {
@@ -0,0 +1,333 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package lit
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockVersionedRoute is an autogenerated mock type for the VersionedRoute type
+type MockVersionedRoute struct {
+	mock.Mock
+}
+
+type MockVersionedRoute_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockVersionedRoute) EXPECT() *MockVersionedRoute_Expecter {
+	return &MockVersionedRoute_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: version, relativePath, handler
+func (_m *MockVersionedRoute) Delete(version string, relativePath string, handler HandlerFunc) VersionedRoute {
+	ret := _m.Called(version, relativePath, handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 VersionedRoute
+	if rf, ok := ret.Get(0).(func(string, string, HandlerFunc) VersionedRoute); ok {
+		r0 = rf(version, relativePath, handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(VersionedRoute)
+		}
+	}
+
+	return r0
+}
+
+// MockVersionedRoute_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockVersionedRoute_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - version string
+//   - relativePath string
+//   - handler HandlerFunc
+func (_e *MockVersionedRoute_Expecter) Delete(version interface{}, relativePath interface{}, handler interface{}) *MockVersionedRoute_Delete_Call {
+	return &MockVersionedRoute_Delete_Call{Call: _e.mock.On("Delete", version, relativePath, handler)}
+}
+
+func (_c *MockVersionedRoute_Delete_Call) Run(run func(version string, relativePath string, handler HandlerFunc)) *MockVersionedRoute_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(HandlerFunc))
+	})
+	return _c
+}
+
+func (_c *MockVersionedRoute_Delete_Call) Return(_a0 VersionedRoute) *MockVersionedRoute_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockVersionedRoute_Delete_Call) RunAndReturn(run func(string, string, HandlerFunc) VersionedRoute) *MockVersionedRoute_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: version, relativePath, handler
+func (_m *MockVersionedRoute) Get(version string, relativePath string, handler HandlerFunc) VersionedRoute {
+	ret := _m.Called(version, relativePath, handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 VersionedRoute
+	if rf, ok := ret.Get(0).(func(string, string, HandlerFunc) VersionedRoute); ok {
+		r0 = rf(version, relativePath, handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(VersionedRoute)
+		}
+	}
+
+	return r0
+}
+
+// MockVersionedRoute_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockVersionedRoute_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - version string
+//   - relativePath string
+//   - handler HandlerFunc
+func (_e *MockVersionedRoute_Expecter) Get(version interface{}, relativePath interface{}, handler interface{}) *MockVersionedRoute_Get_Call {
+	return &MockVersionedRoute_Get_Call{Call: _e.mock.On("Get", version, relativePath, handler)}
+}
+
+func (_c *MockVersionedRoute_Get_Call) Run(run func(version string, relativePath string, handler HandlerFunc)) *MockVersionedRoute_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(HandlerFunc))
+	})
+	return _c
+}
+
+func (_c *MockVersionedRoute_Get_Call) Return(_a0 VersionedRoute) *MockVersionedRoute_Get_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockVersionedRoute_Get_Call) RunAndReturn(run func(string, string, HandlerFunc) VersionedRoute) *MockVersionedRoute_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Handle provides a mock function with given fields: version, method, relativePath, handler
+func (_m *MockVersionedRoute) Handle(version string, method string, relativePath string, handler HandlerFunc) VersionedRoute {
+	ret := _m.Called(version, method, relativePath, handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Handle")
+	}
+
+	var r0 VersionedRoute
+	if rf, ok := ret.Get(0).(func(string, string, string, HandlerFunc) VersionedRoute); ok {
+		r0 = rf(version, method, relativePath, handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(VersionedRoute)
+		}
+	}
+
+	return r0
+}
+
+// MockVersionedRoute_Handle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Handle'
+type MockVersionedRoute_Handle_Call struct {
+	*mock.Call
+}
+
+// Handle is a helper method to define mock.On call
+//   - version string
+//   - method string
+//   - relativePath string
+//   - handler HandlerFunc
+func (_e *MockVersionedRoute_Expecter) Handle(version interface{}, method interface{}, relativePath interface{}, handler interface{}) *MockVersionedRoute_Handle_Call {
+	return &MockVersionedRoute_Handle_Call{Call: _e.mock.On("Handle", version, method, relativePath, handler)}
+}
+
+func (_c *MockVersionedRoute_Handle_Call) Run(run func(version string, method string, relativePath string, handler HandlerFunc)) *MockVersionedRoute_Handle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(HandlerFunc))
+	})
+	return _c
+}
+
+func (_c *MockVersionedRoute_Handle_Call) Return(_a0 VersionedRoute) *MockVersionedRoute_Handle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockVersionedRoute_Handle_Call) RunAndReturn(run func(string, string, string, HandlerFunc) VersionedRoute) *MockVersionedRoute_Handle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Patch provides a mock function with given fields: version, relativePath, handler
+func (_m *MockVersionedRoute) Patch(version string, relativePath string, handler HandlerFunc) VersionedRoute {
+	ret := _m.Called(version, relativePath, handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 VersionedRoute
+	if rf, ok := ret.Get(0).(func(string, string, HandlerFunc) VersionedRoute); ok {
+		r0 = rf(version, relativePath, handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(VersionedRoute)
+		}
+	}
+
+	return r0
+}
+
+// MockVersionedRoute_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type MockVersionedRoute_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - version string
+//   - relativePath string
+//   - handler HandlerFunc
+func (_e *MockVersionedRoute_Expecter) Patch(version interface{}, relativePath interface{}, handler interface{}) *MockVersionedRoute_Patch_Call {
+	return &MockVersionedRoute_Patch_Call{Call: _e.mock.On("Patch", version, relativePath, handler)}
+}
+
+func (_c *MockVersionedRoute_Patch_Call) Run(run func(version string, relativePath string, handler HandlerFunc)) *MockVersionedRoute_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(HandlerFunc))
+	})
+	return _c
+}
+
+func (_c *MockVersionedRoute_Patch_Call) Return(_a0 VersionedRoute) *MockVersionedRoute_Patch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockVersionedRoute_Patch_Call) RunAndReturn(run func(string, string, HandlerFunc) VersionedRoute) *MockVersionedRoute_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Post provides a mock function with given fields: version, relativePath, handler
+func (_m *MockVersionedRoute) Post(version string, relativePath string, handler HandlerFunc) VersionedRoute {
+	ret := _m.Called(version, relativePath, handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Post")
+	}
+
+	var r0 VersionedRoute
+	if rf, ok := ret.Get(0).(func(string, string, HandlerFunc) VersionedRoute); ok {
+		r0 = rf(version, relativePath, handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(VersionedRoute)
+		}
+	}
+
+	return r0
+}
+
+// MockVersionedRoute_Post_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Post'
+type MockVersionedRoute_Post_Call struct {
+	*mock.Call
+}
+
+// Post is a helper method to define mock.On call
+//   - version string
+//   - relativePath string
+//   - handler HandlerFunc
+func (_e *MockVersionedRoute_Expecter) Post(version interface{}, relativePath interface{}, handler interface{}) *MockVersionedRoute_Post_Call {
+	return &MockVersionedRoute_Post_Call{Call: _e.mock.On("Post", version, relativePath, handler)}
+}
+
+func (_c *MockVersionedRoute_Post_Call) Run(run func(version string, relativePath string, handler HandlerFunc)) *MockVersionedRoute_Post_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(HandlerFunc))
+	})
+	return _c
+}
+
+func (_c *MockVersionedRoute_Post_Call) Return(_a0 VersionedRoute) *MockVersionedRoute_Post_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockVersionedRoute_Post_Call) RunAndReturn(run func(string, string, HandlerFunc) VersionedRoute) *MockVersionedRoute_Post_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: version, relativePath, handler
+func (_m *MockVersionedRoute) Put(version string, relativePath string, handler HandlerFunc) VersionedRoute {
+	ret := _m.Called(version, relativePath, handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 VersionedRoute
+	if rf, ok := ret.Get(0).(func(string, string, HandlerFunc) VersionedRoute); ok {
+		r0 = rf(version, relativePath, handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(VersionedRoute)
+		}
+	}
+
+	return r0
+}
+
+// MockVersionedRoute_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type MockVersionedRoute_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - version string
+//   - relativePath string
+//   - handler HandlerFunc
+func (_e *MockVersionedRoute_Expecter) Put(version interface{}, relativePath interface{}, handler interface{}) *MockVersionedRoute_Put_Call {
+	return &MockVersionedRoute_Put_Call{Call: _e.mock.On("Put", version, relativePath, handler)}
+}
+
+func (_c *MockVersionedRoute_Put_Call) Run(run func(version string, relativePath string, handler HandlerFunc)) *MockVersionedRoute_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(HandlerFunc))
+	})
+	return _c
+}
+
+func (_c *MockVersionedRoute_Put_Call) Return(_a0 VersionedRoute) *MockVersionedRoute_Put_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockVersionedRoute_Put_Call) RunAndReturn(run func(string, string, HandlerFunc) VersionedRoute) *MockVersionedRoute_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockVersionedRoute creates a new instance of MockVersionedRoute. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockVersionedRoute(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockVersionedRoute {
+	mock := &MockVersionedRoute{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,216 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package lit
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockretryableError is an autogenerated mock type for the retryableError type
+type MockretryableError struct {
+	mock.Mock
+}
+
+type MockretryableError_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockretryableError) EXPECT() *MockretryableError_Expecter {
+	return &MockretryableError_Expecter{mock: &_m.Mock}
+}
+
+// Error provides a mock function with no fields
+func (_m *MockretryableError) Error() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Error")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockretryableError_Error_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Error'
+type MockretryableError_Error_Call struct {
+	*mock.Call
+}
+
+// Error is a helper method to define mock.On call
+func (_e *MockretryableError_Expecter) Error() *MockretryableError_Error_Call {
+	return &MockretryableError_Error_Call{Call: _e.mock.On("Error")}
+}
+
+func (_c *MockretryableError_Error_Call) Run(run func()) *MockretryableError_Error_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockretryableError_Error_Call) Return(_a0 string) *MockretryableError_Error_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockretryableError_Error_Call) RunAndReturn(run func() string) *MockretryableError_Error_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ErrorCode provides a mock function with no fields
+func (_m *MockretryableError) ErrorCode() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ErrorCode")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockretryableError_ErrorCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ErrorCode'
+type MockretryableError_ErrorCode_Call struct {
+	*mock.Call
+}
+
+// ErrorCode is a helper method to define mock.On call
+func (_e *MockretryableError_Expecter) ErrorCode() *MockretryableError_ErrorCode_Call {
+	return &MockretryableError_ErrorCode_Call{Call: _e.mock.On("ErrorCode")}
+}
+
+func (_c *MockretryableError_ErrorCode_Call) Run(run func()) *MockretryableError_ErrorCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockretryableError_ErrorCode_Call) Return(_a0 string) *MockretryableError_ErrorCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockretryableError_ErrorCode_Call) RunAndReturn(run func() string) *MockretryableError_ErrorCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryDelay provides a mock function with no fields
+func (_m *MockretryableError) RetryDelay() time.Duration {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryDelay")
+	}
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// MockretryableError_RetryDelay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryDelay'
+type MockretryableError_RetryDelay_Call struct {
+	*mock.Call
+}
+
+// RetryDelay is a helper method to define mock.On call
+func (_e *MockretryableError_Expecter) RetryDelay() *MockretryableError_RetryDelay_Call {
+	return &MockretryableError_RetryDelay_Call{Call: _e.mock.On("RetryDelay")}
+}
+
+func (_c *MockretryableError_RetryDelay_Call) Run(run func()) *MockretryableError_RetryDelay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockretryableError_RetryDelay_Call) Return(_a0 time.Duration) *MockretryableError_RetryDelay_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockretryableError_RetryDelay_Call) RunAndReturn(run func() time.Duration) *MockretryableError_RetryDelay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StatusCode provides a mock function with no fields
+func (_m *MockretryableError) StatusCode() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for StatusCode")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// MockretryableError_StatusCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StatusCode'
+type MockretryableError_StatusCode_Call struct {
+	*mock.Call
+}
+
+// StatusCode is a helper method to define mock.On call
+func (_e *MockretryableError_Expecter) StatusCode() *MockretryableError_StatusCode_Call {
+	return &MockretryableError_StatusCode_Call{Call: _e.mock.On("StatusCode")}
+}
+
+func (_c *MockretryableError_StatusCode_Call) Run(run func()) *MockretryableError_StatusCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockretryableError_StatusCode_Call) Return(_a0 int) *MockretryableError_StatusCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockretryableError_StatusCode_Call) RunAndReturn(run func() int) *MockretryableError_StatusCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockretryableError creates a new instance of MockretryableError. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockretryableError(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockretryableError {
+	mock := &MockretryableError{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
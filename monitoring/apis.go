@@ -36,8 +36,10 @@ func New(cfg Config) (*Monitor, error) {
 	cfg.Tags["server.name"] = cfg.ServerName
 	cfg.Tags["environment"] = cfg.Environment
 	cfg.Tags["version"] = cfg.Version
+	base := zap.New(newZapCore(w))
 	m := &Monitor{
-		logger:  zap.New(newZapCore(w)),
+		base:    base,
+		logger:  base.With(toZapFields(cfg.Tags)...),
 		logTags: cfg.Tags,
 	}
 
@@ -2,6 +2,8 @@ package instrumentgrpc
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -9,11 +11,73 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/viebiz/lit/monitoring"
 )
 
+// StartUnaryCallSegment starts the span of an outgoing unary call and propagates the trace context via metadata
 func StartUnaryCallSegment(ctx context.Context, svcInfo monitoring.ExternalServiceInfo, fullMethod string) (context.Context, func(error)) {
+	ctx, span := startOutgoingCallSpan(ctx, unaryOutgoingCallSpanName, svcInfo, fullMethod)
+
+	return ctx, func(err error) {
+		endSpan(span, err)
+	}
+}
+
+// StreamCallSegment instruments an outgoing stream, each message sent and received is recorded as a span event
+type StreamCallSegment struct {
+	span     trace.Span
+	sent     atomic.Int64
+	received atomic.Int64
+	endOnce  sync.Once
+}
+
+// StartStreamCallSegment starts the span of an outgoing stream and propagates the trace context via metadata
+func StartStreamCallSegment(ctx context.Context, svcInfo monitoring.ExternalServiceInfo, fullMethod string) (context.Context, *StreamCallSegment) {
+	ctx, span := startOutgoingCallSpan(ctx, streamOutgoingCallSpanName, svcInfo, fullMethod)
+
+	return ctx, &StreamCallSegment{span: span}
+}
+
+// MessageSent records a message sent to the server
+func (s *StreamCallSegment) MessageSent() {
+	s.span.AddEvent(messageEventName, trace.WithAttributes(
+		semconv.RPCMessageTypeSent,
+		semconv.RPCMessageIDKey.Int64(s.sent.Add(1)),
+	))
+}
+
+// MessageReceived records a message received from the server
+func (s *StreamCallSegment) MessageReceived() {
+	s.span.AddEvent(messageEventName, trace.WithAttributes(
+		semconv.RPCMessageTypeReceived,
+		semconv.RPCMessageIDKey.Int64(s.received.Add(1)),
+	))
+}
+
+// Sent returns the number of messages sent
+func (s *StreamCallSegment) Sent() int64 {
+	return s.sent.Load()
+}
+
+// Received returns the number of messages received
+func (s *StreamCallSegment) Received() int64 {
+	return s.received.Load()
+}
+
+// End ends the span, it returns false if the span is already ended
+func (s *StreamCallSegment) End(err error) bool {
+	ended := false
+	s.endOnce.Do(func() {
+		endSpan(s.span, err)
+		ended = true
+	})
+
+	return ended
+}
+
+func startOutgoingCallSpan(ctx context.Context, spanName string, svcInfo monitoring.ExternalServiceInfo, fullMethod string) (context.Context, trace.Span) {
 	logTags := map[string]string{
 		rpcSystemKey:     "grpc",
 		serverAddressKey: svcInfo.Hostname + ":" + svcInfo.Port,
@@ -34,7 +98,7 @@ func StartUnaryCallSegment(ctx context.Context, svcInfo monitoring.ExternalServi
 		)
 	}
 
-	ctx, span := tracer.Start(ctx, unaryOutgoingCallSpanName, trace.WithSpanKind(trace.SpanKindClient),
+	ctx, span := tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(attrs...),
 	)
 
@@ -53,12 +117,17 @@ func StartUnaryCallSegment(ctx context.Context, svcInfo monitoring.ExternalServi
 	m = m.With(logTags)
 	ctx = monitoring.SetInContext(ctx, m)
 
-	return ctx, func(err error) {
-		if err != nil {
-			span.SetStatus(codes.Error, err.Error())
-			span.RecordError(err, trace.WithStackTrace(true))
-		}
+	return ctx, span
+}
 
-		span.End()
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err, trace.WithStackTrace(true))
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(status.Code(err))))
+	} else {
+		span.SetAttributes(semconv.RPCGRPCStatusCodeOk)
 	}
+
+	span.End()
 }
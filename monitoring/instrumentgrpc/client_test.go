@@ -3,11 +3,13 @@ package instrumentgrpc
 import (
 	"context"
 	"errors"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/viebiz/lit/monitoring"
 	"github.com/viebiz/lit/monitoring/tracing/mocktracer"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/metadata"
 )
@@ -113,3 +115,52 @@ func TestStartUnaryCallSegment_WithExistingMetadata(t *testing.T) {
 
 	endFunc(nil)
 }
+
+// testTracerProvider receives the spans of the package tracer, the global tracer delegates to the first provider set
+// so the providers started by each test are not used once another one is set
+var testTracerProvider mocktracer.TracerProviderMock
+
+func TestMain(m *testing.M) {
+	testTracerProvider = mocktracer.Start()
+	os.Exit(m.Run())
+}
+
+func TestStartStreamCallSegment(t *testing.T) {
+	tp := testTracerProvider
+	tp.Reset()
+
+	// Given
+	ctx := context.Background()
+	svcInfo := monitoring.ExternalServiceInfo{
+		Hostname: "example.com",
+		Port:     "50051",
+	}
+
+	// When
+	newCtx, segment := StartStreamCallSegment(ctx, svcInfo, "/weather.WeatherService/StreamWeather")
+	segment.MessageSent()
+	segment.MessageReceived()
+	segment.MessageReceived()
+	ended := segment.End(nil)
+	endedTwice := segment.End(errors.New("simulated error"))
+
+	// Then
+	requireTraceContextPresent(t, newCtx)
+	md, ok := metadata.FromOutgoingContext(newCtx)
+	require.True(t, ok)
+	require.NotEmpty(t, md.Get("traceparent"))
+
+	require.True(t, ended)
+	require.False(t, endedTwice)
+	require.Equal(t, int64(1), segment.Sent())
+	require.Equal(t, int64(2), segment.Received())
+
+	span := tp.GetLatestSpan()
+	require.Equal(t, "grpc.stream_outgoing_call", span.Name)
+	require.Len(t, span.Events, 3)
+	require.Equal(t, "message", span.Events[0].Name)
+	require.Contains(t, span.Events[0].Attributes, semconv.RPCMessageTypeSent)
+	require.Contains(t, span.Events[2].Attributes, semconv.RPCMessageTypeReceived)
+	require.Contains(t, span.Events[2].Attributes, semconv.RPCMessageIDKey.Int64(2))
+	require.Contains(t, span.Attributes, semconv.RPCGRPCStatusCodeOk)
+}
@@ -7,9 +7,11 @@ import (
 )
 
 const (
-	tracerName                = "github.com/viebiz/lit/monitoring/instrumentgrpc"
-	unaryOutgoingCallSpanName = "grpc.unary_outgoing_call"
-	unaryIncomingSpanName     = "grpc.unary_incoming_call"
+	tracerName                 = "github.com/viebiz/lit/monitoring/instrumentgrpc"
+	unaryOutgoingCallSpanName  = "grpc.unary_outgoing_call"
+	streamOutgoingCallSpanName = "grpc.stream_outgoing_call"
+	messageEventName           = "message"
+	unaryIncomingSpanName      = "grpc.unary_incoming_call"
 
 	// Settings
 	shouldLogUnaryRequestBody = true
@@ -0,0 +1,15 @@
+package monitoring
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// Level is the logging priority
+type Level = zapcore.Level
+
+const (
+	DebugLevel = zapcore.DebugLevel
+	InfoLevel  = zapcore.InfoLevel
+	WarnLevel  = zapcore.WarnLevel
+	ErrorLevel = zapcore.ErrorLevel
+)
@@ -23,3 +23,11 @@ func JSONField(key string, value json.RawMessage) Field {
 
 	return zap.Reflect(key, value)
 }
+
+func BoolField(key string, value bool) Field {
+	return zap.Bool(key, value)
+}
+
+func Float64Field(key string, value float64) Field {
+	return zap.Float64(key, value)
+}
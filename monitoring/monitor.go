@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"maps"
 	"reflect"
+	"slices"
 	"sync"
 	"time"
 
@@ -18,24 +19,29 @@ const DefaultFlushWait = 10 * time.Second // 10 sec (this flush) + 10 sec for se
 // Monitor represents instance for logging and capture error
 type Monitor struct {
 	sentryClient *sentry.Client
-	logger       *zap.Logger
+	// root is the Monitor created by New, nil if this is the root
+	root *Monitor
+	// base is the logger of the root Monitor without tags, used to build a child logger which overrides a root tag
+	base *zap.Logger
 	// Currently unable to retrieve logTags saved in uber zap logger due to its design to be quick.
-	// Hence, keeping a local copy of logTags for other purpose such as sentry error reporting
+	// Hence, keeping a local copy of logTags for other purpose such as sentry error reporting.
+	// A child Monitor only keeps the tags added at its level, the full set is resolved by walking the parents
+	parent  *Monitor
 	logTags map[string]string
+
+	// logger is a zap child logger which already carries all the tags, so the tags are encoded once instead of on every log call.
+	// It's built on the first log call, so the intermediate Monitors which never log do not pay for it
+	loggerOnce sync.Once
+	logger     *zap.Logger
 }
 
+// WithTag creates a new child Monitor and adds the tag to it. Parent Monitor remains unchanged.
 func (m *Monitor) WithTag(key string, value string) *Monitor {
 	if m == nil {
 		return nil
 	}
 
-	clonedTags := maps.Clone(m.logTags)
-	clonedTags[key] = value
-	return &Monitor{
-		sentryClient: m.sentryClient,
-		logger:       m.logger,
-		logTags:      clonedTags,
-	}
+	return m.newChild(map[string]string{key: value})
 }
 
 // With creates a new child Monitor and adds new logTags to it. Parent Monitor remains unchanged.
@@ -44,29 +50,114 @@ func (m *Monitor) With(tags map[string]string) *Monitor {
 		return nil
 	}
 
-	// Add new log field
-	clonedTags := maps.Clone(m.logTags)
-	for k, v := range tags {
-		clonedTags[k] = v
+	return m.newChild(maps.Clone(tags))
+}
+
+func (m *Monitor) newChild(tags map[string]string) *Monitor {
+	root := m.root
+	if root == nil {
+		root = m
 	}
 
 	return &Monitor{
 		sentryClient: m.sentryClient,
-		logger:       m.logger,
-		logTags:      clonedTags,
+		root:         root,
+		base:         m.base,
+		parent:       m,
+		logTags:      tags,
 	}
 }
 
-func (m *Monitor) getLogFields() []zap.Field {
+// zapLogger returns the logger carrying all the tags of this Monitor
+func (m *Monitor) zapLogger() *zap.Logger {
+	m.loggerOnce.Do(func() {
+		if m.root == nil {
+			return // The root logger is set by New
+		}
+
+		// Collect the tags added after the root, the closer tag overrides the farther one
+		tags := make(map[string]string)
+		overrideRootTag := false
+		for _, cur := range m.lineage() {
+			for k, v := range cur.logTags {
+				tags[k] = v
+				if _, ok := m.root.logTags[k]; ok {
+					overrideRootTag = true
+				}
+			}
+		}
+
+		// zap cannot replace a field of the child logger, build from the base logger to avoid duplicated keys
+		if overrideRootTag && m.base != nil {
+			m.logger = m.base.With(toZapFields(m.tags())...)
+			return
+		}
+
+		m.logger = m.root.zapLogger().With(toZapFields(tags)...)
+	})
+
+	return m.logger
+}
+
+// lineage returns the Monitors from the first child of the root to this Monitor
+func (m *Monitor) lineage() []*Monitor {
+	var chain []*Monitor
+	for cur := m; cur != nil && cur != m.root; cur = cur.parent {
+		chain = append(chain, cur)
+	}
+	slices.Reverse(chain)
+
+	return chain
+}
+
+// tags returns all the tags of this Monitor and its ancestors, the closer tag overrides the farther one
+func (m *Monitor) tags() map[string]string {
 	if m == nil {
 		return nil
 	}
 
-	logFields := make([]zap.Field, 0, len(m.logTags))
-	for k, v := range m.logTags {
-		logFields = append(logFields, zap.String(k, v))
+	if m.root == nil {
+		return m.logTags
+	}
+
+	tags := maps.Clone(m.root.logTags)
+	if tags == nil {
+		tags = make(map[string]string)
+	}
+	for _, cur := range m.lineage() {
+		maps.Copy(tags, cur.logTags)
 	}
-	return logFields
+
+	return tags
+}
+
+// Tag returns the value of the tag set on this Monitor or its ancestors
+func (m *Monitor) Tag(key string) (string, bool) {
+	for cur := m; cur != nil; cur = cur.parent {
+		if v, ok := cur.logTags[key]; ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+func toZapFields(tags map[string]string) []zap.Field {
+	fields := make([]zap.Field, 0, len(tags))
+	for k, v := range tags {
+		fields = append(fields, zap.String(k, v))
+	}
+
+	return fields
+}
+
+// Log logs the message at the given level without reporting to sentry
+func (m *Monitor) Log(level Level, msg string, fields ...Field) {
+	if m == nil {
+		return
+	}
+
+	m.zapLogger().Log(level, msg, fields...)
 }
 
 func (m *Monitor) Info(msg string, fields ...Field) {
@@ -74,7 +165,7 @@ func (m *Monitor) Info(msg string, fields ...Field) {
 		return
 	}
 
-	m.logger.Info(msg, append(fields, m.getLogFields()...)...)
+	m.zapLogger().Info(msg, fields...)
 }
 
 // Infof logs the message using info level
@@ -83,7 +174,7 @@ func (m *Monitor) Infof(format string, args ...interface{}) {
 		return
 	}
 
-	m.logger.Info(fmt.Sprintf(format, args...), m.getLogFields()...)
+	m.zapLogger().Info(fmt.Sprintf(format, args...))
 }
 
 func (m *Monitor) Error(err error, msg string, fields ...Field) {
@@ -96,7 +187,6 @@ func (m *Monitor) Error(err error, msg string, fields ...Field) {
 		zap.String("error.kind", reflect.TypeOf(err).String()),
 		zap.String("error.message", err.Error()),
 	)
-	fields = append(fields, m.getLogFields()...)
 
 	if v, ok := err.(stackTracer); ok {
 		stack := fmt.Sprintf("%+v", v.StackTrace())
@@ -107,12 +197,12 @@ func (m *Monitor) Error(err error, msg string, fields ...Field) {
 	}
 
 	if msg != "" {
-		m.logger.Error(fmt.Sprintf(msg+". Err: %v", err), fields...)
+		m.zapLogger().Error(fmt.Sprintf(msg+". Err: %v", err), fields...)
 	} else {
-		m.logger.Error(fmt.Sprintf("Err: %v", err), fields...)
+		m.zapLogger().Error(fmt.Sprintf("Err: %v", err), fields...)
 	}
 
-	m.ReportError(err, m.logTags)
+	m.ReportError(err, m.tags())
 }
 
 // Errorf logs the message using error level and reports the error to sentry
@@ -121,12 +211,11 @@ func (m *Monitor) Errorf(err error, msg string, args ...interface{}) {
 		return
 	}
 
-	logFields := m.getLogFields()
 	// Capture error.key, error.message and error.stack to log
-	logFields = append(logFields,
+	logFields := []zap.Field{
 		zap.String("error.kind", reflect.TypeOf(err).String()),
 		zap.String("error.message", err.Error()),
-	)
+	}
 
 	if v, ok := err.(stackTracer); ok {
 		stack := fmt.Sprintf("%+v", v.StackTrace())
@@ -137,12 +226,12 @@ func (m *Monitor) Errorf(err error, msg string, args ...interface{}) {
 	}
 
 	if msg != "" {
-		m.logger.Error(fmt.Sprintf(msg+". Err: %v", append(args, err)...), logFields...)
+		m.zapLogger().Error(fmt.Sprintf(msg+". Err: %v", append(args, err)...), logFields...)
 	} else {
-		m.logger.Error(fmt.Sprintf("Err: %v", err), logFields...)
+		m.zapLogger().Error(fmt.Sprintf("Err: %v", err), logFields...)
 	}
 
-	m.ReportError(err, m.logTags)
+	m.ReportError(err, m.tags())
 }
 
 func (m *Monitor) ReportError(err error, tags map[string]string) {
@@ -165,7 +254,7 @@ func (m *Monitor) Flush(maxWait time.Duration) {
 	// Zap
 	go func() {
 		defer wg.Done()
-		_ = flushZap(m.logger, DefaultFlushWait)
+		_ = flushZap(m.zapLogger(), DefaultFlushWait)
 	}()
 
 	// Sentry
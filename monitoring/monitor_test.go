@@ -215,11 +215,11 @@ func TestMonitorLogger(t *testing.T) {
 				{"level": "INFO", "ts": "2025-02-23T13:34:56.185+0700", "msg": "Hello lightning project", "request_id": "123", "user_id": "ID-00001", "server.name": "lightning", "environment": "dev", "version": "1.0.0"},
 			},
 		},
-		"getLogField - not in ctx": {
+		"tags - not in ctx": {
 			doLogging: func(w io.Writer) {
 				m := FromContext(context.Background())
 
-				m.getLogFields()
+				m.tags()
 			},
 		},
 	}
@@ -350,3 +350,55 @@ func parseLog(b []byte, skip int) ([]map[string]interface{}, error) {
 	}
 	return result, nil
 }
+
+func TestMonitor_WithOverrideTag(t *testing.T) {
+	// Given
+	logBuffer := bytes.NewBuffer(nil)
+	m, err := New(Config{ServerName: "lightning", Environment: "dev", Version: "1.0.0", Writer: logBuffer})
+	require.NoError(t, err)
+
+	// When
+	child := m.With(map[string]string{"request_id": "123", "span_id": "1"}).
+		WithTag("span_id", "2").
+		WithTag("environment", "test")
+	child.Info("Hello lightning project")
+	m.Info("Parent remains unchanged")
+	m.Flush(DefaultFlushWait)
+
+	// Then
+	logs := strings.Split(strings.TrimSpace(logBuffer.String()), "\n")[2:] // Skip the initializing logs
+	require.Len(t, logs, 2)
+	require.Equal(t, 1, strings.Count(logs[0], `"span_id"`))
+	require.Equal(t, 1, strings.Count(logs[0], `"environment"`))
+
+	parsedLog, err := parseLog(logBuffer.Bytes(), 2)
+	require.NoError(t, err)
+	delete(parsedLog[0], "ts")
+	delete(parsedLog[1], "ts")
+	require.Equal(t, []map[string]interface{}{
+		{"level": "INFO", "msg": "Hello lightning project", "request_id": "123", "span_id": "2", "server.name": "lightning", "environment": "test", "version": "1.0.0"},
+		{"level": "INFO", "msg": "Parent remains unchanged", "server.name": "lightning", "environment": "dev", "version": "1.0.0"},
+	}, parsedLog)
+	require.Equal(t, map[string]string{"request_id": "123", "span_id": "2", "server.name": "lightning", "environment": "test", "version": "1.0.0"}, child.tags())
+}
+
+func TestMonitor_Tag(t *testing.T) {
+	// Given
+	m, err := New(Config{ServerName: "lightning", Writer: io.Discard})
+	require.NoError(t, err)
+	child := m.WithTag("user_id", "guilliman").WithTag("request_id", "123")
+
+	// When
+	userID, userIDOK := child.Tag("user_id")
+	serverName, serverNameOK := child.Tag("server.name")
+	_, missingOK := child.Tag("tenant_id")
+	_, parentOK := m.Tag("user_id")
+
+	// Then
+	require.True(t, userIDOK)
+	require.Equal(t, "guilliman", userID)
+	require.True(t, serverNameOK)
+	require.Equal(t, "lightning", serverName)
+	require.False(t, missingOK)
+	require.False(t, parentOK)
+}
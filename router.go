@@ -24,7 +24,7 @@ func NewRouter(appCtx context.Context, opts ...RouterOption) Router {
 
 	// Setup root middleware
 	// Includes logging, tracing, panic recovery
-	r.Use(rootMiddleware(appCtx))
+	r.Use(rootMiddleware(appCtx, r.accessLog))
 
 	return r
 }
@@ -34,6 +34,7 @@ type router struct {
 	route
 	ginRouter gin.IRouter
 	engine    *gin.Engine
+	accessLog accessLogConfig
 }
 
 func newRouter(engine *gin.Engine) *router {
@@ -43,6 +44,7 @@ func newRouter(engine *gin.Engine) *router {
 		},
 		ginRouter: engine,
 		engine:    engine,
+		accessLog: defaultAccessLogConfig(),
 	}
 }
 
@@ -55,6 +57,7 @@ func (r *router) routing(prefix string, middlewares ...gin.HandlerFunc) *router
 		},
 		ginRouter: gr,
 		engine:    r.engine,
+		accessLog: r.accessLog,
 	}
 }
 
@@ -171,24 +174,21 @@ func buildGinHandlers(
 	handler HandlerFunc,
 	middlewares []HandlerFunc,
 ) []gin.HandlerFunc {
-	if len(middlewares) == 0 {
-		return []gin.HandlerFunc{toGinHandler(handler)}
-	}
-
-	// Append handler to the last of middlewares, base on gin.Context.Handle docs
-	middlewares = append(middlewares, handler)
-
-	out := make([]gin.HandlerFunc, len(middlewares))
-	for idx := 0; idx < len(middlewares); idx++ {
-		m := middlewares[idx]
+	// Append handler to the last of middlewares, base on gin.Context.Handle docs.
+	// Build a new slice instead of appending to middlewares, which may share the backing array of the caller
+	out := make([]gin.HandlerFunc, len(middlewares)+1)
+	for idx, m := range middlewares {
 		out[idx] = toGinHandler(m)
 	}
+	out[len(middlewares)] = toGinHandler(handler)
+
 	return out
 }
 
 func toGinHandler(h HandlerFunc) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		litCtx := newContext(ctx)
+		// litContext is pointer-shaped, passing it as Context does not allocate
+		litCtx := litContext{Context: ctx}
 		if err := h(litCtx); err != nil {
 			litCtx.Abort() // To skip all middleware after
 			litCtx.Error(err)
@@ -7,6 +7,10 @@ import (
 // WithLivenessEndpoint setup liveness endpoint, that not captured by monitoring
 func WithLivenessEndpoint(endpoint string) RouterOption {
 	return func(r Router) {
+		if rt, ok := r.(*router); ok {
+			rt.accessLog.skipPath(endpoint)
+		}
+
 		r.Get(endpoint, func(c Context) error {
 			c.Header("Content-Type", "text/plain; charset=utf-8")
 			c.Header("X-Content-Type-Options", "nosniff")
@@ -0,0 +1,127 @@
+package session
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/viebiz/lit/caching/redis"
+)
+
+const (
+	defaultCookieName = "lit_session"
+	defaultCookiePath = "/"
+	defaultMaxAge     = 24 * time.Hour
+	defaultKeyPrefix  = "session:"
+)
+
+// Config holds the session configuration
+type Config struct {
+	// CookieName is the name of session cookie
+	// Default: lit_session
+	CookieName string
+
+	// HashKey is used to sign the session cookie with HMAC-SHA256, it must be at least 32 bytes
+	HashKey []byte
+
+	// EncryptionKey is used to encrypt the session cookie with AES-GCM, it must be 16, 24 or 32 bytes.
+	// Skip encryption if it's not provided
+	EncryptionKey []byte
+
+	// MaxAge is the idle timeout of a session
+	// Default: 24h
+	MaxAge time.Duration
+
+	// Rolling resets the session expiry on every request
+	// Default: false
+	Rolling bool
+
+	// Path is the cookie path
+	// Default: /
+	Path string
+
+	// Domain is the cookie domain
+	Domain string
+
+	// Secure only sends the cookie over HTTPS
+	Secure bool
+
+	// SameSite is the cookie SameSite attribute
+	// Default: http.SameSiteLaxMode
+	SameSite http.SameSite
+
+	// PopulateUserProfile puts the session user to the context as iam.UserProfile,
+	// so the guard handlers can authorize the request
+	PopulateUserProfile bool
+}
+
+// New creates a new session Manager with the given store
+//
+// Example:
+//
+//	func main() {
+//		mgr, err := session.New(session.Config{
+//			HashKey: []byte(os.Getenv("SESSION_HASH_KEY")),
+//			Rolling: true,
+//			Secure:  true,
+//			PopulateUserProfile: true,
+//		}, session.NewRedisStore(redisClient, ""))
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		r.Use(mgr.Middleware())
+//	}
+func New(cfg Config, store Store) (Manager, error) {
+	cfg = prepareConfig(cfg)
+
+	codec, err := newCookieCodec(cfg.CookieName, cfg.HashKey, cfg.EncryptionKey, cfg.MaxAge)
+	if err != nil {
+		return Manager{}, err
+	}
+
+	return Manager{
+		cfg:   cfg,
+		store: store,
+		codec: codec,
+	}, nil
+}
+
+// NewMemoryStore creates an in-memory Store, it's intended for testing and local development
+func NewMemoryStore() Store {
+	return &memoryStore{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+// NewRedisStore creates a Store backed by Redis, keys are prefixed by keyPrefix
+// Default key prefix: session:
+func NewRedisStore(client redis.Client, keyPrefix string) Store {
+	if keyPrefix == "" {
+		keyPrefix = defaultKeyPrefix
+	}
+
+	return redisStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+	}
+}
+
+func prepareConfig(cfg Config) Config {
+	if cfg.CookieName == "" {
+		cfg.CookieName = defaultCookieName
+	}
+
+	if cfg.Path == "" {
+		cfg.Path = defaultCookiePath
+	}
+
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = defaultMaxAge
+	}
+
+	if cfg.SameSite == 0 {
+		cfg.SameSite = http.SameSiteLaxMode
+	}
+
+	return cfg
+}
@@ -0,0 +1,135 @@
+package session
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+const (
+	minHashKeyLength = 32
+	cookieSeparator  = "."
+	payloadSeparator = "|"
+)
+
+// cookieCodec signs and optionally encrypts the session ID stored in the cookie
+type cookieCodec struct {
+	name    string
+	hashKey []byte
+	aead    cipher.AEAD // nil if encryption is disabled
+	maxAge  time.Duration
+}
+
+func newCookieCodec(name string, hashKey, encryptionKey []byte, maxAge time.Duration) (cookieCodec, error) {
+	if len(hashKey) < minHashKeyLength {
+		return cookieCodec{}, ErrMissingHashKey
+	}
+
+	c := cookieCodec{
+		name:    name,
+		hashKey: hashKey,
+		maxAge:  maxAge,
+	}
+
+	if len(encryptionKey) > 0 {
+		block, err := aes.NewCipher(encryptionKey)
+		if err != nil {
+			return cookieCodec{}, ErrInvalidEncryptionKey
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return cookieCodec{}, pkgerrors.WithStack(err)
+		}
+
+		c.aead = aead
+	}
+
+	return c, nil
+}
+
+// encode returns the cookie value for the given session ID in form of `base64(payload).base64(mac)`
+func (c cookieCodec) encode(id string) (string, error) {
+	payload := []byte(strconv.FormatInt(timeNowFunc().Unix(), 10) + payloadSeparator + id)
+
+	if c.aead != nil {
+		nonce := make([]byte, c.aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return "", pkgerrors.WithStack(err)
+		}
+
+		payload = c.aead.Seal(nonce, nonce, payload, []byte(c.name))
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encoded + cookieSeparator + base64.RawURLEncoding.EncodeToString(c.sign(encoded)), nil
+}
+
+// decode verifies the cookie value and returns the session ID
+func (c cookieCodec) decode(value string) (string, error) {
+	encoded, sig, found := strings.Cut(value, cookieSeparator)
+	if !found {
+		return "", ErrInvalidCookie
+	}
+
+	// 1. Verify signature
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	if !hmac.Equal(mac, c.sign(encoded)) {
+		return "", ErrInvalidCookie
+	}
+
+	// 2. Decrypt payload
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	if c.aead != nil {
+		nonceSize := c.aead.NonceSize()
+		if len(payload) < nonceSize {
+			return "", ErrInvalidCookie
+		}
+
+		payload, err = c.aead.Open(nil, payload[:nonceSize], payload[nonceSize:], []byte(c.name))
+		if err != nil {
+			return "", ErrInvalidCookie
+		}
+	}
+
+	// 3. Verify cookie age
+	tsStr, id, found := strings.Cut(string(payload), payloadSeparator)
+	if !found || id == "" {
+		return "", ErrInvalidCookie
+	}
+
+	ts, err := strconv.ParseInt(tsStr, 10, 64)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	if c.maxAge > 0 && timeNowFunc().After(time.Unix(ts, 0).Add(c.maxAge)) {
+		return "", ErrInvalidCookie
+	}
+
+	return id, nil
+}
+
+func (c cookieCodec) sign(value string) []byte {
+	h := hmac.New(sha256.New, c.hashKey)
+	h.Write([]byte(c.name + payloadSeparator + value))
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,125 @@
+package session
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCookieCodec(t *testing.T) {
+	hashKey := []byte(strings.Repeat("h", 32))
+	encryptionKey := []byte(strings.Repeat("e", 32))
+
+	tcs := map[string]struct {
+		givenEncryptionKey []byte
+		givenMaxAge        time.Duration
+		givenTamperFn      func(string) string
+		givenDecodeAfter   time.Duration
+		expID              string
+		expErr             error
+	}{
+		"success - signed only": {
+			expID: "session-id",
+		},
+		"success - signed and encrypted": {
+			givenEncryptionKey: encryptionKey,
+			expID:              "session-id",
+		},
+		"error - tampered payload": {
+			givenTamperFn: func(v string) string {
+				return "x" + v
+			},
+			expErr: ErrInvalidCookie,
+		},
+		"error - missing signature": {
+			givenTamperFn: func(v string) string {
+				return strings.Split(v, ".")[0]
+			},
+			expErr: ErrInvalidCookie,
+		},
+		"error - tampered encrypted payload": {
+			givenEncryptionKey: encryptionKey,
+			givenTamperFn: func(v string) string {
+				return strings.Replace(v, ".", "A.", 1)
+			},
+			expErr: ErrInvalidCookie,
+		},
+		"error - cookie expired": {
+			givenMaxAge:      time.Hour,
+			givenDecodeAfter: 2 * time.Hour,
+			expErr:           ErrInvalidCookie,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		t.Run(scenario, func(t *testing.T) {
+			// Given
+			now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+			timeNowFunc = func() time.Time { return now }
+			defer func() { timeNowFunc = time.Now }()
+
+			codec, err := newCookieCodec("lit_session", hashKey, tc.givenEncryptionKey, tc.givenMaxAge)
+			require.NoError(t, err)
+
+			value, err := codec.encode("session-id")
+			require.NoError(t, err)
+			if tc.givenEncryptionKey != nil {
+				require.NotContains(t, value, "session-id")
+			}
+
+			if tc.givenTamperFn != nil {
+				value = tc.givenTamperFn(value)
+			}
+			now = now.Add(tc.givenDecodeAfter)
+
+			// When
+			id, err := codec.decode(value)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expID, id)
+		})
+	}
+}
+
+func TestNewCookieCodec(t *testing.T) {
+	tcs := map[string]struct {
+		givenHashKey       []byte
+		givenEncryptionKey []byte
+		expErr             error
+	}{
+		"success": {
+			givenHashKey:       []byte(strings.Repeat("h", 32)),
+			givenEncryptionKey: []byte(strings.Repeat("e", 16)),
+		},
+		"error - hash key too short": {
+			givenHashKey: []byte("short"),
+			expErr:       ErrMissingHashKey,
+		},
+		"error - invalid encryption key": {
+			givenHashKey:       []byte(strings.Repeat("h", 32)),
+			givenEncryptionKey: []byte("invalid"),
+			expErr:             ErrInvalidEncryptionKey,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// When
+			_, err := newCookieCodec("lit_session", tc.givenHashKey, tc.givenEncryptionKey, 0)
+
+			// Then
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package session
+
+import (
+	"context"
+)
+
+type contextKey struct{}
+
+// SetInContext sets the session in context
+func SetInContext(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, contextKey{}, s)
+}
+
+// FromContext gets the session from context, returns nil if the session middleware is not mounted.
+// Because lit.Context implements context.Context, it can be passed directly from a handler
+func FromContext(ctx context.Context) *Session {
+	if s, ok := ctx.Value(contextKey{}).(*Session); ok && s != nil {
+		return s
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package session
+
+import (
+	"errors"
+)
+
+var (
+	// ErrSessionNotFound means the session does not exist in the store or has expired
+	ErrSessionNotFound = errors.New("session not found")
+
+	// ErrInvalidCookie means the session cookie is malformed, has been tampered with or has expired
+	ErrInvalidCookie = errors.New("invalid session cookie")
+
+	// ErrMissingHashKey means the Config.HashKey is not provided or too short
+	ErrMissingHashKey = errors.New("session hash key must be at least 32 bytes")
+
+	// ErrInvalidEncryptionKey means the Config.EncryptionKey is not a valid AES key
+	ErrInvalidEncryptionKey = errors.New("session encryption key must be 16, 24 or 32 bytes")
+
+	// ErrNoSession means the session middleware is not mounted for the current request
+	ErrNoSession = errors.New("session not in context")
+)
@@ -0,0 +1,185 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/viebiz/lit"
+	"github.com/viebiz/lit/iam"
+	"github.com/viebiz/lit/monitoring"
+)
+
+const (
+	userIDKey = "user_id"
+)
+
+// Manager loads and persists sessions for incoming requests
+type Manager struct {
+	cfg   Config
+	store Store
+	codec cookieCodec
+}
+
+// Middleware loads the session from the request cookie and puts it to the request context.
+// The session is saved and the cookie is written right before the response header is sent
+func (m Manager) Middleware() lit.HandlerFunc {
+	return func(c lit.Context) error {
+		ctx := c.Request().Context()
+
+		// 1. Load session from cookie, start a new one if not found
+		sess, err := m.load(ctx, c.Request())
+		if err != nil {
+			return err
+		}
+
+		// 2. Inject session, and optionally the user profile, to request context
+		ctx = SetInContext(ctx, sess)
+		if user, ok := sess.User(); ok && m.cfg.PopulateUserProfile {
+			ctx = iam.SetUserProfileInContext(ctx, user.UserProfile())
+			ctx = monitoring.InjectField(ctx, userIDKey, user.ID)
+		}
+		c.SetRequestContext(ctx)
+
+		// 3. Commit session before the response header is written
+		var once sync.Once
+		w := c.Writer()
+		commit := func() {
+			once.Do(func() {
+				if err := m.commit(ctx, w, sess); err != nil {
+					monitoring.FromContext(ctx).Errorf(err, "Failed to save session")
+				}
+			})
+		}
+		c.SetWriter(&writer{ResponseWriter: w, beforeWrite: commit})
+
+		// 4. Continue handle request
+		c.Next()
+
+		// Commit if the handler did not write anything
+		commit()
+
+		return nil
+	}
+}
+
+func (m Manager) load(ctx context.Context, r *http.Request) (*Session, error) {
+	cookie, err := r.Cookie(m.cfg.CookieName)
+	if err != nil {
+		return newSession()
+	}
+
+	id, err := m.codec.decode(cookie.Value)
+	if err != nil {
+		return newSession()
+	}
+
+	data, err := m.store.Load(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return newSession()
+		}
+
+		return nil, err
+	}
+
+	return loadSession(id, data)
+}
+
+func (m Manager) commit(ctx context.Context, w http.ResponseWriter, sess *Session) error {
+	sess.mu.RLock()
+	defer sess.mu.RUnlock()
+
+	// 1. Remove the rotated session
+	if sess.oldID != "" {
+		if err := m.store.Delete(ctx, sess.oldID); err != nil {
+			return err
+		}
+	}
+
+	// 2. Remove destroyed session and expire the cookie
+	if sess.destroyed {
+		if !sess.isNew {
+			if err := m.store.Delete(ctx, sess.id); err != nil {
+				return err
+			}
+		}
+
+		http.SetCookie(w, m.cookie("", -1))
+		return nil
+	}
+
+	switch {
+	case sess.modified:
+		// 3. Persist changed session
+		data, err := json.Marshal(sess.rec)
+		if err != nil {
+			return pkgerrors.WithStack(err)
+		}
+
+		if err := m.store.Save(ctx, sess.id, data, m.cfg.MaxAge); err != nil {
+			return err
+		}
+	case m.cfg.Rolling && !sess.isNew:
+		// 4. Extend expiry of unchanged session
+		if err := m.store.Touch(ctx, sess.id, m.cfg.MaxAge); err != nil {
+			return err
+		}
+	default:
+		// Nothing changed, keep the current cookie
+		return nil
+	}
+
+	value, err := m.codec.encode(sess.id)
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, m.cookie(value, int(m.cfg.MaxAge.Seconds())))
+
+	return nil
+}
+
+func (m Manager) cookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     m.cfg.CookieName,
+		Value:    value,
+		Path:     m.cfg.Path,
+		Domain:   m.cfg.Domain,
+		MaxAge:   maxAge,
+		Secure:   m.cfg.Secure,
+		HttpOnly: true,
+		SameSite: m.cfg.SameSite,
+	}
+}
+
+// writer calls beforeWrite right before the response header is sent
+type writer struct {
+	lit.ResponseWriter
+
+	beforeWrite func()
+}
+
+func (w *writer) WriteHeaderNow() {
+	w.beforeWrite()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *writer) Write(b []byte) (int, error) {
+	w.beforeWrite()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *writer) WriteString(s string) (int, error) {
+	w.beforeWrite()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *writer) Flush() {
+	w.beforeWrite()
+	w.ResponseWriter.Flush()
+}
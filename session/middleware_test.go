@@ -0,0 +1,228 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit"
+	"github.com/viebiz/lit/guard"
+	"github.com/viebiz/lit/iam"
+)
+
+func TestManager_Middleware(t *testing.T) {
+	type step struct {
+		handler      lit.HandlerFunc
+		expStatus    int
+		expBody      string
+		expSetCookie bool
+		expExpired   bool
+	}
+
+	tcs := map[string]struct {
+		givenCfg Config
+		steps    []step
+	}{
+		"set and read value": {
+			steps: []step{
+				{
+					handler: func(c lit.Context) error {
+						if err := FromContext(c).Set("theme", "dark"); err != nil {
+							return err
+						}
+						return c.String(http.StatusOK, "saved")
+					},
+					expStatus:    http.StatusOK,
+					expBody:      "saved",
+					expSetCookie: true,
+				},
+				{
+					handler: func(c lit.Context) error {
+						theme, _ := FromContext(c).GetString("theme")
+						return c.String(http.StatusOK, theme)
+					},
+					expStatus: http.StatusOK,
+					expBody:   "dark",
+				},
+			},
+		},
+		"new session without data is not persisted": {
+			steps: []step{
+				{
+					handler: func(c lit.Context) error {
+						return c.NoContent(http.StatusNoContent)
+					},
+					expStatus: http.StatusNoContent,
+				},
+			},
+		},
+		"rolling expiry refreshes cookie": {
+			givenCfg: Config{Rolling: true},
+			steps: []step{
+				{
+					handler: func(c lit.Context) error {
+						return FromContext(c).Set("theme", "dark")
+					},
+					expStatus:    http.StatusOK,
+					expSetCookie: true,
+				},
+				{
+					handler: func(c lit.Context) error {
+						return c.NoContent(http.StatusNoContent)
+					},
+					expStatus:    http.StatusNoContent,
+					expSetCookie: true,
+				},
+			},
+		},
+		"destroy expires cookie": {
+			steps: []step{
+				{
+					handler: func(c lit.Context) error {
+						return FromContext(c).Set("theme", "dark")
+					},
+					expStatus:    http.StatusOK,
+					expSetCookie: true,
+				},
+				{
+					handler: func(c lit.Context) error {
+						FromContext(c).Destroy()
+						return c.NoContent(http.StatusNoContent)
+					},
+					expStatus:    http.StatusNoContent,
+					expSetCookie: true,
+					expExpired:   true,
+				},
+				{
+					handler: func(c lit.Context) error {
+						_, exists := FromContext(c).GetString("theme")
+						require.False(t, exists)
+						return c.NoContent(http.StatusNoContent)
+					},
+					expStatus: http.StatusNoContent,
+				},
+			},
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			tc.givenCfg.HashKey = []byte(strings.Repeat("k", 32))
+			mgr, err := New(tc.givenCfg, NewMemoryStore())
+			require.NoError(t, err)
+
+			var cookie *http.Cookie
+			for idx, s := range tc.steps {
+				// When
+				w := serveWithSession(mgr, s.handler, cookie)
+
+				// Then
+				require.Equal(t, s.expStatus, w.Code, "step %d", idx)
+				if s.expBody != "" {
+					require.Equal(t, s.expBody, w.Body.String(), "step %d", idx)
+				}
+
+				cookies := w.Result().Cookies()
+				if !s.expSetCookie {
+					require.Empty(t, cookies, "step %d", idx)
+					continue
+				}
+
+				require.Len(t, cookies, 1, "step %d", idx)
+				require.Equal(t, defaultCookieName, cookies[0].Name)
+				require.True(t, cookies[0].HttpOnly)
+				if s.expExpired {
+					require.Less(t, cookies[0].MaxAge, 0)
+					cookie = nil
+					continue
+				}
+
+				require.Equal(t, int(defaultMaxAge.Seconds()), cookies[0].MaxAge)
+				cookie = cookies[0]
+			}
+		})
+	}
+}
+
+func TestManager_Middleware_Login(t *testing.T) {
+	// Given
+	store := NewMemoryStore()
+	mgr, err := New(Config{HashKey: []byte(strings.Repeat("k", 32)), PopulateUserProfile: true}, store)
+	require.NoError(t, err)
+
+	w := serveWithSession(mgr, func(c lit.Context) error {
+		return FromContext(c).Set("cart", "cart-1")
+	}, nil)
+	anonymousCookie := w.Result().Cookies()[0]
+	anonymousID, err := mgr.codec.decode(anonymousCookie.Value)
+	require.NoError(t, err)
+
+	// When
+	w = serveWithSession(mgr, func(c lit.Context) error {
+		return FromContext(c).Login(User{ID: "user-1", Roles: []string{"admin"}})
+	}, anonymousCookie)
+
+	// Then
+	userCookie := w.Result().Cookies()[0]
+	userID, err := mgr.codec.decode(userCookie.Value)
+	require.NoError(t, err)
+	require.NotEqual(t, anonymousID, userID)
+
+	_, err = store.Load(context.Background(), anonymousID)
+	require.ErrorIs(t, err, ErrSessionNotFound) // Old session is removed
+
+	// The session user can be authorized by guard handlers
+	mockEnforcer := new(iam.MockEnforcer)
+	mockEnforcer.On("Enforce", "admin", "orders", guard.ActionRead.String()).Return(nil)
+	authGuard := guard.New(nil, mockEnforcer)
+
+	w = serveWithSession(mgr, authGuard.RolePermissionHandler(func(c lit.Context) error {
+		cart, _ := FromContext(c).GetString("cart")
+		return c.String(http.StatusOK, iam.GetUserProfileFromContext(c).ID()+":"+cart)
+	}, "orders", guard.ActionRead), userCookie)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "user-1:cart-1", w.Body.String())
+	mockEnforcer.AssertExpectations(t)
+}
+
+func TestManager_Middleware_InvalidCookie(t *testing.T) {
+	// Given
+	mgr, err := New(Config{HashKey: []byte(strings.Repeat("k", 32)), MaxAge: time.Minute}, NewMemoryStore())
+	require.NoError(t, err)
+
+	// When
+	w := serveWithSession(mgr, func(c lit.Context) error {
+		require.True(t, FromContext(c).IsNew())
+		return c.NoContent(http.StatusNoContent)
+	}, &http.Cookie{Name: defaultCookieName, Value: "forged.value"})
+
+	// Then
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func serveWithSession(mgr Manager, hdl lit.HandlerFunc, cookie *http.Cookie) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r, c, handleRequest := lit.NewRouterForTest(w)
+	r.Use(mgr.Middleware())
+	r.Get("/", hdl)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	c.SetRequest(req)
+
+	handleRequest()
+
+	return w
+}
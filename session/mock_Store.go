@@ -0,0 +1,240 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package session
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockStore is an autogenerated mock type for the Store type
+type MockStore struct {
+	mock.Mock
+}
+
+type MockStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStore) EXPECT() *MockStore_Expecter {
+	return &MockStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MockStore) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStore_Expecter) Delete(ctx interface{}, id interface{}) *MockStore_Delete_Call {
+	return &MockStore_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockStore_Delete_Call) Run(run func(ctx context.Context, id string)) *MockStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_Delete_Call) Return(_a0 error) *MockStore_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_Delete_Call) RunAndReturn(run func(context.Context, string) error) *MockStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Load provides a mock function with given fields: ctx, id
+func (_m *MockStore) Load(ctx context.Context, id string) ([]byte, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Load")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Load_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Load'
+type MockStore_Load_Call struct {
+	*mock.Call
+}
+
+// Load is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStore_Expecter) Load(ctx interface{}, id interface{}) *MockStore_Load_Call {
+	return &MockStore_Load_Call{Call: _e.mock.On("Load", ctx, id)}
+}
+
+func (_c *MockStore_Load_Call) Run(run func(ctx context.Context, id string)) *MockStore_Load_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_Load_Call) Return(_a0 []byte, _a1 error) *MockStore_Load_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_Load_Call) RunAndReturn(run func(context.Context, string) ([]byte, error)) *MockStore_Load_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, id, data, ttl
+func (_m *MockStore) Save(ctx context.Context, id string, data []byte, ttl time.Duration) error {
+	ret := _m.Called(ctx, id, data, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, time.Duration) error); ok {
+		r0 = rf(ctx, id, data, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockStore_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - data []byte
+//   - ttl time.Duration
+func (_e *MockStore_Expecter) Save(ctx interface{}, id interface{}, data interface{}, ttl interface{}) *MockStore_Save_Call {
+	return &MockStore_Save_Call{Call: _e.mock.On("Save", ctx, id, data, ttl)}
+}
+
+func (_c *MockStore_Save_Call) Run(run func(ctx context.Context, id string, data []byte, ttl time.Duration)) *MockStore_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockStore_Save_Call) Return(_a0 error) *MockStore_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_Save_Call) RunAndReturn(run func(context.Context, string, []byte, time.Duration) error) *MockStore_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Touch provides a mock function with given fields: ctx, id, ttl
+func (_m *MockStore) Touch(ctx context.Context, id string, ttl time.Duration) error {
+	ret := _m.Called(ctx, id, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, id, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type MockStore_Touch_Call struct {
+	*mock.Call
+}
+
+// Touch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ttl time.Duration
+func (_e *MockStore_Expecter) Touch(ctx interface{}, id interface{}, ttl interface{}) *MockStore_Touch_Call {
+	return &MockStore_Touch_Call{Call: _e.mock.On("Touch", ctx, id, ttl)}
+}
+
+func (_c *MockStore_Touch_Call) Run(run func(ctx context.Context, id string, ttl time.Duration)) *MockStore_Touch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockStore_Touch_Call) Return(_a0 error) *MockStore_Touch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_Touch_Call) RunAndReturn(run func(context.Context, string, time.Duration) error) *MockStore_Touch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStore creates a new instance of MockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStore {
+	mock := &MockStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
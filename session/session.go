@@ -0,0 +1,317 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/viebiz/lit/iam"
+)
+
+const (
+	sessionIDLength = 32
+)
+
+// User represents the authenticated user attached to a session
+type User struct {
+	ID          string   `json:"id"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// UserProfile converts the session user to iam.UserProfile
+func (u User) UserProfile() iam.UserProfile {
+	return iam.NewUserProfile(u.ID, u.Roles, u.Permissions)
+}
+
+// record is the serialized form of a session in the Store
+type record struct {
+	Values    map[string]json.RawMessage `json:"values,omitempty"`
+	User      *User                      `json:"user,omitempty"`
+	CreatedAt int64                      `json:"created_at"`
+}
+
+// Session holds the server-side state of a browser session.
+// All methods are safe for concurrent use
+type Session struct {
+	mu sync.RWMutex
+
+	id        string
+	oldID     string // Previous ID to be deleted from store after RenewID
+	rec       record
+	isNew     bool
+	modified  bool
+	destroyed bool
+}
+
+func newSession() (*Session, error) {
+	id, err := generateID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		id:    id,
+		isNew: true,
+		rec: record{
+			Values:    map[string]json.RawMessage{},
+			CreatedAt: timeNowFunc().Unix(),
+		},
+	}, nil
+}
+
+func loadSession(id string, data []byte) (*Session, error) {
+	var rec record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, pkgerrors.WithStack(err)
+	}
+
+	if rec.Values == nil {
+		rec.Values = map[string]json.RawMessage{}
+	}
+
+	return &Session{
+		id:  id,
+		rec: rec,
+	}, nil
+}
+
+// ID returns the current session ID
+func (s *Session) ID() string {
+	if s == nil {
+		return ""
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.id
+}
+
+// IsNew reports whether the session was created during the current request
+func (s *Session) IsNew() bool {
+	if s == nil {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.isNew
+}
+
+// CreatedAt returns the time the session was first created
+func (s *Session) CreatedAt() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return time.Unix(s.rec.CreatedAt, 0)
+}
+
+// Get decodes the value stored under key into out, returns false if the key does not exist
+func (s *Session) Get(key string, out any) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+
+	s.mu.RLock()
+	raw, exists := s.rec.Values[key]
+	s.mu.RUnlock()
+
+	if !exists {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		return true, pkgerrors.WithStack(err)
+	}
+
+	return true, nil
+}
+
+// GetString returns the string value stored under key
+func (s *Session) GetString(key string) (string, bool) {
+	return Value[string](s, key)
+}
+
+// GetInt returns the integer value stored under key
+func (s *Session) GetInt(key string) (int64, bool) {
+	return Value[int64](s, key)
+}
+
+// GetFloat returns the float value stored under key
+func (s *Session) GetFloat(key string) (float64, bool) {
+	return Value[float64](s, key)
+}
+
+// GetBool returns the boolean value stored under key
+func (s *Session) GetBool(key string) (bool, bool) {
+	return Value[bool](s, key)
+}
+
+// GetTime returns the time value stored under key
+func (s *Session) GetTime(key string) (time.Time, bool) {
+	return Value[time.Time](s, key)
+}
+
+// Set stores a JSON serializable value under key
+func (s *Session) Set(key string, value any) error {
+	if s == nil {
+		return ErrNoSession
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return pkgerrors.WithStack(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rec.Values[key] = b
+	s.modified = true
+
+	return nil
+}
+
+// Delete removes the value stored under key
+func (s *Session) Delete(key string) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.rec.Values[key]; exists {
+		delete(s.rec.Values, key)
+		s.modified = true
+	}
+}
+
+// Clear removes all values and the user from the session, but keeps the session ID
+func (s *Session) Clear() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rec.Values = map[string]json.RawMessage{}
+	s.rec.User = nil
+	s.modified = true
+}
+
+// User returns the user attached to the session
+func (s *Session) User() (User, bool) {
+	if s == nil {
+		return User{}, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.rec.User == nil {
+		return User{}, false
+	}
+
+	return *s.rec.User, true
+}
+
+// RenewID generates a new session ID while keeping the session data.
+// The old session is removed from the store when the response is written
+func (s *Session) RenewID() error {
+	if s == nil {
+		return ErrNoSession
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.renewID(id)
+
+	return nil
+}
+
+// Login attaches the user to the session and rotates the session ID to prevent session fixation
+func (s *Session) Login(user User) error {
+	if s == nil {
+		return ErrNoSession
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.renewID(id)
+	s.rec.User = &user
+
+	return nil
+}
+
+// Destroy deletes the session from the store and expires the session cookie
+func (s *Session) Destroy() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.destroyed = true
+}
+
+func (s *Session) renewID(id string) {
+	// Only keep the first ID, it's the one persisted in the store
+	if s.oldID == "" && !s.isNew {
+		s.oldID = s.id
+	}
+
+	s.id = id
+	s.modified = true
+}
+
+// Value returns the value stored under key decoded as T
+//
+// Usage:
+//
+//	func handler(c lit.Context) error {
+//		cartID, ok := session.Value[string](session.FromContext(c), "cart_id")
+//		...
+//	}
+func Value[T any](s *Session, key string) (T, bool) {
+	var rs T
+	found, err := s.Get(key, &rs)
+	if !found || err != nil {
+		return *new(T), false
+	}
+
+	return rs, true
+}
+
+func generateID() (string, error) {
+	b := make([]byte, sessionIDLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", pkgerrors.WithStack(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,87 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_TypedAccessors(t *testing.T) {
+	// Given
+	sess, err := newSession()
+	require.NoError(t, err)
+
+	givenTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, sess.Set("name", "lit"))
+	require.NoError(t, sess.Set("count", 42))
+	require.NoError(t, sess.Set("ratio", 0.5))
+	require.NoError(t, sess.Set("enabled", true))
+	require.NoError(t, sess.Set("at", givenTime))
+	require.NoError(t, sess.Set("cart", map[string]int{"sku-1": 2}))
+
+	// When & Then
+	name, ok := sess.GetString("name")
+	require.True(t, ok)
+	require.Equal(t, "lit", name)
+
+	count, ok := sess.GetInt("count")
+	require.True(t, ok)
+	require.Equal(t, int64(42), count)
+
+	ratio, ok := sess.GetFloat("ratio")
+	require.True(t, ok)
+	require.Equal(t, 0.5, ratio)
+
+	enabled, ok := sess.GetBool("enabled")
+	require.True(t, ok)
+	require.True(t, enabled)
+
+	at, ok := sess.GetTime("at")
+	require.True(t, ok)
+	require.True(t, givenTime.Equal(at))
+
+	cart, ok := Value[map[string]int](sess, "cart")
+	require.True(t, ok)
+	require.Equal(t, map[string]int{"sku-1": 2}, cart)
+
+	_, ok = sess.GetInt("name") // Wrong type
+	require.False(t, ok)
+
+	_, ok = sess.GetString("missing")
+	require.False(t, ok)
+
+	sess.Delete("name")
+	_, ok = sess.GetString("name")
+	require.False(t, ok)
+}
+
+func TestSession_Login(t *testing.T) {
+	// Given
+	sess, err := loadSession("old-id", []byte(`{"values":{"theme":"\"dark\""},"created_at":1735689600}`))
+	require.NoError(t, err)
+
+	// When
+	require.NoError(t, sess.Login(User{ID: "user-1", Roles: []string{"admin"}}))
+	require.NoError(t, sess.RenewID())
+
+	// Then
+	require.NotEqual(t, "old-id", sess.ID())
+	require.Equal(t, "old-id", sess.oldID) // The persisted ID must be kept for deletion
+	require.True(t, sess.modified)
+
+	user, ok := sess.User()
+	require.True(t, ok)
+	require.Equal(t, "user-1", user.UserProfile().ID())
+	require.Equal(t, []string{"admin"}, user.UserProfile().GetRoles())
+}
+
+func TestSession_NilSafe(t *testing.T) {
+	var sess *Session
+
+	_, ok := sess.GetString("key")
+	require.False(t, ok)
+	require.Equal(t, ErrNoSession, sess.Set("key", "value"))
+	require.Equal(t, ErrNoSession, sess.Login(User{ID: "user-1"}))
+	require.Equal(t, "", sess.ID())
+}
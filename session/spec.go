@@ -0,0 +1,21 @@
+package session
+
+import (
+	"context"
+	"time"
+)
+
+// Store persists serialized session data by session ID
+type Store interface {
+	// Load returns the serialized session data, or ErrSessionNotFound if the session does not exist or has expired
+	Load(ctx context.Context, id string) ([]byte, error)
+
+	// Save stores the serialized session data with the given time to live
+	Save(ctx context.Context, id string, data []byte, ttl time.Duration) error
+
+	// Touch extends the time to live of an existing session without rewriting its data
+	Touch(ctx context.Context, id string, ttl time.Duration) error
+
+	// Delete removes the session, deleting a missing session is not an error
+	Delete(ctx context.Context, id string) error
+}
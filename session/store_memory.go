@@ -0,0 +1,71 @@
+package session
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+func (s *memoryStore) Load(_ context.Context, id string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.entries[id]
+	if !exists {
+		return nil, ErrSessionNotFound
+	}
+
+	if timeNowFunc().After(entry.expiresAt) {
+		delete(s.entries, id)
+		return nil, ErrSessionNotFound
+	}
+
+	return slices.Clone(entry.data), nil
+}
+
+func (s *memoryStore) Save(_ context.Context, id string, data []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[id] = memoryEntry{
+		data:      slices.Clone(data),
+		expiresAt: timeNowFunc().Add(ttl),
+	}
+
+	return nil
+}
+
+func (s *memoryStore) Touch(_ context.Context, id string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.entries[id]
+	if !exists || timeNowFunc().After(entry.expiresAt) {
+		return ErrSessionNotFound
+	}
+
+	entry.expiresAt = timeNowFunc().Add(ttl)
+	s.entries[id] = entry
+
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, id)
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	// Given
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	timeNowFunc = func() time.Time { return now }
+	defer func() { timeNowFunc = time.Now }()
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	// When & Then
+	_, err := store.Load(ctx, "id-1")
+	require.ErrorIs(t, err, ErrSessionNotFound)
+
+	require.NoError(t, store.Save(ctx, "id-1", []byte("data"), time.Minute))
+	data, err := store.Load(ctx, "id-1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), data)
+
+	now = now.Add(50 * time.Second)
+	require.NoError(t, store.Touch(ctx, "id-1", time.Minute))
+
+	now = now.Add(50 * time.Second)
+	_, err = store.Load(ctx, "id-1")
+	require.NoError(t, err) // Still alive because of Touch
+
+	now = now.Add(2 * time.Minute)
+	_, err = store.Load(ctx, "id-1")
+	require.ErrorIs(t, err, ErrSessionNotFound)
+	require.ErrorIs(t, store.Touch(ctx, "id-1", time.Minute), ErrSessionNotFound)
+
+	require.NoError(t, store.Delete(ctx, "id-1"))
+}
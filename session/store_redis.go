@@ -0,0 +1,53 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/viebiz/lit/caching/redis"
+)
+
+type redisStore struct {
+	client    redis.Client
+	keyPrefix string
+}
+
+func (s redisStore) Load(ctx context.Context, id string) ([]byte, error) {
+	data, err := s.client.GetString(ctx, s.key(id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Redis client returns empty string if the key does not exist
+	if data == "" {
+		return nil, ErrSessionNotFound
+	}
+
+	return []byte(data), nil
+}
+
+func (s redisStore) Save(ctx context.Context, id string, data []byte, ttl time.Duration) error {
+	return s.client.SetString(ctx, s.key(id), string(data), ttl)
+}
+
+func (s redisStore) Touch(ctx context.Context, id string, ttl time.Duration) error {
+	updated, err := s.client.Expire(ctx, s.key(id), ttl)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (s redisStore) Delete(ctx context.Context, id string) error {
+	_, err := s.client.Delete(ctx, s.key(id))
+	return err
+}
+
+func (s redisStore) key(id string) string {
+	return s.keyPrefix + id
+}
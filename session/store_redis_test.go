@@ -0,0 +1,74 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit/caching/redis"
+)
+
+func TestRedisStore_Load(t *testing.T) {
+	tcs := map[string]struct {
+		mockValue string
+		mockErr   error
+		expData   []byte
+		expErr    error
+	}{
+		"success": {
+			mockValue: `{"created_at":1}`,
+			expData:   []byte(`{"created_at":1}`),
+		},
+		"error - not found": {
+			expErr: ErrSessionNotFound,
+		},
+		"error - redis error": {
+			mockErr: errors.New("simulated error"),
+			expErr:  errors.New("simulated error"),
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			ctx := context.Background()
+			mockClient := redis.NewMockClient(t)
+			mockClient.EXPECT().GetString(ctx, "session:id-1").Return(tc.mockValue, tc.mockErr)
+
+			// When
+			data, err := NewRedisStore(mockClient, "").Load(ctx, "id-1")
+
+			// Then
+			if tc.expErr != nil {
+				require.EqualError(t, err, tc.expErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expData, data)
+		})
+	}
+}
+
+func TestRedisStore_SaveTouchDelete(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	mockClient := redis.NewMockClient(t)
+	mockClient.EXPECT().SetString(ctx, "app:id-1", "data", time.Hour).Return(nil)
+	mockClient.EXPECT().Expire(ctx, "app:id-1", time.Hour).Return(true, nil)
+	mockClient.EXPECT().Expire(ctx, "app:id-2", time.Hour).Return(false, nil)
+	mockClient.EXPECT().Delete(ctx, "app:id-1").Return(1, nil)
+	store := NewRedisStore(mockClient, "app:")
+
+	// When & Then
+	require.NoError(t, store.Save(ctx, "id-1", []byte("data"), time.Hour))
+	require.NoError(t, store.Touch(ctx, "id-1", time.Hour))
+	require.ErrorIs(t, store.Touch(ctx, "id-2", time.Hour), ErrSessionNotFound)
+	require.NoError(t, store.Delete(ctx, "id-1"))
+}
@@ -0,0 +1,9 @@
+package session
+
+import (
+	"time"
+)
+
+var (
+	timeNowFunc = time.Now
+)
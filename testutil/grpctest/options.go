@@ -0,0 +1,58 @@
+package grpctest
+
+import (
+	"github.com/viebiz/lit"
+	"github.com/viebiz/lit/grpcclient"
+	"github.com/viebiz/lit/guard"
+	"github.com/viebiz/lit/iam"
+	"github.com/viebiz/lit/monitoring"
+)
+
+// Option customizes the test server
+type Option func(cfg *config)
+
+type config struct {
+	monitor   *monitoring.Monitor
+	validator iam.Validator
+	enforcer  iam.Enforcer
+	rules     guard.GRPCMethodRules
+	grpcOpts  []lit.GRPCOption
+	connOpts  []grpcclient.ConnOption
+}
+
+// WithMonitor sets the monitor of the server, e.g. one writing to a buffer to assert the logs.
+// The logs are discarded by default
+func WithMonitor(m *monitoring.Monitor) Option {
+	return func(cfg *config) {
+		cfg.monitor = m
+	}
+}
+
+// WithValidator authenticates the calls by the validator, e.g. a mock iam.Validator, and the method rules
+func WithValidator(validator iam.Validator, rules guard.GRPCMethodRules) Option {
+	return func(cfg *config) {
+		cfg.validator = validator
+		cfg.rules = rules
+	}
+}
+
+// WithEnforcer authorizes the calls of the rules with resource and action, it requires WithValidator
+func WithEnforcer(enforcer iam.Enforcer) Option {
+	return func(cfg *config) {
+		cfg.enforcer = enforcer
+	}
+}
+
+// WithServerOptions adds the options of the server after the default interceptors, e.g. lit.WithRequestValidation
+func WithServerOptions(opts ...lit.GRPCOption) Option {
+	return func(cfg *config) {
+		cfg.grpcOpts = append(cfg.grpcOpts, opts...)
+	}
+}
+
+// WithConnOptions adds the options of the client connection
+func WithConnOptions(opts ...grpcclient.ConnOption) Option {
+	return func(cfg *config) {
+		cfg.connOpts = append(cfg.connOpts, opts...)
+	}
+}
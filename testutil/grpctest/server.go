@@ -0,0 +1,114 @@
+package grpctest
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/viebiz/lit"
+	"github.com/viebiz/lit/grpcclient"
+	"github.com/viebiz/lit/guard"
+	"github.com/viebiz/lit/monitoring"
+)
+
+const (
+	bufSize = 1024 * 1024
+
+	// target is the dial target of the in-memory server, passthrough skips the DNS resolution
+	target = "passthrough:///bufnet"
+)
+
+// Start starts a lit.GRPCServer with the default interceptors over an in-memory bufconn listener
+// and returns the client connection to it. The services are registered by register before the server starts,
+// the connection is closed and the server is stopped when the test finishes.
+//
+// Example:
+//
+//	var logs bytes.Buffer
+//	m, _ := monitoring.New(monitoring.Config{Writer: &logs})
+//	conn := grpctest.Start(t, func(r lit.ServiceRegistrar) {
+//		orderspb.RegisterOrderServiceServer(r, ordersServer)
+//	},
+//		grpctest.WithMonitor(m),
+//		grpctest.WithValidator(mockValidator, guard.GRPCMethodRules{"/orders.v1.OrderService/*": {M2M: true}}),
+//	)
+//	client := orderspb.NewOrderServiceClient(conn)
+func Start(t testing.TB, register func(r lit.ServiceRegistrar), opts ...Option) grpcclient.Conn {
+	t.Helper()
+
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.monitor == nil {
+		m, err := monitoring.New(monitoring.Config{Writer: io.Discard})
+		require.NoError(t, err)
+		cfg.monitor = m
+	}
+
+	ctx, cancel := context.WithCancel(monitoring.SetInContext(context.Background(), cfg.monitor))
+
+	serverOpts, err := cfg.serverOptions(ctx)
+	require.NoError(t, err)
+
+	srv, err := lit.NewGRPCServerWithOptions(ctx, target, serverOpts...)
+	require.NoError(t, err)
+	register(srv.Registrar())
+
+	lis := bufconn.Listen(bufSize)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		_ = srv.ServeWithContext(ctx, lis)
+	}()
+
+	conn, err := grpcclient.NewUnauthenticatedConnection(ctx, target,
+		append([]grpcclient.ConnOption{
+			grpcclient.WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			})),
+		}, cfg.connOpts...)...,
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		cancel()
+		<-stopped
+	})
+
+	return conn
+}
+
+func (cfg config) serverOptions(ctx context.Context) ([]lit.GRPCOption, error) {
+	opts := []lit.GRPCOption{lit.WithDefaultInterceptors(ctx)}
+
+	if cfg.validator != nil {
+		authGuard := guard.New(cfg.validator, cfg.enforcer)
+
+		unaryInterceptor, err := authGuard.UnaryServerInterceptor(cfg.rules)
+		if err != nil {
+			return nil, err
+		}
+
+		streamInterceptor, err := authGuard.StreamServerInterceptor(cfg.rules)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, func(serverOpts *[]grpc.ServerOption) {
+			*serverOpts = append(*serverOpts,
+				grpc.ChainUnaryInterceptor(unaryInterceptor),
+				grpc.ChainStreamInterceptor(streamInterceptor),
+			)
+		})
+	}
+
+	return append(opts, cfg.grpcOpts...), nil
+}
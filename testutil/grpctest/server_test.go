@@ -0,0 +1,142 @@
+package grpctest
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/viebiz/lit"
+	"github.com/viebiz/lit/grpcclient"
+	"github.com/viebiz/lit/grpcclient/testdata"
+	"github.com/viebiz/lit/guard"
+	"github.com/viebiz/lit/iam"
+	"github.com/viebiz/lit/jwt"
+	"github.com/viebiz/lit/monitoring"
+)
+
+func TestStart(t *testing.T) {
+	m2mToken := jwt.Token[iam.Claims]{
+		Claims: iam.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "imperium|ultra_marine"},
+			ExtraClaims:      map[string]interface{}{"scope": "weather:read"},
+		},
+	}
+
+	type mockValidator struct {
+		expCall  bool
+		outToken jwt.Token[iam.Claims]
+		outErr   error
+	}
+	tcs := map[string]struct {
+		givenAuth     string
+		mockValidator mockValidator
+		expM2MID      string
+		expCode       codes.Code
+	}{
+		"success": {
+			givenAuth:     "Bearer m2m-token",
+			mockValidator: mockValidator{expCall: true, outToken: m2mToken},
+			expM2MID:      "imperium|ultra_marine",
+			expCode:       codes.OK,
+		},
+		"error - missing access token": {
+			expCode: codes.Unauthenticated,
+		},
+		"error - token expired": {
+			givenAuth:     "Bearer m2m-token",
+			mockValidator: mockValidator{expCall: true, outErr: iam.ErrTokenExpired},
+			expCode:       codes.Unauthenticated,
+		},
+	}
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			var logs syncBuffer
+			m, err := monitoring.New(monitoring.Config{Writer: &logs})
+			require.NoError(t, err)
+
+			validator := iam.NewMockValidator(t)
+			if tc.mockValidator.expCall {
+				validator.EXPECT().Validate("m2m-token").Return(tc.mockValidator.outToken, tc.mockValidator.outErr)
+			}
+
+			svc := &weatherService{}
+			conn := Start(t, func(r lit.ServiceRegistrar) {
+				testdata.RegisterWeatherServiceServer(r, svc)
+			},
+				WithMonitor(m),
+				WithValidator(validator, guard.GRPCMethodRules{"/weather.WeatherService/*": {M2M: true}}),
+			)
+
+			ctx := context.Background()
+			if tc.givenAuth != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tc.givenAuth)
+			}
+
+			// When
+			_, err = testdata.NewWeatherServiceClient(conn).GetWeatherInfo(ctx, &testdata.WeatherRequest{Date: "M41.993.32"})
+
+			// Then
+			require.Equal(t, tc.expCode, status.Code(err))
+			require.Equal(t, tc.expM2MID, svc.m2mID)
+			require.Contains(t, logs.String(), "/weather.WeatherService/GetWeatherInfo")
+		})
+	}
+}
+
+func TestStart_ClosesConnection(t *testing.T) {
+	// Given
+	var conn grpcclient.Conn
+	t.Run("start", func(t *testing.T) {
+		conn = Start(t, func(r lit.ServiceRegistrar) {
+			testdata.RegisterWeatherServiceServer(r, &weatherService{})
+		})
+	})
+
+	// When
+	err := conn.Invoke(context.Background(), testdata.WeatherService_GetWeatherInfo_FullMethodName, &testdata.WeatherRequest{}, &testdata.WeatherResponse{})
+
+	// Then
+	// The connection is closed by the cleanup of the subtest
+	require.Equal(t, codes.Canceled, status.Code(err))
+}
+
+type weatherService struct {
+	testdata.UnimplementedWeatherServiceServer
+	m2mID string
+}
+
+func (s *weatherService) GetWeatherInfo(ctx context.Context, _ *testdata.WeatherRequest) (*testdata.WeatherResponse, error) {
+	s.m2mID = iam.GetM2MProfileFromContext(ctx).ID()
+
+	return &testdata.WeatherResponse{}, nil
+}
+
+// syncBuffer is the log writer shared by the server goroutines
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
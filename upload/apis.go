@@ -0,0 +1,113 @@
+package upload
+
+const (
+	defaultMaxFileSize      = 32 << 20 // 32MB
+	defaultMaxTotalSize     = 64 << 20 // 64MB
+	defaultMaxValueSize     = 1 << 20  // 1MB
+	defaultMaxValuesSize    = 10 << 20 // 10MB, as the non-file parts reserve of multipart.Reader.ReadForm
+	defaultMaxFiles         = 10
+	defaultProgressInterval = 4 << 20 // 4MB
+)
+
+// Config holds the upload limits and validation rules
+type Config struct {
+	// MaxFileSize is the maximum size in bytes of a single file
+	// Default: 32MB
+	MaxFileSize int64
+
+	// MaxTotalSize is the maximum size in bytes of the whole request body
+	// Default: 64MB
+	MaxTotalSize int64
+
+	// MaxFiles is the maximum number of files in a request
+	// Default: 10
+	MaxFiles int
+
+	// MaxValueSize is the maximum size in bytes of a non-file form value
+	// Default: 1MB
+	MaxValueSize int64
+
+	// MaxValuesSize is the maximum total size in bytes of the non-file form values, they are kept in memory
+	// Default: 10MB
+	MaxValuesSize int64
+
+	// AllowedTypes is the list of allowed MIME types detected by magic bytes, e.g. "image/png" or "image/*".
+	// All types are allowed if it's empty
+	AllowedTypes []string
+
+	// KeyFunc generates the key of the file in the Sink
+	// Default: random UUID
+	KeyFunc func(FileInfo) string
+
+	// ProgressInterval is the number of bytes between progress logs
+	// Default: 4MB
+	ProgressInterval int64
+}
+
+// New creates a new Uploader which streams the files to the given sink
+//
+// Example:
+//
+//	uploader := upload.New(upload.Config{
+//		MaxFileSize:  10 << 20,
+//		MaxTotalSize: 50 << 20,
+//		AllowedTypes: []string{"image/png", "image/jpeg", "application/pdf"},
+//	}, upload.NewFileSystemSink("/var/lib/app/uploads"))
+//
+//	r.Post("/documents", func(c lit.Context) error {
+//		rs, err := uploader.Upload(c)
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	})
+func New(cfg Config, sink Sink) Uploader {
+	return Uploader{
+		cfg:  prepareConfig(cfg),
+		sink: sink,
+	}
+}
+
+// NewFileSystemSink creates a Sink which stores the files in the given directory
+func NewFileSystemSink(dir string) Sink {
+	return fileSystemSink{dir: dir}
+}
+
+// NewMemorySink creates a Sink which keeps the files in memory, it's suitable for testing
+func NewMemorySink() Sink {
+	return &memorySink{
+		objects: map[string][]byte{},
+	}
+}
+
+func prepareConfig(cfg Config) Config {
+	if cfg.MaxFileSize <= 0 {
+		cfg.MaxFileSize = defaultMaxFileSize
+	}
+
+	if cfg.MaxTotalSize <= 0 {
+		cfg.MaxTotalSize = defaultMaxTotalSize
+	}
+
+	if cfg.MaxFiles <= 0 {
+		cfg.MaxFiles = defaultMaxFiles
+	}
+
+	if cfg.MaxValueSize <= 0 {
+		cfg.MaxValueSize = defaultMaxValueSize
+	}
+
+	if cfg.MaxValuesSize <= 0 {
+		cfg.MaxValuesSize = defaultMaxValuesSize
+	}
+
+	if cfg.KeyFunc == nil {
+		cfg.KeyFunc = newKeyFunc
+	}
+
+	if cfg.ProgressInterval <= 0 {
+		cfg.ProgressInterval = defaultProgressInterval
+	}
+
+	return cfg
+}
@@ -0,0 +1,41 @@
+package upload
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/viebiz/lit"
+)
+
+var (
+	// ErrNotMultipart means the request content type is not multipart/form-data
+	ErrNotMultipart = &lit.HTTPError{Status: http.StatusBadRequest, Code: "invalid_request", Desc: "Request must be multipart/form-data"}
+
+	// ErrFileTooLarge means a file exceeds Config.MaxFileSize
+	ErrFileTooLarge = &lit.HTTPError{Status: http.StatusRequestEntityTooLarge, Code: "file_too_large", Desc: "File exceeds the maximum allowed size"}
+
+	// ErrRequestTooLarge means the request body exceeds Config.MaxTotalSize
+	ErrRequestTooLarge = &lit.HTTPError{Status: http.StatusRequestEntityTooLarge, Code: "request_too_large", Desc: "Request exceeds the maximum allowed size"}
+
+	// ErrValueTooLarge means a non-file form value exceeds Config.MaxValueSize
+	ErrValueTooLarge = &lit.HTTPError{Status: http.StatusRequestEntityTooLarge, Code: "value_too_large", Desc: "Form value exceeds the maximum allowed size"}
+
+	// ErrValuesTooLarge means the non-file form values exceed Config.MaxValuesSize in total
+	ErrValuesTooLarge = &lit.HTTPError{Status: http.StatusRequestEntityTooLarge, Code: "values_too_large", Desc: "Form values exceed the maximum allowed size"}
+
+	// ErrTooManyFiles means the request contains more than Config.MaxFiles files
+	ErrTooManyFiles = &lit.HTTPError{Status: http.StatusBadRequest, Code: "too_many_files", Desc: "Request contains too many files"}
+
+	// ErrUnsupportedMediaType means the file content does not match Config.AllowedTypes
+	ErrUnsupportedMediaType = &lit.HTTPError{Status: http.StatusUnsupportedMediaType, Code: "unsupported_media_type", Desc: "File type is not allowed"}
+
+	// ErrObjectNotFound means the key does not exist in the sink
+	ErrObjectNotFound = errors.New("object not found")
+
+	// ErrInvalidKey means the key is empty or escapes the sink root directory
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+func invalidRequestErr(err error) *lit.HTTPError {
+	return &lit.HTTPError{Status: http.StatusBadRequest, Code: "invalid_request", Desc: err.Error()}
+}
@@ -0,0 +1,192 @@
+// Code generated by mockery v2.53.0. DO NOT EDIT.
+
+package upload
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSink is an autogenerated mock type for the Sink type
+type MockSink struct {
+	mock.Mock
+}
+
+type MockSink_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSink) EXPECT() *MockSink_Expecter {
+	return &MockSink_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, key
+func (_m *MockSink) Delete(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSink_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSink_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockSink_Expecter) Delete(ctx interface{}, key interface{}) *MockSink_Delete_Call {
+	return &MockSink_Delete_Call{Call: _e.mock.On("Delete", ctx, key)}
+}
+
+func (_c *MockSink_Delete_Call) Run(run func(ctx context.Context, key string)) *MockSink_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSink_Delete_Call) Return(_a0 error) *MockSink_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSink_Delete_Call) RunAndReturn(run func(context.Context, string) error) *MockSink_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function with given fields: ctx, key
+func (_m *MockSink) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (io.ReadCloser, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSink_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockSink_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockSink_Expecter) Open(ctx interface{}, key interface{}) *MockSink_Open_Call {
+	return &MockSink_Open_Call{Call: _e.mock.On("Open", ctx, key)}
+}
+
+func (_c *MockSink_Open_Call) Run(run func(ctx context.Context, key string)) *MockSink_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSink_Open_Call) Return(_a0 io.ReadCloser, _a1 error) *MockSink_Open_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSink_Open_Call) RunAndReturn(run func(context.Context, string) (io.ReadCloser, error)) *MockSink_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, key, r, contentType
+func (_m *MockSink) Save(ctx context.Context, key string, r io.Reader, contentType string) error {
+	ret := _m.Called(ctx, key, r, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, string) error); ok {
+		r0 = rf(ctx, key, r, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSink_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockSink_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - r io.Reader
+//   - contentType string
+func (_e *MockSink_Expecter) Save(ctx interface{}, key interface{}, r interface{}, contentType interface{}) *MockSink_Save_Call {
+	return &MockSink_Save_Call{Call: _e.mock.On("Save", ctx, key, r, contentType)}
+}
+
+func (_c *MockSink_Save_Call) Run(run func(ctx context.Context, key string, r io.Reader, contentType string)) *MockSink_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(io.Reader), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockSink_Save_Call) Return(_a0 error) *MockSink_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSink_Save_Call) RunAndReturn(run func(context.Context, string, io.Reader, string) error) *MockSink_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSink creates a new instance of MockSink. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSink(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSink {
+	mock := &MockSink{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,88 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSystemSink(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	dir := t.TempDir()
+	sink := NewFileSystemSink(dir)
+
+	// When & Then
+	require.NoError(t, sink.Save(ctx, "docs/report.pdf", strings.NewReader("content"), "application/pdf"))
+	rc, err := sink.Open(ctx, "docs/report.pdf")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, "content", string(data))
+
+	require.NoError(t, sink.Delete(ctx, "docs/report.pdf"))
+	require.NoError(t, sink.Delete(ctx, "docs/report.pdf"))
+	_, err = sink.Open(ctx, "docs/report.pdf")
+	require.ErrorIs(t, err, ErrObjectNotFound)
+}
+
+func TestFileSystemSink_Save(t *testing.T) {
+	tcs := map[string]struct {
+		givenKey    string
+		givenReader io.Reader
+		expErr      error
+	}{
+		"invalid key - parent directory": {
+			givenKey:    "../escape.txt",
+			givenReader: strings.NewReader("content"),
+			expErr:      ErrInvalidKey,
+		},
+		"invalid key - absolute path": {
+			givenKey:    "/etc/passwd",
+			givenReader: strings.NewReader("content"),
+			expErr:      ErrInvalidKey,
+		},
+		"read error - partial file is removed": {
+			givenKey:    "partial.txt",
+			givenReader: io.MultiReader(strings.NewReader("content"), errReader{err: ErrFileTooLarge}),
+			expErr:      ErrFileTooLarge,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			dir := t.TempDir()
+			sink := NewFileSystemSink(dir)
+
+			// When
+			err := sink.Save(context.Background(), tc.givenKey, tc.givenReader, "text/plain")
+
+			// Then
+			require.ErrorIs(t, err, tc.expErr)
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			require.Empty(t, entries)
+			_, err = os.Stat(filepath.Join(dir, tc.givenKey))
+			require.True(t, errors.Is(err, os.ErrNotExist))
+		})
+	}
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
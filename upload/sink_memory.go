@@ -0,0 +1,46 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+type memorySink struct {
+	mu      sync.RWMutex
+	objects map[string][]byte
+}
+
+func (s *memorySink) Save(_ context.Context, key string, r io.Reader, _ string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memorySink) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, ErrObjectNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memorySink) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects, key)
+	return nil
+}
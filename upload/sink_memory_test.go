@@ -0,0 +1,32 @@
+package upload
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemorySink(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	sink := NewMemorySink()
+
+	// When & Then
+	_, err := sink.Open(ctx, "avatar.png")
+	require.ErrorIs(t, err, ErrObjectNotFound)
+
+	require.NoError(t, sink.Save(ctx, "avatar.png", strings.NewReader("content"), "image/png"))
+	rc, err := sink.Open(ctx, "avatar.png")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.Equal(t, "content", string(data))
+
+	require.NoError(t, sink.Delete(ctx, "avatar.png"))
+	require.NoError(t, sink.Delete(ctx, "avatar.png"))
+	_, err = sink.Open(ctx, "avatar.png")
+	require.ErrorIs(t, err, ErrObjectNotFound)
+}
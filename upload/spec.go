@@ -0,0 +1,21 @@
+package upload
+
+import (
+	"context"
+	"io"
+)
+
+// Sink stores the uploaded file content.
+// Implement this interface to stream uploads to an object store such as S3 or GCS
+type Sink interface {
+	// Save streams the content to the storage under the given key.
+	// The reader returns an error when the upload exceeds the limits, the partial content must not be kept in that case
+	// and the content previously stored under the key must stay untouched
+	Save(ctx context.Context, key string, r io.Reader, contentType string) error
+
+	// Open returns the content of the stored file, or ErrObjectNotFound if the key does not exist
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the stored file, deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+}
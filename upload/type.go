@@ -0,0 +1,55 @@
+package upload
+
+import (
+	"github.com/google/uuid"
+)
+
+// File is the metadata of a stored file
+type File struct {
+	// Field is the form field name of the file
+	Field string
+
+	// Filename is the file name provided by the client
+	Filename string
+
+	// Key is the key of the file in the Sink
+	Key string
+
+	// ContentType is the MIME type detected from the file content
+	ContentType string
+
+	// Size is the number of bytes stored
+	Size int64
+
+	// SHA256 is the hex encoded SHA-256 checksum of the content
+	SHA256 string
+
+	// MD5 is the base64 encoded MD5 checksum of the content, it can be used as Content-MD5 of object stores
+	MD5 string
+}
+
+// Result holds the stored files and the non-file form values of a multipart request
+type Result struct {
+	Files  []File
+	Values map[string][]string
+}
+
+// Value returns the first value of the form field
+func (r Result) Value(key string) string {
+	if vs := r.Values[key]; len(vs) > 0 {
+		return vs[0]
+	}
+
+	return ""
+}
+
+// FileInfo describes a file part before it is stored, used to generate the key
+type FileInfo struct {
+	Field       string
+	Filename    string
+	ContentType string
+}
+
+var newKeyFunc = func(FileInfo) string {
+	return uuid.NewString()
+}
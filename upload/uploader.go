@@ -0,0 +1,250 @@
+package upload
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/viebiz/lit"
+	"github.com/viebiz/lit/monitoring"
+)
+
+const (
+	// sniffLen is the number of bytes used by http.DetectContentType
+	sniffLen = 512
+)
+
+// Uploader streams the files of multipart requests to a Sink
+type Uploader struct {
+	cfg  Config
+	sink Sink
+}
+
+// Upload reads the multipart request part by part and streams every file to the Sink without buffering it in memory or on disk.
+// The limits and allowed types are checked while reading, and the stored files are removed if the request is rejected
+func (u Uploader) Upload(c lit.Context) (Result, error) {
+	req := c.Request()
+	ctx := req.Context()
+
+	req.Body = http.MaxBytesReader(c.Writer(), req.Body, u.cfg.MaxTotalSize)
+
+	mr, err := req.MultipartReader()
+	if err != nil {
+		return Result{}, ErrNotMultipart
+	}
+
+	rs := Result{
+		Values: map[string][]string{},
+	}
+	if err := u.readParts(ctx, mr, &rs); err != nil {
+		u.cleanup(ctx, rs.Files)
+		return Result{}, err
+	}
+
+	return rs, nil
+}
+
+func (u Uploader) readParts(ctx context.Context, mr *multipart.Reader, rs *Result) error {
+	valuesSize := int64(0)
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return convertReadErr(err)
+		}
+
+		// 1. Non-file form value
+		if part.FileName() == "" {
+			value, err := u.readValue(part, u.cfg.MaxValuesSize-valuesSize)
+			part.Close()
+			if err != nil {
+				return err
+			}
+
+			valuesSize += int64(len(value))
+			rs.Values[part.FormName()] = append(rs.Values[part.FormName()], value)
+			continue
+		}
+
+		// 2. File
+		if len(rs.Files) >= u.cfg.MaxFiles {
+			part.Close()
+			return ErrTooManyFiles
+		}
+
+		file, err := u.store(ctx, part)
+		part.Close()
+		if err != nil {
+			return err
+		}
+
+		rs.Files = append(rs.Files, file)
+	}
+}
+
+// readValue reads the form value, it must fit in MaxValueSize and the remaining bytes of MaxValuesSize
+func (u Uploader) readValue(part *multipart.Part, remaining int64) (string, error) {
+	value, err := io.ReadAll(io.LimitReader(part, min(u.cfg.MaxValueSize, remaining)+1))
+	if err != nil {
+		return "", convertReadErr(err)
+	}
+
+	if int64(len(value)) > u.cfg.MaxValueSize {
+		return "", ErrValueTooLarge
+	}
+
+	if int64(len(value)) > remaining {
+		return "", ErrValuesTooLarge
+	}
+
+	return string(value), nil
+}
+
+func (u Uploader) store(ctx context.Context, part *multipart.Part) (File, error) {
+	// 1. Detect content type by magic bytes
+	br := bufio.NewReaderSize(part, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return File{}, convertReadErr(err)
+	}
+
+	file := File{
+		Field:       part.FormName(),
+		Filename:    part.FileName(),
+		ContentType: http.DetectContentType(head),
+	}
+	if !u.isAllowed(file.ContentType) {
+		return File{}, ErrUnsupportedMediaType
+	}
+
+	file.Key = u.cfg.KeyFunc(FileInfo{
+		Field:       file.Field,
+		Filename:    file.Filename,
+		ContentType: file.ContentType,
+	})
+
+	// 2. Stream to the sink, enforce limit and compute checksums on the fly
+	monitor := monitoring.FromContext(ctx).With(map[string]string{
+		"upload_field": file.Field,
+		"upload_key":   file.Key,
+	})
+	sha256Hash, md5Hash := sha256.New(), md5.New()
+	r := &fileReader{
+		r:        br,
+		limit:    u.cfg.MaxFileSize,
+		interval: u.cfg.ProgressInterval,
+		next:     u.cfg.ProgressInterval,
+		onProgress: func(n int64) {
+			monitor.Info("Upload in progress", monitoring.IntField("upload_bytes", int(n)))
+		},
+	}
+
+	// The sink discards the partial content of a failed Save, the key may hold a previous upload so it's not deleted
+	if err := u.sink.Save(ctx, file.Key, io.TeeReader(r, io.MultiWriter(sha256Hash, md5Hash)), file.ContentType); err != nil {
+		return File{}, convertReadErr(err)
+	}
+
+	file.Size = r.n
+	file.SHA256 = hex.EncodeToString(sha256Hash.Sum(nil))
+	file.MD5 = base64.StdEncoding.EncodeToString(md5Hash.Sum(nil))
+
+	monitor.Info("Uploaded file",
+		monitoring.IntField("upload_bytes", int(file.Size)),
+		monitoring.StringField("upload_content_type", file.ContentType),
+		monitoring.StringField("upload_sha256", file.SHA256),
+	)
+
+	return file, nil
+}
+
+func (u Uploader) isAllowed(contentType string) bool {
+	if len(u.cfg.AllowedTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range u.cfg.AllowedTypes {
+		if allowed == mediaType {
+			return true
+		}
+
+		if prefix, found := strings.CutSuffix(allowed, "*"); found && strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cleanup removes the stored files of a rejected request
+func (u Uploader) cleanup(ctx context.Context, files []File) {
+	for _, file := range files {
+		if err := u.sink.Delete(ctx, file.Key); err != nil {
+			monitoring.FromContext(ctx).Errorf(err, "Failed to delete uploaded file %s", file.Key)
+		}
+	}
+}
+
+func convertReadErr(err error) error {
+	if errors.Is(err, ErrFileTooLarge) {
+		return ErrFileTooLarge
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return ErrRequestTooLarge
+	}
+
+	var httpErr lit.Error
+	if errors.As(err, &httpErr) {
+		return err
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || strings.HasPrefix(err.Error(), "multipart:") {
+		return invalidRequestErr(err)
+	}
+
+	return pkgerrors.WithStack(err)
+}
+
+// fileReader counts the read bytes, fails when the limit is exceeded and reports the progress
+type fileReader struct {
+	r          io.Reader
+	n          int64
+	limit      int64
+	interval   int64
+	next       int64
+	onProgress func(n int64)
+}
+
+func (r *fileReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if r.n > r.limit {
+		return n, ErrFileTooLarge
+	}
+
+	if r.n >= r.next {
+		r.onProgress(r.n)
+		r.next = r.n - r.n%r.interval + r.interval
+	}
+
+	return n, err
+}
@@ -0,0 +1,248 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/viebiz/lit"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+type givenPart struct {
+	field    string
+	filename string
+	content  []byte
+}
+
+func TestUploader_Upload(t *testing.T) {
+	tcs := map[string]struct {
+		givenConfig      Config
+		givenParts       []givenPart
+		givenContentType string
+		expResult        Result
+		expStored        map[string]string
+		expErr           error
+	}{
+		"success": {
+			givenConfig: Config{
+				AllowedTypes: []string{"image/*", "text/plain"},
+			},
+			givenParts: []givenPart{
+				{field: "title", content: []byte("holiday")},
+				{field: "photo", filename: "beach.png", content: append(pngHeader, "pixels"...)},
+				{field: "note", filename: "note.txt", content: []byte("hello")},
+			},
+			expResult: Result{
+				Files: []File{
+					{
+						Field:       "photo",
+						Filename:    "beach.png",
+						Key:         "photo/beach.png",
+						ContentType: "image/png",
+						Size:        14,
+						SHA256:      "4624836652c450f26fc11e7e372a59af7963ea7613e59ce92186c60c22301c97",
+						MD5:         "WJTHBujyWs81ukriw7LrWA==",
+					},
+					{
+						Field:       "note",
+						Filename:    "note.txt",
+						Key:         "note/note.txt",
+						ContentType: "text/plain; charset=utf-8",
+						Size:        5,
+						SHA256:      "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+						MD5:         "XUFAKrxLKna5cZ2REBfFkg==",
+					},
+				},
+				Values: map[string][]string{"title": {"holiday"}},
+			},
+			expStored: map[string]string{
+				"photo/beach.png": string(pngHeader) + "pixels",
+				"note/note.txt":   "hello",
+			},
+		},
+		"error - not multipart": {
+			givenContentType: "application/json",
+			expErr:           ErrNotMultipart,
+		},
+		"error - file too large": {
+			givenConfig: Config{MaxFileSize: 10},
+			givenParts: []givenPart{
+				{field: "small", filename: "small.txt", content: []byte("hello")},
+				{field: "large", filename: "large.txt", content: []byte(strings.Repeat("a", 11))},
+			},
+			expErr: ErrFileTooLarge,
+		},
+		"error - request too large": {
+			givenConfig: Config{MaxTotalSize: 256},
+			givenParts: []givenPart{
+				{field: "large", filename: "large.txt", content: []byte(strings.Repeat("a", 512))},
+			},
+			expErr: ErrRequestTooLarge,
+		},
+		"error - too many files": {
+			givenConfig: Config{MaxFiles: 1},
+			givenParts: []givenPart{
+				{field: "first", filename: "first.txt", content: []byte("hello")},
+				{field: "second", filename: "second.txt", content: []byte("world")},
+			},
+			expErr: ErrTooManyFiles,
+		},
+		"error - value too large": {
+			givenConfig: Config{MaxValueSize: 4},
+			givenParts: []givenPart{
+				{field: "title", content: []byte("holiday")},
+			},
+			expErr: ErrValueTooLarge,
+		},
+		"error - values too large": {
+			givenConfig: Config{MaxValuesSize: 10},
+			givenParts: []givenPart{
+				{field: "title", content: []byte("holiday")},
+				{field: "title", content: []byte("beach")},
+			},
+			expErr: ErrValuesTooLarge,
+		},
+		"error - unsupported media type": {
+			givenConfig: Config{AllowedTypes: []string{"image/png"}},
+			givenParts: []givenPart{
+				{field: "photo", filename: "fake.png", content: []byte("<html><body>not an image</body></html>")},
+			},
+			expErr: ErrUnsupportedMediaType,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			body, contentType := newMultipartBody(t, tc.givenParts)
+			if tc.givenContentType != "" {
+				contentType = tc.givenContentType
+			}
+			req := httptest.NewRequest(http.MethodPost, "/upload", body)
+			req.Header.Set("Content-Type", contentType)
+
+			w := httptest.NewRecorder()
+			c := lit.CreateTestContext(w)
+			c.SetRequest(req)
+
+			tc.givenConfig.KeyFunc = func(info FileInfo) string {
+				return info.Field + "/" + info.Filename
+			}
+			sink := NewMemorySink()
+			uploader := New(tc.givenConfig, sink)
+
+			// When
+			rs, err := uploader.Upload(c)
+
+			// Then
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				require.Empty(t, sink.(*memorySink).objects) // Stored files are removed
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expResult, rs)
+			for key, expContent := range tc.expStored {
+				rc, err := sink.Open(context.Background(), key)
+				require.NoError(t, err)
+				content, err := io.ReadAll(rc)
+				require.NoError(t, err)
+				require.Equal(t, expContent, string(content))
+			}
+		})
+	}
+}
+
+func TestUploader_Upload_KeepsPreviousFileOnFailedSave(t *testing.T) {
+	// Given
+	body, contentType := newMultipartBody(t, []givenPart{
+		{field: "doc", filename: "report.txt", content: []byte(strings.Repeat("a", 11))},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/upload", body)
+	req.Header.Set("Content-Type", contentType)
+
+	c := lit.CreateTestContext(httptest.NewRecorder())
+	c.SetRequest(req)
+
+	sink := NewMemorySink()
+	require.NoError(t, sink.Save(context.Background(), "doc/report.txt", strings.NewReader("previous"), "text/plain"))
+
+	uploader := New(Config{
+		MaxFileSize: 10,
+		KeyFunc: func(info FileInfo) string {
+			return info.Field + "/" + info.Filename
+		},
+	}, sink)
+
+	// When
+	_, err := uploader.Upload(c)
+
+	// Then
+	require.ErrorIs(t, err, ErrFileTooLarge)
+	rc, err := sink.Open(context.Background(), "doc/report.txt")
+	require.NoError(t, err)
+	content, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.Equal(t, "previous", string(content))
+}
+
+func TestFileReader_Progress(t *testing.T) {
+	// Given
+	var progress []int64
+	r := &fileReader{
+		r:          strings.NewReader(strings.Repeat("a", 25)),
+		limit:      100,
+		interval:   10,
+		next:       10,
+		onProgress: func(n int64) { progress = append(progress, n) },
+	}
+	buf := make([]byte, 4)
+
+	// When
+	for {
+		if _, err := r.Read(buf); err != nil {
+			require.ErrorIs(t, err, io.EOF)
+			break
+		}
+	}
+
+	// Then
+	require.Equal(t, []int64{12, 20}, progress)
+	require.Equal(t, int64(25), r.n)
+}
+
+func newMultipartBody(t *testing.T, parts []givenPart) (io.Reader, string) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, part := range parts {
+		var (
+			w   io.Writer
+			err error
+		)
+		if part.filename == "" {
+			w, err = mw.CreateFormField(part.field)
+		} else {
+			w, err = mw.CreateFormFile(part.field, part.filename)
+		}
+		require.NoError(t, err)
+
+		_, err = w.Write(part.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, mw.Close())
+
+	return &buf, mw.FormDataContentType()
+}
@@ -0,0 +1,318 @@
+package lit
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/viebiz/lit/monitoring"
+)
+
+const (
+	deprecationHeader = "Deprecation"
+	sunsetHeader      = "Sunset"
+	linkHeader        = "Link"
+	varyHeader        = "Vary"
+	acceptHeader      = "Accept"
+
+	meterName = "github.com/viebiz/lit"
+)
+
+var (
+	deprecatedVersionRequests, _ = otel.Meter(meterName).Int64Counter("http.server.deprecated_api_version.requests",
+		metric.WithDescription("Number of the requests to a deprecated API version"),
+		metric.WithUnit("{request}"),
+	)
+)
+
+type apiVersionCtxKey struct{}
+
+// APIVersion describes a version of the API and its deprecation policy
+type APIVersion struct {
+	// Name is the version name, e.g. "v1". It's used as the path prefix
+	// and matched with the header or Accept parameter value, the "v" prefix is optional there
+	Name string
+
+	// DeprecatedAt marks the version as deprecated when it's not zero, sent in the Deprecation header (RFC 9745)
+	// The Sunset is sent as the Deprecation date when only Sunset is set
+	DeprecatedAt time.Time
+
+	// Sunset is the time the version will be removed, sent in the Sunset header (RFC 8594)
+	Sunset time.Time
+
+	// Link is the URL of the deprecation notice or migration guide, sent in the Link header
+	Link string
+}
+
+// Deprecated reports whether the version is deprecated
+func (v APIVersion) Deprecated() bool {
+	return !v.DeprecatedAt.IsZero() || !v.Sunset.IsZero()
+}
+
+// VersioningConfig holds the API versioning configuration
+type VersioningConfig struct {
+	// Versions is the list of supported versions, ordered from the oldest to the latest
+	Versions []APIVersion
+
+	// PathPrefix registers every route under the version name prefix, e.g. /v1/orders and /v2/orders
+	// Header and AcceptParam are not used if it's enabled
+	PathPrefix bool
+
+	// Header is the request header carrying the version, e.g. API-Version
+	Header string
+
+	// AcceptParam is the Accept media type parameter carrying the version, e.g. "version" for
+	// Accept: application/json; version=2
+	AcceptParam string
+
+	// DefaultVersion is used when the request does not specify a version
+	// Default: the latest version
+	DefaultVersion string
+
+	// OnDeprecated is called when a deprecated version is requested, e.g. to notify the client owner.
+	// The requests are counted by the http.server.deprecated_api_version.requests metric anyway
+	OnDeprecated func(c Context, version APIVersion)
+}
+
+// APIVersionFromContext returns the API version requested by the client
+func APIVersionFromContext(ctx context.Context) (APIVersion, bool) {
+	v, ok := ctx.Value(apiVersionCtxKey{}).(APIVersion)
+	return v, ok
+}
+
+// versionedRoute implements VersionedRoute interface
+type versionedRoute struct {
+	cfg         VersioningConfig
+	routes      *router
+	defaultIdx  int
+	endpointsMu sync.RWMutex
+	endpoints   map[string]map[int]HandlerFunc // Handlers of the endpoint by version index
+}
+
+func (r *router) Versioned(cfg VersioningConfig, middleware ...HandlerFunc) VersionedRoute {
+	if len(cfg.Versions) == 0 {
+		panic("lit: versioning requires at least one version")
+	}
+
+	vr := &versionedRoute{
+		cfg:        cfg,
+		routes:     r.Route("", middleware...).(*router),
+		defaultIdx: len(cfg.Versions) - 1,
+		endpoints:  map[string]map[int]HandlerFunc{},
+	}
+
+	if cfg.DefaultVersion != "" {
+		idx, ok := vr.versionIndex(cfg.DefaultVersion)
+		if !ok {
+			panic(fmt.Sprintf("lit: unknown default API version %q", cfg.DefaultVersion))
+		}
+		vr.defaultIdx = idx
+	}
+
+	return vr
+}
+
+func (vr *versionedRoute) Handle(version string, method string, relativePath string, handler HandlerFunc) VersionedRoute {
+	idx, ok := vr.versionIndex(version)
+	if !ok {
+		panic(fmt.Sprintf("lit: unknown API version %q", version))
+	}
+
+	vr.endpointsMu.Lock()
+	defer vr.endpointsMu.Unlock()
+
+	endpointKey := method + " " + relativePath
+	handlers, exists := vr.endpoints[endpointKey]
+	if !exists {
+		handlers = map[int]HandlerFunc{}
+		vr.endpoints[endpointKey] = handlers
+
+		// Register the dispatcher once per endpoint, the handlers are resolved by version at request time
+		if vr.cfg.PathPrefix {
+			for vIdx, v := range vr.cfg.Versions {
+				vr.routes.Handle(method, "/"+v.Name+relativePath, vr.dispatch(endpointKey, func(Context) (int, error) {
+					return vIdx, nil
+				}))
+			}
+		} else {
+			vr.routes.Handle(method, relativePath, vr.dispatch(endpointKey, vr.resolveVersion))
+		}
+	}
+	handlers[idx] = handler
+
+	return vr
+}
+
+func (vr *versionedRoute) Get(version string, relativePath string, handler HandlerFunc) VersionedRoute {
+	return vr.Handle(version, http.MethodGet, relativePath, handler)
+}
+
+func (vr *versionedRoute) Post(version string, relativePath string, handler HandlerFunc) VersionedRoute {
+	return vr.Handle(version, http.MethodPost, relativePath, handler)
+}
+
+func (vr *versionedRoute) Delete(version string, relativePath string, handler HandlerFunc) VersionedRoute {
+	return vr.Handle(version, http.MethodDelete, relativePath, handler)
+}
+
+func (vr *versionedRoute) Patch(version string, relativePath string, handler HandlerFunc) VersionedRoute {
+	return vr.Handle(version, http.MethodPatch, relativePath, handler)
+}
+
+func (vr *versionedRoute) Put(version string, relativePath string, handler HandlerFunc) VersionedRoute {
+	return vr.Handle(version, http.MethodPut, relativePath, handler)
+}
+
+func (vr *versionedRoute) dispatch(endpointKey string, resolve func(Context) (int, error)) HandlerFunc {
+	return func(c Context) error {
+		if !vr.cfg.PathPrefix {
+			vr.setVaryHeader(c)
+		}
+
+		// 1. Resolve requested version
+		idx, err := resolve(c)
+		if err != nil {
+			return err
+		}
+		version := vr.cfg.Versions[idx]
+		c.SetRequestContext(context.WithValue(c.Request().Context(), apiVersionCtxKey{}, version))
+
+		// 2. Notify deprecated version
+		if version.Deprecated() {
+			vr.notifyDeprecated(c, version)
+		}
+
+		// 3. Fallback to the latest version which is older than the requested one
+		handler := vr.lookup(endpointKey, idx)
+		if handler == nil {
+			return HTTPError{
+				Status: http.StatusNotFound,
+				Code:   "unsupported_api_version",
+				Desc:   fmt.Sprintf("The endpoint is not available in API version %s", version.Name),
+			}
+		}
+
+		return handler(c)
+	}
+}
+
+func (vr *versionedRoute) lookup(endpointKey string, idx int) HandlerFunc {
+	vr.endpointsMu.RLock()
+	defer vr.endpointsMu.RUnlock()
+
+	handlers := vr.endpoints[endpointKey]
+	for ; idx >= 0; idx-- {
+		if h, ok := handlers[idx]; ok {
+			return h
+		}
+	}
+
+	return nil
+}
+
+// resolveVersion resolves the version from the header, then the Accept parameter, then the default version
+func (vr *versionedRoute) resolveVersion(c Context) (int, error) {
+	value := ""
+	if vr.cfg.Header != "" {
+		value = c.Request().Header.Get(vr.cfg.Header)
+	}
+
+	if value == "" && vr.cfg.AcceptParam != "" {
+		value = acceptParam(c.Request().Header.Get(acceptHeader), vr.cfg.AcceptParam)
+	}
+
+	if value == "" {
+		return vr.defaultIdx, nil
+	}
+
+	idx, ok := vr.versionIndex(value)
+	if !ok {
+		return 0, HTTPError{
+			Status: http.StatusBadRequest,
+			Code:   "invalid_api_version",
+			Desc:   fmt.Sprintf("API version %s is not supported", value),
+		}
+	}
+
+	return idx, nil
+}
+
+func (vr *versionedRoute) versionIndex(name string) (int, bool) {
+	name = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), "v")
+	for idx, v := range vr.cfg.Versions {
+		if strings.TrimPrefix(strings.ToLower(v.Name), "v") == name {
+			return idx, true
+		}
+	}
+
+	return 0, false
+}
+
+func (vr *versionedRoute) setVaryHeader(c Context) {
+	header := c.Writer().Header()
+	if vr.cfg.Header != "" {
+		header.Add(varyHeader, vr.cfg.Header)
+	}
+
+	if vr.cfg.AcceptParam != "" {
+		header.Add(varyHeader, acceptHeader)
+	}
+}
+
+func (vr *versionedRoute) notifyDeprecated(c Context, version APIVersion) {
+	header := c.Writer().Header()
+
+	// The version is deprecated by its sunset at the latest when the deprecation time is unknown
+	deprecatedAt := version.DeprecatedAt
+	if deprecatedAt.IsZero() {
+		deprecatedAt = version.Sunset
+	}
+	header.Set(deprecationHeader, "@"+strconv.FormatInt(deprecatedAt.Unix(), 10))
+
+	if !version.Sunset.IsZero() {
+		header.Set(sunsetHeader, version.Sunset.UTC().Format(http.TimeFormat))
+	}
+
+	if version.Link != "" {
+		header.Add(linkHeader, fmt.Sprintf(`<%s>; rel="deprecation"; type="text/html"`, version.Link))
+	}
+
+	ctx := c.Request().Context()
+	monitoring.FromContext(ctx).Info("Deprecated API version requested",
+		monitoring.StringField("api_version", version.Name),
+		monitoring.StringField("http.route", c.FullPath()),
+	)
+	deprecatedVersionRequests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("api.version", version.Name),
+		attribute.String("http.route", c.FullPath()),
+	))
+
+	if vr.cfg.OnDeprecated != nil {
+		vr.cfg.OnDeprecated(c, version)
+	}
+}
+
+// acceptParam returns the value of the parameter from the first media range that contains it
+func acceptParam(accept string, param string) string {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		_, params, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+
+		if value := params[param]; value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,193 @@
+package lit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_Versioned(t *testing.T) {
+	deprecatedAt := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+	versions := []APIVersion{
+		{Name: "v1", DeprecatedAt: deprecatedAt, Sunset: sunset, Link: "https://example.com/migrate-v2"},
+		{Name: "v2"},
+		{Name: "v3"},
+	}
+
+	tcs := map[string]struct {
+		givenConfig     VersioningConfig
+		givenTarget     string
+		givenHeaders    map[string]string
+		expStatus       int
+		expBody         string
+		expHeaders      http.Header
+		expDeprecatedCb bool
+	}{
+		"path - deprecated version": {
+			givenConfig: VersioningConfig{PathPrefix: true},
+			givenTarget: "/v1/orders",
+			expStatus:   http.StatusOK,
+			expBody:     "list v1",
+			expHeaders: http.Header{
+				"Deprecation": []string{"@1735689600"},
+				"Sunset":      []string{"Tue, 01 Jul 2025 00:00:00 GMT"},
+				"Link":        []string{`<https://example.com/migrate-v2>; rel="deprecation"; type="text/html"`},
+			},
+			expDeprecatedCb: true,
+		},
+		"path - fallback to older version": {
+			givenConfig: VersioningConfig{PathPrefix: true},
+			givenTarget: "/v3/orders",
+			expStatus:   http.StatusOK,
+			expBody:     "list v2",
+		},
+		"path - endpoint not available in older version": {
+			givenConfig: VersioningConfig{PathPrefix: true},
+			givenTarget: "/v1/invoices",
+			expStatus:   http.StatusNotFound,
+			expBody:     `{"error":"unsupported_api_version","error_description":"The endpoint is not available in API version v1"}`,
+			expHeaders: http.Header{
+				"Deprecation": []string{"@1735689600"},
+			},
+			expDeprecatedCb: true,
+		},
+		"header - exact version": {
+			givenConfig:  VersioningConfig{Header: "API-Version"},
+			givenTarget:  "/orders",
+			givenHeaders: map[string]string{"API-Version": "2"},
+			expStatus:    http.StatusOK,
+			expBody:      "list v2",
+			expHeaders:   http.Header{"Vary": []string{"API-Version"}},
+		},
+		"header - default latest version": {
+			givenConfig: VersioningConfig{Header: "API-Version"},
+			givenTarget: "/invoices",
+			expStatus:   http.StatusOK,
+			expBody:     "invoices v3",
+		},
+		"header - configured default version": {
+			givenConfig: VersioningConfig{Header: "API-Version", DefaultVersion: "v1"},
+			givenTarget: "/orders",
+			expStatus:   http.StatusOK,
+			expBody:     "list v1",
+			expHeaders: http.Header{
+				"Deprecation": []string{"@1735689600"},
+			},
+			expDeprecatedCb: true,
+		},
+		"header - unknown version": {
+			givenConfig:  VersioningConfig{Header: "API-Version"},
+			givenTarget:  "/orders",
+			givenHeaders: map[string]string{"API-Version": "v9"},
+			expStatus:    http.StatusBadRequest,
+			expBody:      `{"error":"invalid_api_version","error_description":"API version v9 is not supported"}`,
+		},
+		"accept - version parameter": {
+			givenConfig:  VersioningConfig{Header: "API-Version", AcceptParam: "version"},
+			givenTarget:  "/orders",
+			givenHeaders: map[string]string{"Accept": "text/html, application/json; version=1"},
+			expStatus:    http.StatusOK,
+			expBody:      "list v1",
+			expHeaders: http.Header{
+				"Vary":        []string{"API-Version", "Accept"},
+				"Deprecation": []string{"@1735689600"},
+			},
+			expDeprecatedCb: true,
+		},
+	}
+
+	for scenario, tc := range tcs {
+		tc := tc
+		t.Run(scenario, func(t *testing.T) {
+			t.Parallel()
+
+			// Given
+			var deprecatedCalled bool
+			cfg := tc.givenConfig
+			cfg.Versions = versions
+			cfg.OnDeprecated = func(c Context, version APIVersion) {
+				deprecatedCalled = true
+				require.Equal(t, "v1", version.Name)
+			}
+
+			r := NewRouter(context.Background())
+			r.Versioned(cfg).
+				Get("v1", "/orders", func(c Context) error {
+					v, ok := APIVersionFromContext(c)
+					require.True(t, ok)
+					return c.String(http.StatusOK, "list "+v.Name)
+				}).
+				Get("v2", "/orders", func(c Context) error {
+					return c.String(http.StatusOK, "list v2")
+				}).
+				Get("v3", "/invoices", func(c Context) error {
+					return c.String(http.StatusOK, "invoices v3")
+				})
+
+			req := httptest.NewRequest(http.MethodGet, tc.givenTarget, nil)
+			for k, v := range tc.givenHeaders {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			// When
+			r.Handler().ServeHTTP(w, req)
+
+			// Then
+			require.Equal(t, tc.expStatus, w.Code)
+			require.Equal(t, tc.expBody, strings.TrimSpace(w.Body.String()))
+			for k, v := range tc.expHeaders {
+				require.Equal(t, v, w.Header().Values(k))
+			}
+			require.Equal(t, tc.expDeprecatedCb, deprecatedCalled)
+		})
+	}
+}
+
+func TestRouter_Versioned_InvalidConfig(t *testing.T) {
+	r := NewRouter(context.Background())
+
+	require.Panics(t, func() {
+		r.Versioned(VersioningConfig{})
+	})
+	require.Panics(t, func() {
+		r.Versioned(VersioningConfig{Versions: []APIVersion{{Name: "v1"}}, DefaultVersion: "v2"})
+	})
+	require.Panics(t, func() {
+		r.Versioned(VersioningConfig{Versions: []APIVersion{{Name: "v1"}}}).
+			Get("v2", "/orders", func(c Context) error { return nil })
+	})
+}
+
+func TestRouter_Versioned_SunsetOnly(t *testing.T) {
+	// Given
+	sunset := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+	var deprecatedCalled bool
+
+	r := NewRouter(context.Background())
+	r.Versioned(VersioningConfig{
+		Versions:     []APIVersion{{Name: "v1", Sunset: sunset}, {Name: "v2"}},
+		PathPrefix:   true,
+		OnDeprecated: func(c Context, version APIVersion) { deprecatedCalled = true },
+	}).Get("v1", "/orders", func(c Context) error {
+		return c.String(http.StatusOK, "list v1")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/orders", nil)
+	w := httptest.NewRecorder()
+
+	// When
+	r.Handler().ServeHTTP(w, req)
+
+	// Then
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "@1751328000", w.Header().Get("Deprecation"))
+	require.Equal(t, "Tue, 01 Jul 2025 00:00:00 GMT", w.Header().Get("Sunset"))
+	require.True(t, deprecatedCalled)
+}